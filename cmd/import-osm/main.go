@@ -0,0 +1,84 @@
+// import-osm 將本地 OpenStreetMap 資料檔（GeoJSON 或 Overpass JSON）匯入餐廳資料表
+//
+// 使用方式：
+//
+//	osmium tags-filter taiwan.osm.pbf nwr/amenity=restaurant,fast_food,cafe,food_court -o food.osm.pbf
+//	osmium export food.osm.pbf --add-unique-id=type_id -f geojsonseq -o food.geojsonseq
+//	go run ./cmd/import-osm -file food.geojsonseq
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	_ "github.com/lib/pq" // PostgreSQL 驅動程式
+	"github.com/shaunchuang/food-roulette-backend/internal/config"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/repository/postgresql"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/external"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	file := flag.String("file", "", "OpenStreetMap 資料檔路徑（GeoJSON、GeoJSON Sequence 或 Overpass JSON）")
+	dryRun := flag.Bool("dry-run", false, "只解析資料檔，不寫入資料庫")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// 載入配置
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("載入配置失敗:", err)
+	}
+
+	// 初始化日誌
+	if err := logger.Init(cfg.Logger.Level); err != nil {
+		log.Fatal("初始化日誌失敗:", err)
+	}
+	defer logger.Sync()
+
+	offline, err := external.LoadOfflineService(*file)
+	if err != nil {
+		logger.Fatal("載入資料檔失敗", zap.Error(err))
+	}
+	if *dryRun {
+		fmt.Printf("解析完成，共 %d 家餐廳\n", offline.Len())
+		return
+	}
+
+	// 初始化資料庫連接
+	db, err := sql.Open("postgres", cfg.Database.GetDSN())
+	if err != nil {
+		logger.Fatal("資料庫連接失敗", zap.Error(err))
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		logger.Fatal("資料庫連接測試失敗", zap.Error(err))
+	}
+
+	restaurantRepo := postgresql.NewRestaurantRepository(db)
+	cuisineUseCase := usecase.NewCuisineUseCase(postgresql.NewCuisineRepository(db))
+	importUseCase := usecase.NewRestaurantImportUseCase(restaurantRepo, cuisineUseCase)
+
+	// 中斷時停止匯入，已匯入的批次保留
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	summary, err := importUseCase.Import(ctx, offline.Restaurants(), domain.RestaurantSourceOSM)
+	fmt.Printf("新增 %d、更新 %d、未變動 %d、失敗 %d\n", summary.Created, summary.Updated, summary.Unchanged, summary.Failed)
+	if err != nil {
+		logger.Fatal("匯入中斷", zap.Error(err))
+	}
+}
@@ -1,17 +1,24 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq" // PostgreSQL 驅動程式
 	"github.com/shaunchuang/food-roulette-backend/internal/config"
 	"github.com/shaunchuang/food-roulette-backend/internal/delivery/http"
 	"github.com/shaunchuang/food-roulette-backend/internal/delivery/http/handler"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/internal/repository/postgresql"
 	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/internal/worker"
 	"github.com/shaunchuang/food-roulette-backend/pkg/auth"
+	"github.com/shaunchuang/food-roulette-backend/pkg/cache"
 	"github.com/shaunchuang/food-roulette-backend/pkg/external"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
 	"go.uber.org/zap"
@@ -57,30 +64,116 @@ func main() {
 	favoriteRepo := postgresql.NewFavoriteRepository(db)
 	gameRepo := postgresql.NewGameRepository(db)
 	adRepo := postgresql.NewAdvertisementRepository(db)
+	statsRepo := postgresql.NewStatsRepository(db)
+	searchLogRepo := postgresql.NewSearchLogRepository(db)
+	coverageRepo := postgresql.NewCoverageRepository(db)
+	activityRepo := postgresql.NewActivityRepository(db)
+	openingHoursRepo := postgresql.NewOpeningHoursRepository(db)
+	closureRepo := postgresql.NewClosureRepository(db)
+	tagRepo := postgresql.NewTagRepository(db)
+	cuisineRepo := postgresql.NewCuisineRepository(db)
+	menuRepo := postgresql.NewMenuRepository(db)
+	reviewRepo := postgresql.NewReviewRepository(db)
+	mergeRepo := postgresql.NewRestaurantMergeRepository(db)
+	apiUsageRepo := postgresql.NewAPIUsageRepository(db)
 
 	// 初始化 Services
 	authService := auth.NewJWTService(cfg.Auth.Secret)
-	var externalAPIService usecase.ExternalAPIService
-	if cfg.GoogleAPI.PlacesAPIKey != "" {
-		externalAPIService = external.NewGooglePlacesService(cfg.GoogleAPI.PlacesAPIKey)
+	apiUsageUseCase := usecase.NewAPIUsageUseCase(apiUsageRepo, map[string]usecase.APIBudget{
+		domain.RestaurantSourceGoogle: {Daily: cfg.GoogleAPI.DailyBudget, Monthly: cfg.GoogleAPI.MonthlyBudget},
+	})
+	externalAPIService, err := buildExternalAPIService(cfg, apiUsageUseCase)
+	if err != nil {
+		logger.Fatal("外部資料來源設定錯誤", zap.Error(err))
+	}
+	placeCache, err := buildPlaceCache(cfg, externalAPIService)
+	if err != nil {
+		logger.Fatal("外部地點搜尋快取設定錯誤", zap.Error(err))
+	}
+	if placeCache != nil {
+		externalAPIService = placeCache
+	}
+	placePhotoUseCase, err := buildPlacePhotoUseCase(cfg, apiUsageUseCase, restaurantRepo)
+	if err != nil {
+		logger.Fatal("餐廳照片快取設定錯誤", zap.Error(err))
+	}
+
+	// 初始化遊戲抽選權重策略
+	gameSettings, err := buildGameSettings(cfg.Game)
+	if err != nil {
+		logger.Fatal("遊戲權重策略設定錯誤", zap.Error(err))
 	}
 
 	// 初始化 Use Cases
 	userUseCase := usecase.NewUserUseCase(userRepo, authService)
-	restaurantUseCase := usecase.NewRestaurantUseCase(restaurantRepo, favoriteRepo, externalAPIService)
-	gameUseCase := usecase.NewGameUseCase(gameRepo, restaurantRepo, favoriteRepo, adRepo)
+	cuisineUseCase := usecase.NewCuisineUseCase(cuisineRepo)
+	menuUseCase := usecase.NewMenuUseCase(menuRepo, tagRepo, restaurantRepo, cfg.Restaurant.PriceLevelThresholds)
+	restaurantUseCase := usecase.NewRestaurantUseCase(restaurantRepo, favoriteRepo, openingHoursRepo, searchLogRepo, externalAPIService, cuisineUseCase, menuUseCase)
+	gameUseCase := usecase.NewGameUseCase(gameRepo, restaurantRepo, favoriteRepo, adRepo, searchLogRepo, externalAPIService, cuisineUseCase, gameSettings)
 	adUseCase := usecase.NewAdvertisementUseCase(adRepo)
+	statsUseCase := usecase.NewStatsUseCase(statsRepo, cuisineUseCase)
+	closureUseCase := usecase.NewClosureUseCase(closureRepo, restaurantRepo, cfg.Restaurant.ClosureReportThreshold)
+	tagUseCase := usecase.NewTagUseCase(tagRepo, restaurantRepo)
+	reviewUseCase := usecase.NewReviewUseCase(reviewRepo, restaurantRepo)
+	naturalQueryUseCase := usecase.NewNaturalQueryUseCase(restaurantUseCase, cuisineUseCase, tagRepo)
+	mergeUseCase := usecase.NewRestaurantMergeUseCase(mergeRepo, domain.DuplicateDetectionSettings{
+		MaxDistance:       cfg.Restaurant.DuplicateMaxDistance,
+		MinNameSimilarity: cfg.Restaurant.DuplicateMinSimilarity,
+	})
+	analyticsUseCase := usecase.NewAnalyticsUseCase(coverageRepo, activityRepo, usecase.AnalyticsSettings{
+		CoverageLookback:      time.Duration(cfg.Analytics.CoverageLookbackDays) * 24 * time.Hour,
+		ActivityRecomputeDays: cfg.Analytics.ActivityRecomputeDays,
+		HeatmapMinCellCount:   cfg.Analytics.HeatmapMinCellCount,
+	})
 
 	// 初始化 Handlers
 	userHandler := handler.NewUserHandler(userUseCase)
 	restaurantHandler := handler.NewRestaurantHandler(restaurantUseCase)
 	gameHandler := handler.NewGameHandler(gameUseCase)
 	adHandler := handler.NewAdvertisementHandler(adUseCase)
+	statsHandler := handler.NewStatsHandler(statsUseCase)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsUseCase)
+	closureHandler := handler.NewClosureHandler(closureUseCase)
+	tagHandler := handler.NewTagHandler(tagUseCase)
+	cuisineHandler := handler.NewCuisineHandler(cuisineUseCase)
+	menuHandler := handler.NewMenuHandler(menuUseCase)
+	reviewHandler := handler.NewReviewHandler(reviewUseCase)
+	naturalQueryHandler := handler.NewNaturalQueryHandler(naturalQueryUseCase)
+	mergeHandler := handler.NewRestaurantMergeHandler(mergeUseCase)
+	placeCacheHandler := handler.NewPlaceCacheHandler(placeCache)
+	apiUsageHandler := handler.NewAPIUsageHandler(apiUsageUseCase)
+	mediaHandler := handler.NewMediaHandler(placePhotoUseCase)
 
 	// 初始化路由器
-	router := http.NewRouter(userHandler, restaurantHandler, gameHandler, adHandler)
+	router := http.NewRouter(userHandler, restaurantHandler, gameHandler, adHandler, statsHandler, analyticsHandler, closureHandler, tagHandler, cuisineHandler, menuHandler, reviewHandler, naturalQueryHandler, mergeHandler, placeCacheHandler, apiUsageHandler, mediaHandler)
 	router.SetupRoutes(engine, authService, userUseCase)
 
+	// 啟動背景排程
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+
+	coverageWorker := worker.NewPeriodicWorker("coverage", analyticsUseCase.RefreshCoverage, time.Duration(cfg.Analytics.CoverageRefreshMinutes)*time.Minute)
+	go coverageWorker.Start(workerCtx)
+
+	activityWorker := worker.NewPeriodicWorker("activity", analyticsUseCase.RefreshActivity, time.Duration(cfg.Analytics.ActivityRefreshMinutes)*time.Minute)
+	go activityWorker.Start(workerCtx)
+
+	duplicateWorker := worker.NewPeriodicWorker("restaurant-duplicates", mergeUseCase.DetectDuplicates, time.Duration(cfg.Restaurant.DuplicateScanMinutes)*time.Minute)
+	go duplicateWorker.Start(workerCtx)
+
+	photoCleanupWorker := worker.NewPeriodicWorker("place-photo-cleanup", placePhotoUseCase.PruneCache, time.Duration(cfg.PlacePhoto.CleanupMinutes)*time.Minute)
+	go photoCleanupWorker.Start(workerCtx)
+
+	// 外部資料同步需要至少一個可用的資料來源
+	if externalAPIService != nil {
+		syncUseCase := usecase.NewProviderSyncUseCase(restaurantRepo, externalAPIService, cuisineUseCase, usecase.ProviderSyncSettings{
+			MaxAge: time.Duration(cfg.Restaurant.SyncMaxAgeDays) * 24 * time.Hour,
+			Budget: cfg.Restaurant.SyncBudget,
+		})
+		syncWorker := worker.NewPeriodicWorker("restaurant-sync", syncUseCase.RefreshStale, time.Duration(cfg.Restaurant.SyncIntervalMinutes)*time.Minute)
+		go syncWorker.Start(workerCtx)
+	}
+
 	// 啟動伺服器
 	serverAddr := cfg.Server.Host + ":" + cfg.Server.Port
 	logger.Info("伺服器啟動",
@@ -92,3 +185,185 @@ func main() {
 		logger.Fatal("伺服器啟動失敗", zap.Error(err))
 	}
 }
+
+// googlePlacesOptions 依設定建立 Google Places 請求選項，請求經由 apiUsage 控管額度
+func googlePlacesOptions(cfg *config.Config, apiUsage *usecase.APIUsageUseCase) external.GooglePlacesOptions {
+	return external.GooglePlacesOptions{
+		MaxPages:             cfg.GoogleAPI.PlacesMaxPages,
+		Language:             cfg.GoogleAPI.PlacesLanguage,
+		PriceLevelThresholds: cfg.Restaurant.PriceLevelThresholds,
+		Resilience: external.ResilienceOptions{
+			Timeout:          time.Duration(cfg.GoogleAPI.TimeoutSeconds) * time.Second,
+			MaxRetries:       cfg.GoogleAPI.MaxRetries,
+			RetryBaseDelay:   time.Duration(cfg.GoogleAPI.RetryBaseDelayMs) * time.Millisecond,
+			BreakerThreshold: cfg.GoogleAPI.BreakerThreshold,
+			BreakerCooldown:  time.Duration(cfg.GoogleAPI.BreakerCooldownSeconds) * time.Second,
+		},
+		Quota: apiUsage,
+	}
+}
+
+// buildPlacePhotoUseCase 建立餐廳照片代理用例，只下載餐廳資料中使用的照片；未設定 Google API 金鑰時只提供已快取的照片
+func buildPlacePhotoUseCase(cfg *config.Config, apiUsage *usecase.APIUsageUseCase, restaurantRepo usecase.RestaurantRepository) (*usecase.PlacePhotoUseCase, error) {
+	photoCache, err := cache.NewDiskPhotoCache(cfg.PlacePhoto.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var photoFetcher usecase.PlacePhotoFetcher
+	if cfg.GoogleAPI.PlacesAPIKey != "" {
+		photoFetcher = external.NewGooglePhotoService(cfg.GoogleAPI.PlacesAPIKey, googlePlacesOptions(cfg, apiUsage))
+	}
+
+	return usecase.NewPlacePhotoUseCase(photoFetcher, photoCache, restaurantRepo, usecase.PlacePhotoSettings{
+		MaxWidth: cfg.PlacePhoto.MaxWidth,
+		CacheTTL: time.Duration(cfg.PlacePhoto.CacheTTLHours) * time.Hour,
+	}), nil
+}
+
+// buildExternalAPIService 依設定的資料來源順序建立外部餐廳資料服務，沒有可用的資料來源時回傳 nil
+// Google Places 的請求會經由 apiUsage 控管額度並回報斷路器狀態
+func buildExternalAPIService(cfg *config.Config, apiUsage *usecase.APIUsageUseCase) (usecase.ExternalAPIService, error) {
+	var providers []external.NamedProvider
+	for _, name := range cfg.Providers.Order {
+		name = strings.TrimSpace(name)
+		var provider external.RestaurantProvider
+		switch name {
+		case "":
+			continue
+		case domain.RestaurantSourceGoogle:
+			if cfg.GoogleAPI.PlacesAPIKey == "" {
+				logger.Warn("未設定 GOOGLE_PLACES_API_KEY，略過 Google Places")
+				continue
+			}
+			options := googlePlacesOptions(cfg, apiUsage)
+			switch cfg.GoogleAPI.PlacesAPIVersion {
+			case "", "legacy":
+				google := external.NewGooglePlacesService(cfg.GoogleAPI.PlacesAPIKey, options)
+				apiUsage.WatchCircuit(name, google)
+				provider = google
+			case "new":
+				google := external.NewGooglePlacesNewService(cfg.GoogleAPI.PlacesAPIKey, options)
+				apiUsage.WatchCircuit(name, google)
+				provider = google
+			default:
+				return nil, fmt.Errorf("未知的 Google Places API 版本: %s", cfg.GoogleAPI.PlacesAPIVersion)
+			}
+		case domain.RestaurantSourceOSM:
+			provider = external.NewOverpassService(cfg.Providers.OverpassURL)
+		case domain.RestaurantSourceFoursquare:
+			if cfg.Providers.FoursquareAPIKey == "" {
+				logger.Warn("未設定 FOURSQUARE_API_KEY，略過 Foursquare")
+				continue
+			}
+			provider = external.NewFoursquareService(cfg.Providers.FoursquareAPIKey, cfg.GoogleAPI.PlacesLanguage)
+		case "offline":
+			if cfg.Providers.OfflineFile == "" {
+				logger.Warn("未設定 OFFLINE_PROVIDER_FILE，略過離線資料來源")
+				continue
+			}
+			offline, err := external.LoadOfflineService(cfg.Providers.OfflineFile)
+			if err != nil {
+				return nil, err
+			}
+			// 離線資料的地點 ID 與 OpenStreetMap 相同，以 osm 名稱取得詳細資訊
+			name, provider = domain.RestaurantSourceOSM, offline
+		default:
+			return nil, fmt.Errorf("未知的外部資料來源: %s", name)
+		}
+		providers = append(providers, external.NamedProvider{Name: name, Provider: provider})
+	}
+
+	switch cfg.Providers.Mode {
+	case external.CompositeModeSequential, external.CompositeModeParallel:
+	default:
+		return nil, fmt.Errorf("未知的資料來源查詢模式: %s", cfg.Providers.Mode)
+	}
+
+	switch len(providers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return providers[0].Provider, nil
+	default:
+		return external.NewCompositeService(providers, external.CompositeOptions{
+			Mode:          cfg.Providers.Mode,
+			MinResults:    cfg.Providers.MinResults,
+			QuotaCooldown: time.Duration(cfg.Providers.QuotaCooldownMinutes) * time.Minute,
+			Dedupe: domain.DuplicateDetectionSettings{
+				MaxDistance:       cfg.Restaurant.DuplicateMaxDistance,
+				MinNameSimilarity: cfg.Restaurant.DuplicateMinSimilarity,
+			},
+		}), nil
+	}
+}
+
+// buildPlaceCache 依配置建立外部地點搜尋快取，未設定外部資料來源或停用快取時回傳 nil
+func buildPlaceCache(cfg *config.Config, externalAPIService usecase.ExternalAPIService) (*usecase.CachedExternalAPI, error) {
+	if externalAPIService == nil {
+		return nil, nil
+	}
+
+	var store usecase.PlaceSearchCache
+	backend := cfg.PlaceCache.Backend
+	switch backend {
+	case "none":
+		return nil, nil
+	case "", "memory":
+		backend = "memory"
+		store = cache.NewLRUPlaceCache(cfg.PlaceCache.MaxEntries)
+	case "redis":
+		redisCache, err := cache.NewRedisPlaceCache(cfg.Redis.URL, cfg.Redis.Password)
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := redisCache.Ping(ctx); err != nil {
+			return nil, fmt.Errorf("Redis 連線失敗: %w", err)
+		}
+		store = redisCache
+	default:
+		return nil, fmt.Errorf("未知的快取後端: %s", backend)
+	}
+
+	return usecase.NewCachedExternalAPI(externalAPIService, store, usecase.PlaceCacheSettings{
+		Backend:   backend,
+		TTL:       time.Duration(cfg.PlaceCache.TTLMinutes) * time.Minute,
+		Precision: cfg.PlaceCache.Precision,
+	}), nil
+}
+
+// buildGameSettings 依配置建立遊戲用例設定
+func buildGameSettings(cfg config.GameConfig) (usecase.GameSettings, error) {
+	novelty := usecase.NoveltyWeightingConfig{
+		NoveltyBoost: cfg.Weighting.NoveltyBoost,
+		WinPenalty:   cfg.Weighting.WinPenalty,
+		VetoPenalty:  cfg.Weighting.VetoPenalty,
+		HalfLifeDays: cfg.Weighting.HalfLifeDays,
+		CuisineBias:  cfg.Weighting.CuisineBias,
+	}
+
+	settings := usecase.GameSettings{
+		MaxRestaurantsPerRound: cfg.MaxRestaurantsPerRound,
+		Weighting:              make(map[domain.GameType]usecase.WeightingStrategy),
+		MaxSearchRadius:        cfg.MaxSearchRadius,
+		RadiusExpansionFactor:  cfg.RadiusExpansionFactor,
+	}
+
+	defaultStrategy, err := usecase.NewWeightingStrategy(cfg.Weighting.DefaultStrategy, novelty)
+	if err != nil {
+		return settings, err
+	}
+	settings.DefaultWeighting = defaultStrategy
+
+	for gameType, name := range cfg.Weighting.Overrides {
+		strategy, err := usecase.NewWeightingStrategy(name, novelty)
+		if err != nil {
+			return settings, err
+		}
+		settings.Weighting[domain.GameType(gameType)] = strategy
+	}
+
+	return settings, nil
+}
@@ -21,6 +21,11 @@ type Config struct {
 	RateLimit     RateLimitConfig
 	Game          GameConfig
 	Advertisement AdvertisementConfig
+	Analytics     AnalyticsConfig
+	Restaurant    RestaurantConfig
+	Providers     ProvidersConfig
+	PlaceCache    PlaceCacheConfig
+	PlacePhoto    PlacePhotoConfig
 }
 
 // ServerConfig HTTP 伺服器配置
@@ -53,7 +58,45 @@ type AuthConfig struct {
 
 // GoogleAPIConfig Google API 配置
 type GoogleAPIConfig struct {
-	PlacesAPIKey string
+	PlacesAPIKey     string
+	PlacesAPIVersion string // legacy（舊版 Places API）或 new（Places API (New)）
+	PlacesMaxPages   int    // 搜尋最多讀取的頁數（每頁 20 筆，最多 3 頁）
+	PlacesLanguage   string // Google Places 回傳結果的語言
+
+	TimeoutSeconds         int // 單次請求逾時（秒）
+	MaxRetries             int // 暫時性錯誤的重試次數
+	RetryBaseDelayMs       int // 重試等待時間基準（毫秒），每次重試加倍並加上隨機抖動
+	BreakerThreshold       int // 連續失敗達此次數時暫停請求，0 表示停用斷路器
+	BreakerCooldownSeconds int // 斷路器開啟後暫停請求的時間（秒）
+	DailyBudget            int // 每日請求額度（UTC），0 表示不限
+	MonthlyBudget          int // 每月請求額度（UTC），0 表示不限
+}
+
+// ProvidersConfig 外部餐廳資料來源配置
+type ProvidersConfig struct {
+	Order                []string // 依優先順序使用的資料來源：google、osm、foursquare、offline
+	Mode                 string   // sequential（依序查詢，結果足夠即停止）或 parallel（同時查詢後合併）
+	MinResults           int      // sequential 模式下結果達此數量即不再查詢下一個資料來源
+	QuotaCooldownMinutes int      // 資料來源額度用盡後暫停使用的時間（分鐘）
+	OverpassURL          string   // OpenStreetMap Overpass API 端點
+	FoursquareAPIKey     string
+	OfflineFile          string // 離線資料來源使用的本地 GeoJSON 或 Overpass JSON 檔案
+}
+
+// PlaceCacheConfig 外部地點搜尋快取配置
+type PlaceCacheConfig struct {
+	Backend    string // memory（記憶體 LRU）、redis 或 none（停用）
+	TTLMinutes int    // 搜尋結果保留時間（分鐘）
+	Precision  int    // 快取格網的 geohash 精度
+	MaxEntries int    // memory 後端最多保留的搜尋結果數
+}
+
+// PlacePhotoConfig 餐廳照片代理配置
+type PlacePhotoConfig struct {
+	CacheDir       string // 照片快取目錄
+	CacheTTLHours  int    // 照片快取保留時間（小時）
+	MaxWidth       int    // 向外部資料來源要求的照片寬度（像素）
+	CleanupMinutes int    // 清除過期照片的排程間隔（分鐘）
 }
 
 // RedisConfig Redis 配置
@@ -85,6 +128,20 @@ type RateLimitConfig struct {
 type GameConfig struct {
 	MaxRestaurantsPerRound int
 	SessionTimeoutMinutes  int
+	Weighting              WeightingConfig
+	MaxSearchRadius        int     // 找不到餐廳時擴大搜尋的半徑上限（公尺）
+	RadiusExpansionFactor  float64 // 每次擴大搜尋半徑的倍數
+}
+
+// WeightingConfig 候選餐廳抽選權重配置
+type WeightingConfig struct {
+	DefaultStrategy string            // uniform 或 novelty
+	Overrides       map[string]string // 依遊戲類型覆寫的策略，例如 dice:uniform
+	NoveltyBoost    float64
+	WinPenalty      float64
+	VetoPenalty     float64
+	HalfLifeDays    float64
+	CuisineBias     float64
 }
 
 // AdvertisementConfig 廣告配置
@@ -93,6 +150,27 @@ type AdvertisementConfig struct {
 	ClickCooldownSeconds int
 }
 
+// AnalyticsConfig 分析報表配置
+type AnalyticsConfig struct {
+	CoverageRefreshMinutes int // 覆蓋格網重建間隔（分鐘），0 表示停用排程
+	CoverageLookbackDays   int // 覆蓋格網參考的搜尋記錄天數
+	ActivityRefreshMinutes int // 遊戲活動熱度彙總間隔（分鐘），0 表示停用排程
+	ActivityRecomputeDays  int // 每次彙總重新計算的天數（含今日）
+	HeatmapMinCellCount    int // 熱度格網最低顯示次數（隱私保護）
+}
+
+// RestaurantConfig 餐廳資料配置
+type RestaurantConfig struct {
+	ClosureReportThreshold int       // 同一天回報休業達此人數時自動標記休業
+	PriceLevelThresholds   []float64 // 由菜單價格中位數計算價位等級的分界（等級 1/2、2/3、3/4）
+	DuplicateScanMinutes   int       // 重複餐廳偵測間隔（分鐘），0 表示停用排程
+	DuplicateMaxDistance   float64   // 視為重複的最大距離（公尺）
+	DuplicateMinSimilarity float64   // 視為重複的名稱最低相似度（0-1）
+	SyncIntervalMinutes    int       // 外部資料同步排程間隔（分鐘），0 表示停用排程
+	SyncMaxAgeDays         int       // 超過此天數未同步的餐廳會重新取得外部資料
+	SyncBudget             int       // 每次同步排程最多發出的外部請求數
+}
+
 // Load 載入配置，優先從環境變數讀取，其次從 .env 檔案
 func Load() (*Config, error) {
 	// 嘗試載入 .env 檔案（如果存在的話）
@@ -125,7 +203,18 @@ func Load() (*Config, error) {
 			ExpiresIn: getEnv("JWT_EXPIRES_IN", "24h"),
 		},
 		GoogleAPI: GoogleAPIConfig{
-			PlacesAPIKey: getEnv("GOOGLE_PLACES_API_KEY", ""),
+			PlacesAPIKey:     getEnv("GOOGLE_PLACES_API_KEY", ""),
+			PlacesAPIVersion: getEnv("GOOGLE_PLACES_API_VERSION", "legacy"),
+			PlacesMaxPages:   getEnvInt("GOOGLE_PLACES_MAX_PAGES", 3),
+			PlacesLanguage:   getEnv("GOOGLE_PLACES_LANGUAGE", "zh-TW"),
+
+			TimeoutSeconds:         getEnvInt("GOOGLE_API_TIMEOUT_SECONDS", 10),
+			MaxRetries:             getEnvInt("GOOGLE_API_MAX_RETRIES", 2),
+			RetryBaseDelayMs:       getEnvInt("GOOGLE_API_RETRY_BASE_DELAY_MS", 200),
+			BreakerThreshold:       getEnvInt("GOOGLE_API_BREAKER_THRESHOLD", 5),
+			BreakerCooldownSeconds: getEnvInt("GOOGLE_API_BREAKER_COOLDOWN_SECONDS", 60),
+			DailyBudget:            getEnvInt("GOOGLE_API_DAILY_BUDGET", 0),
+			MonthlyBudget:          getEnvInt("GOOGLE_API_MONTHLY_BUDGET", 0),
 		},
 		Redis: RedisConfig{
 			URL:      getEnv("REDIS_URL", "redis://localhost:6379"),
@@ -147,11 +236,60 @@ func Load() (*Config, error) {
 		Game: GameConfig{
 			MaxRestaurantsPerRound: getEnvInt("GAME_MAX_RESTAURANTS_PER_ROUND", 10),
 			SessionTimeoutMinutes:  getEnvInt("GAME_SESSION_TIMEOUT_MINUTES", 30),
+			MaxSearchRadius:        getEnvInt("GAME_MAX_SEARCH_RADIUS", 10000),
+			RadiusExpansionFactor:  getEnvFloat("GAME_RADIUS_EXPANSION_FACTOR", 2),
+			Weighting: WeightingConfig{
+				DefaultStrategy: getEnv("GAME_WEIGHTING_STRATEGY", "novelty"),
+				Overrides:       getEnvMap("GAME_WEIGHTING_OVERRIDES", ""),
+				NoveltyBoost:    getEnvFloat("GAME_WEIGHTING_NOVELTY_BOOST", 1.5),
+				WinPenalty:      getEnvFloat("GAME_WEIGHTING_WIN_PENALTY", 0.7),
+				VetoPenalty:     getEnvFloat("GAME_WEIGHTING_VETO_PENALTY", 0.9),
+				HalfLifeDays:    getEnvFloat("GAME_WEIGHTING_HALF_LIFE_DAYS", 7),
+				CuisineBias:     getEnvFloat("GAME_WEIGHTING_CUISINE_BIAS", 0.5),
+			},
 		},
 		Advertisement: AdvertisementConfig{
 			ViewCooldownSeconds:  getEnvInt("AD_VIEW_COOLDOWN_SECONDS", 30),
 			ClickCooldownSeconds: getEnvInt("AD_CLICK_COOLDOWN_SECONDS", 60),
 		},
+		Analytics: AnalyticsConfig{
+			CoverageRefreshMinutes: getEnvInt("ANALYTICS_COVERAGE_REFRESH_MINUTES", 60),
+			CoverageLookbackDays:   getEnvInt("ANALYTICS_COVERAGE_LOOKBACK_DAYS", 30),
+			ActivityRefreshMinutes: getEnvInt("ANALYTICS_ACTIVITY_REFRESH_MINUTES", 60),
+			ActivityRecomputeDays:  getEnvInt("ANALYTICS_ACTIVITY_RECOMPUTE_DAYS", 2),
+			HeatmapMinCellCount:    getEnvInt("ANALYTICS_HEATMAP_MIN_CELL_COUNT", 5),
+		},
+		Restaurant: RestaurantConfig{
+			ClosureReportThreshold: getEnvInt("RESTAURANT_CLOSURE_REPORT_THRESHOLD", 3),
+			PriceLevelThresholds:   getEnvFloatList("RESTAURANT_PRICE_LEVEL_THRESHOLDS", []float64{150, 400, 1000}),
+			DuplicateScanMinutes:   getEnvInt("RESTAURANT_DUPLICATE_SCAN_MINUTES", 1440),
+			DuplicateMaxDistance:   getEnvFloat("RESTAURANT_DUPLICATE_MAX_DISTANCE", 80),
+			DuplicateMinSimilarity: getEnvFloat("RESTAURANT_DUPLICATE_MIN_SIMILARITY", 0.5),
+			SyncIntervalMinutes:    getEnvInt("RESTAURANT_SYNC_INTERVAL_MINUTES", 60),
+			SyncMaxAgeDays:         getEnvInt("RESTAURANT_SYNC_MAX_AGE_DAYS", 30),
+			SyncBudget:             getEnvInt("RESTAURANT_SYNC_BUDGET", 100),
+		},
+		Providers: ProvidersConfig{
+			Order:                strings.Split(getEnv("EXTERNAL_PROVIDERS", "google"), ","),
+			Mode:                 getEnv("EXTERNAL_PROVIDER_MODE", "sequential"),
+			MinResults:           getEnvInt("EXTERNAL_PROVIDER_MIN_RESULTS", 10),
+			QuotaCooldownMinutes: getEnvInt("EXTERNAL_PROVIDER_QUOTA_COOLDOWN_MINUTES", 60),
+			OverpassURL:          getEnv("OVERPASS_API_URL", "https://overpass-api.de/api/interpreter"),
+			FoursquareAPIKey:     getEnv("FOURSQUARE_API_KEY", ""),
+			OfflineFile:          getEnv("OFFLINE_PROVIDER_FILE", ""),
+		},
+		PlaceCache: PlaceCacheConfig{
+			Backend:    getEnv("PLACE_CACHE_BACKEND", "memory"),
+			TTLMinutes: getEnvInt("PLACE_CACHE_TTL_MINUTES", 60),
+			Precision:  getEnvInt("PLACE_CACHE_GEOHASH_PRECISION", 6),
+			MaxEntries: getEnvInt("PLACE_CACHE_MAX_ENTRIES", 10000),
+		},
+		PlacePhoto: PlacePhotoConfig{
+			CacheDir:       getEnv("PLACE_PHOTO_CACHE_DIR", "./data/photos"),
+			CacheTTLHours:  getEnvInt("PLACE_PHOTO_CACHE_TTL_HOURS", 168),
+			MaxWidth:       getEnvInt("PLACE_PHOTO_MAX_WIDTH", 400),
+			CleanupMinutes: getEnvInt("PLACE_PHOTO_CLEANUP_MINUTES", 1440),
+		},
 	}
 
 	return config, nil
@@ -185,6 +323,46 @@ func getEnvInt64(key string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+// getEnvFloat 取得浮點數型環境變數，如果不存在或無效則使用預設值
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvFloatList 取得以逗號分隔的浮點數環境變數，任一值無效則使用預設值
+func getEnvFloatList(key string, defaultValue []float64) []float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []float64
+	for _, part := range strings.Split(value, ",") {
+		floatValue, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return defaultValue
+		}
+		result = append(result, floatValue)
+	}
+	return result
+}
+
+// getEnvMap 取得 key:value 以逗號分隔的環境變數
+func getEnvMap(key, defaultValue string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(getEnv(key, defaultValue), ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) == 2 && parts[0] != "" {
+			result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return result
+}
+
 // GetDSN 取得資料庫連接字串
 func (c *DatabaseConfig) GetDSN() string {
 	return "host=" + c.Host +
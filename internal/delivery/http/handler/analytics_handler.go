@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// AnalyticsHandler 管理分析 HTTP 處理器
+type AnalyticsHandler struct {
+	analyticsUseCase *usecase.AnalyticsUseCase
+}
+
+// NewAnalyticsHandler 建立管理分析處理器
+func NewAnalyticsHandler(analyticsUseCase *usecase.AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+	}
+}
+
+// GetCoverage 取得美食沙漠覆蓋地圖（GeoJSON）
+func (h *AnalyticsHandler) GetCoverage(c *gin.Context) {
+	var query domain.CoverageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error("覆蓋地圖請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateStruct(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return
+	}
+
+	collection, err := h.analyticsUseCase.GetCoverageMap(c.Request.Context(), &query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// RefreshCoverage 立即重建美食沙漠覆蓋格網
+func (h *AnalyticsHandler) RefreshCoverage(c *gin.Context) {
+	count, err := h.analyticsUseCase.RefreshCoverage(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "覆蓋格網更新成功",
+		"cell_count": count,
+	})
+}
+
+// GetActivityHeatmap 取得遊戲活動熱度地圖（GeoJSON）
+func (h *AnalyticsHandler) GetActivityHeatmap(c *gin.Context) {
+	var req domain.HeatmapRequest
+	if !h.bindHeatmapRequest(c, &req) {
+		return
+	}
+
+	collection, err := h.analyticsUseCase.GetActivityHeatmap(c.Request.Context(), &req)
+	if err != nil {
+		h.respondHeatmapError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// GetRestaurantHeatmap 取得選中指定餐廳的玩家來源熱度地圖（GeoJSON）
+func (h *AnalyticsHandler) GetRestaurantHeatmap(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.HeatmapRequest
+	if !h.bindHeatmapRequest(c, &req) {
+		return
+	}
+
+	collection, err := h.analyticsUseCase.GetRestaurantHeatmap(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		h.respondHeatmapError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// RefreshActivity 立即重新彙總遊戲活動熱度
+func (h *AnalyticsHandler) RefreshActivity(c *gin.Context) {
+	count, err := h.analyticsUseCase.RefreshActivity(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "遊戲活動熱度更新成功",
+		"cell_count": count,
+	})
+}
+
+// bindHeatmapRequest 綁定並驗證熱度地圖查詢參數
+func (h *AnalyticsHandler) bindHeatmapRequest(c *gin.Context, req *domain.HeatmapRequest) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		logger.Error("熱度地圖請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return false
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondHeatmapError 回傳熱度地圖錯誤
+func (h *AnalyticsHandler) respondHeatmapError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, domain.ErrInvalidDateRange) || errors.Is(err, domain.ErrInvalidActivityKind) {
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// APIUsageHandler 外部 API 用量 HTTP 處理器
+type APIUsageHandler struct {
+	apiUsageUseCase *usecase.APIUsageUseCase
+}
+
+// NewAPIUsageHandler 建立外部 API 用量處理器
+func NewAPIUsageHandler(apiUsageUseCase *usecase.APIUsageUseCase) *APIUsageHandler {
+	return &APIUsageHandler{
+		apiUsageUseCase: apiUsageUseCase,
+	}
+}
+
+// GetUsage 取得外部 API 目前的每日與每月用量、額度及斷路器狀態
+func (h *APIUsageHandler) GetUsage(c *gin.Context) {
+	usages, err := h.apiUsageUseCase.GetUsage(c.Request.Context())
+	if err != nil {
+		logger.Error("取得外部 API 用量失敗", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "取得外部 API 用量失敗",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"providers": usages,
+	})
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// ClosureHandler 餐廳休業 HTTP 處理器
+type ClosureHandler struct {
+	closureUseCase *usecase.ClosureUseCase
+}
+
+// NewClosureHandler 建立餐廳休業處理器
+func NewClosureHandler(closureUseCase *usecase.ClosureUseCase) *ClosureHandler {
+	return &ClosureHandler{
+		closureUseCase: closureUseCase,
+	}
+}
+
+// ListClosures 取得餐廳休業記錄
+func (h *ClosureHandler) ListClosures(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	closures, err := h.closureUseCase.ListClosures(c.Request.Context(), restaurantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"closures": closures,
+		"count":    len(closures),
+	})
+}
+
+// ReportClosed 回報餐廳今日休業
+func (h *ClosureHandler) ReportClosed(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	result, err := h.closureUseCase.ReportClosed(c.Request.Context(), userID.(int), restaurantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "回報休業成功",
+		"report":  result,
+	})
+}
+
+// CreateClosure 建立餐廳休業記錄（管理功能）
+func (h *ClosureHandler) CreateClosure(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.CreateClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("建立休業請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return
+	}
+
+	closure, err := h.closureUseCase.CreateClosure(c.Request.Context(), userID.(int), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "建立休業記錄成功",
+		"closure": closure,
+	})
+}
+
+// DeleteClosure 刪除餐廳休業記錄（管理功能）
+func (h *ClosureHandler) DeleteClosure(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	closureID, err := strconv.Atoi(c.Param("closure_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的休業記錄 ID",
+		})
+		return
+	}
+
+	if err := h.closureUseCase.DeleteClosure(c.Request.Context(), restaurantID, closureID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除休業記錄成功",
+	})
+}
+
+// respondError 回傳休業相關錯誤
+func (h *ClosureHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrRestaurantNotFound), errors.Is(err, domain.ErrClosureNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidClosureDate):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
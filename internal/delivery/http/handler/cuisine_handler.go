@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+)
+
+// CuisineHandler 料理分類 HTTP 處理器
+type CuisineHandler struct {
+	cuisineUseCase *usecase.CuisineUseCase
+}
+
+// NewCuisineHandler 建立料理分類處理器
+func NewCuisineHandler(cuisineUseCase *usecase.CuisineUseCase) *CuisineHandler {
+	return &CuisineHandler{
+		cuisineUseCase: cuisineUseCase,
+	}
+}
+
+// ListCuisines 取得料理分類樹（名稱依 Accept-Language 顯示）
+func (h *CuisineHandler) ListCuisines(c *gin.Context) {
+	cuisines, err := h.cuisineUseCase.ListCuisines(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cuisines": cuisines,
+		"locale":   domain.LocaleFromContext(c.Request.Context()),
+	})
+}
+
+// CreateCuisine 建立料理分類（管理功能）
+func (h *CuisineHandler) CreateCuisine(c *gin.Context) {
+	var req domain.CreateCuisineRequest
+	if !bindAndValidateJSON(c, &req, "建立料理分類請求參數錯誤") {
+		return
+	}
+
+	cuisine, err := h.cuisineUseCase.CreateCuisine(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "建立料理分類成功",
+		"cuisine": cuisine,
+	})
+}
+
+// UpdateCuisine 更新料理分類（管理功能）
+func (h *CuisineHandler) UpdateCuisine(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的料理分類 ID",
+		})
+		return
+	}
+
+	var req domain.UpdateCuisineRequest
+	if !bindAndValidateJSON(c, &req, "更新料理分類請求參數錯誤") {
+		return
+	}
+
+	cuisine, err := h.cuisineUseCase.UpdateCuisine(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "更新料理分類成功",
+		"cuisine": cuisine,
+	})
+}
+
+// SetProviderType 設定外部地點類型對應（管理功能）
+func (h *CuisineHandler) SetProviderType(c *gin.Context) {
+	var req domain.CuisineProviderType
+	if !bindAndValidateJSON(c, &req, "設定地點類型對應請求參數錯誤") {
+		return
+	}
+
+	if err := h.cuisineUseCase.SetProviderType(c.Request.Context(), &req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "設定地點類型對應成功",
+		"mapping": req,
+	})
+}
+
+// DeleteProviderType 刪除外部地點類型對應（管理功能）
+func (h *CuisineHandler) DeleteProviderType(c *gin.Context) {
+	if err := h.cuisineUseCase.DeleteProviderType(c.Request.Context(), c.Param("provider"), c.Param("type")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除地點類型對應成功",
+	})
+}
+
+// respondError 回傳料理分類相關錯誤
+func (h *CuisineHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrCuisineNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrCuisineExists):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidCuisineParent), errors.Is(err, domain.ErrInvalidCuisine),
+		errors.Is(err, domain.ErrInvalidCuisineSlug), errors.Is(err, domain.ErrMissingCuisineName):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
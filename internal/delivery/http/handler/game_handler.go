@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
 	"go.uber.org/zap"
 )
 
@@ -48,9 +50,21 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 		req.Radius = 1000 // 預設 1 公里
 	}
 
+	if err := validator.ValidateStruct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return
+	}
+
 	session, err := h.gameUseCase.StartGame(c.Request.Context(), userID.(int), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidOpenAt) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
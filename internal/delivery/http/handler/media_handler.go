@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MediaHandler 媒體檔案 HTTP 處理器
+type MediaHandler struct {
+	placePhotoUseCase *usecase.PlacePhotoUseCase
+}
+
+// NewMediaHandler 建立媒體檔案處理器
+func NewMediaHandler(placePhotoUseCase *usecase.PlacePhotoUseCase) *MediaHandler {
+	return &MediaHandler{
+		placePhotoUseCase: placePhotoUseCase,
+	}
+}
+
+// GetPlacePhoto 代理餐廳照片，照片由伺服器端以 API 金鑰下載並快取，回應附帶快取標頭
+func (h *MediaHandler) GetPlacePhoto(c *gin.Context) {
+	photo, err := h.placePhotoUseCase.GetPhoto(c.Request.Context(), c.Param("ref"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	maxAge := int(time.Until(photo.ExpiresAt).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	sum := sha256.Sum256(photo.Data)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", photo.ContentType)
+	header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(c.Writer, c.Request, "", photo.FetchedAt, bytes.NewReader(photo.Data))
+}
+
+// respondError 回傳照片代理相關錯誤
+func (h *MediaHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrInvalidPhotoReference):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrProviderPlaceNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrAPIQuotaExceeded), errors.Is(err, domain.ErrProviderUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, domain.ErrExternalAPIFailed), errors.Is(err, domain.ErrGoogleAPIFailed):
+		status = http.StatusBadGateway
+	}
+
+	if status >= http.StatusInternalServerError {
+		logger.Error("取得餐廳照片失敗", zap.Error(err), zap.String("ref", c.Param("ref")))
+	}
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
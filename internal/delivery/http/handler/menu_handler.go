@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
+)
+
+// maxMenuImportBytes 菜單匯入請求內容大小上限
+const maxMenuImportBytes = 5 << 20
+
+// MenuHandler 餐廳菜單 HTTP 處理器
+type MenuHandler struct {
+	menuUseCase *usecase.MenuUseCase
+}
+
+// NewMenuHandler 建立菜單處理器
+func NewMenuHandler(menuUseCase *usecase.MenuUseCase) *MenuHandler {
+	return &MenuHandler{
+		menuUseCase: menuUseCase,
+	}
+}
+
+// GetMenu 取得餐廳菜單
+func (h *MenuHandler) GetMenu(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	menu, err := h.menuUseCase.GetMenu(c.Request.Context(), restaurantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"menu": menu,
+	})
+}
+
+// CreateSection 建立菜單分類（管理功能）
+func (h *MenuHandler) CreateSection(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.MenuSectionRequest
+	if !bindAndValidateJSON(c, &req, "建立菜單分類請求參數錯誤") {
+		return
+	}
+
+	section, err := h.menuUseCase.CreateSection(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "建立菜單分類成功",
+		"section": section,
+	})
+}
+
+// UpdateSection 更新菜單分類（管理功能）
+func (h *MenuHandler) UpdateSection(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的菜單分類 ID",
+		})
+		return
+	}
+
+	var req domain.MenuSectionRequest
+	if !bindAndValidateJSON(c, &req, "更新菜單分類請求參數錯誤") {
+		return
+	}
+
+	section, err := h.menuUseCase.UpdateSection(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "更新菜單分類成功",
+		"section": section,
+	})
+}
+
+// DeleteSection 刪除菜單分類（管理功能）
+func (h *MenuHandler) DeleteSection(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的菜單分類 ID",
+		})
+		return
+	}
+
+	if err := h.menuUseCase.DeleteSection(c.Request.Context(), id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除菜單分類成功",
+	})
+}
+
+// CreateItem 建立菜單品項（管理功能）
+func (h *MenuHandler) CreateItem(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.MenuItemRequest
+	if !bindAndValidateJSON(c, &req, "建立菜單品項請求參數錯誤") {
+		return
+	}
+
+	item, err := h.menuUseCase.CreateItem(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "建立菜單品項成功",
+		"item":    item,
+	})
+}
+
+// UpdateItem 更新菜單品項（管理功能）
+func (h *MenuHandler) UpdateItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的菜單品項 ID",
+		})
+		return
+	}
+
+	var req domain.MenuItemRequest
+	if !bindAndValidateJSON(c, &req, "更新菜單品項請求參數錯誤") {
+		return
+	}
+
+	item, err := h.menuUseCase.UpdateItem(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "更新菜單品項成功",
+		"item":    item,
+	})
+}
+
+// DeleteItem 刪除菜單品項（管理功能）
+func (h *MenuHandler) DeleteItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的菜單品項 ID",
+		})
+		return
+	}
+
+	if err := h.menuUseCase.DeleteItem(c.Request.Context(), id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除菜單品項成功",
+	})
+}
+
+// ImportMenu 批次匯入菜單（管理功能）
+// Content-Type 為 text/csv 時以 CSV 解析，其餘以 JSON 解析
+func (h *MenuHandler) ImportMenu(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxMenuImportBytes)
+
+	var menuImport *domain.MenuImport
+	if c.ContentType() == "text/csv" {
+		parsed, err := domain.ParseMenuCSV(c.Request.Body)
+		if err != nil {
+			h.respondError(c, err)
+			return
+		}
+		if err := validator.ValidateStruct(parsed); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "請求參數錯誤",
+				"details": validator.GetValidationErrors(err),
+			})
+			return
+		}
+		menuImport = parsed
+	} else {
+		var req domain.MenuImport
+		if !bindAndValidateJSON(c, &req, "匯入菜單請求參數錯誤") {
+			return
+		}
+		menuImport = &req
+	}
+
+	menu, err := h.menuUseCase.ImportMenu(c.Request.Context(), restaurantID, menuImport)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "匯入菜單成功",
+		"menu":    menu,
+	})
+}
+
+// UpdateSettings 更新菜單設定（管理功能）
+func (h *MenuHandler) UpdateSettings(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.MenuSettingsRequest
+	if !bindAndValidateJSON(c, &req, "更新菜單設定請求參數錯誤") {
+		return
+	}
+
+	menu, err := h.menuUseCase.UpdateSettings(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "更新菜單設定成功",
+		"menu":    menu,
+	})
+}
+
+// respondError 回傳菜單相關錯誤
+func (h *MenuHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrRestaurantNotFound), errors.Is(err, domain.ErrMenuSectionNotFound),
+		errors.Is(err, domain.ErrMenuItemNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrUnknownTag), errors.Is(err, domain.ErrInvalidMenuImport):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrMenuImportTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
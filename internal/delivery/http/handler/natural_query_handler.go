@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+)
+
+// NaturalQueryHandler 自然語言搜尋 HTTP 處理器
+type NaturalQueryHandler struct {
+	naturalQueryUseCase *usecase.NaturalQueryUseCase
+}
+
+// NewNaturalQueryHandler 建立自然語言搜尋處理器
+func NewNaturalQueryHandler(naturalQueryUseCase *usecase.NaturalQueryUseCase) *NaturalQueryHandler {
+	return &NaturalQueryHandler{
+		naturalQueryUseCase: naturalQueryUseCase,
+	}
+}
+
+// Search 以自然語言查詢搜尋附近餐廳，例如「便宜的拉麵 走路10分鐘內」
+func (h *NaturalQueryHandler) Search(c *gin.Context) {
+	var params domain.NaturalQueryParams
+	if !bindAndValidateQuery(c, &params, "自然語言搜尋請求參數錯誤") {
+		return
+	}
+
+	result, err := h.naturalQueryUseCase.Search(c.Request.Context(), &params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidSearchQuery) || errors.Is(err, domain.ErrInvalidCuisine) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+)
+
+// PlaceCacheHandler 外部地點搜尋快取 HTTP 處理器
+type PlaceCacheHandler struct {
+	placeCache *usecase.CachedExternalAPI
+}
+
+// NewPlaceCacheHandler 建立外部地點搜尋快取處理器，placeCache 為 nil 表示未啟用快取
+func NewPlaceCacheHandler(placeCache *usecase.CachedExternalAPI) *PlaceCacheHandler {
+	return &PlaceCacheHandler{
+		placeCache: placeCache,
+	}
+}
+
+// GetStats 取得快取命中統計
+func (h *PlaceCacheHandler) GetStats(c *gin.Context) {
+	if h.placeCache == nil {
+		c.JSON(http.StatusOK, domain.PlaceCacheStats{})
+		return
+	}
+	c.JSON(http.StatusOK, h.placeCache.Stats())
+}
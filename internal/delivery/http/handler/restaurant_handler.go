@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
 	"go.uber.org/zap"
 )
 
@@ -45,7 +47,11 @@ func (h *RestaurantHandler) SearchNearby(c *gin.Context) {
 
 	restaurants, err := h.restaurantUseCase.SearchNearby(c.Request.Context(), &params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidOpenAt) || errors.Is(err, domain.ErrInvalidCuisine) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -57,6 +63,89 @@ func (h *RestaurantHandler) SearchNearby(c *gin.Context) {
 	})
 }
 
+// SearchViewport 搜尋地圖可視範圍內的餐廳
+func (h *RestaurantHandler) SearchViewport(c *gin.Context) {
+	var params domain.ViewportSearchParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		logger.Error("範圍搜尋請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateStruct(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return
+	}
+
+	result, err := h.restaurantUseCase.SearchInViewport(c.Request.Context(), &params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidViewport) || errors.Is(err, domain.ErrInvalidCuisine) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SearchText 以名稱、別名或地址文字搜尋餐廳
+func (h *RestaurantHandler) SearchText(c *gin.Context) {
+	var params domain.TextSearchParams
+	if !bindAndValidateQuery(c, &params, "文字搜尋請求參數錯誤") {
+		return
+	}
+
+	results, err := h.restaurantUseCase.SearchText(c.Request.Context(), &params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidSearchQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":       params.Query,
+		"restaurants": results,
+		"count":       len(results),
+	})
+}
+
+// Autocomplete 依輸入文字建議餐廳與料理分類
+func (h *RestaurantHandler) Autocomplete(c *gin.Context) {
+	var params domain.AutocompleteParams
+	if !bindAndValidateQuery(c, &params, "自動完成請求參數錯誤") {
+		return
+	}
+
+	result, err := h.restaurantUseCase.Autocomplete(c.Request.Context(), &params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidSearchQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetRestaurant 取得餐廳詳細資訊
 func (h *RestaurantHandler) GetRestaurant(c *gin.Context) {
 	idStr := c.Param("id")
@@ -181,7 +270,14 @@ func (h *RestaurantHandler) CreateRestaurant(c *gin.Context) {
 	}
 
 	if err := h.restaurantUseCase.CreateRestaurant(c.Request.Context(), &restaurant); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrInvalidCuisine):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrRestaurantProviderIDExists):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -245,7 +341,14 @@ func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
 
 	restaurant.ID = id
 	if err := h.restaurantUseCase.UpdateRestaurant(c.Request.Context(), &restaurant); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrInvalidCuisine):
+			status = http.StatusBadRequest
+		case errors.Is(err, domain.ErrRestaurantProviderIDExists):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
 			"error": err.Error(),
 		})
 		return
@@ -256,3 +359,138 @@ func (h *RestaurantHandler) UpdateRestaurant(c *gin.Context) {
 		"restaurant": restaurant,
 	})
 }
+
+// GetOpeningHours 取得餐廳營業時間
+func (h *RestaurantHandler) GetOpeningHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	hours, err := h.restaurantUseCase.GetOpeningHours(c.Request.Context(), id)
+	if err != nil {
+		h.respondOpeningHoursError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"opening_hours": hours,
+	})
+}
+
+// UpdateOpeningHours 更新餐廳營業時間（管理功能）
+func (h *RestaurantHandler) UpdateOpeningHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.UpdateOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("更新營業時間請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return
+	}
+
+	hours, err := h.restaurantUseCase.UpdateOpeningHours(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondOpeningHoursError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "更新營業時間成功",
+		"opening_hours": hours,
+	})
+}
+
+// ImportOpeningHours 從外部 API 匯入餐廳營業時間（管理功能）
+func (h *RestaurantHandler) ImportOpeningHours(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	hours, err := h.restaurantUseCase.ImportOpeningHours(c.Request.Context(), id)
+	if err != nil {
+		h.respondOpeningHoursError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "匯入營業時間成功",
+		"opening_hours": hours,
+	})
+}
+
+// SetAliases 設定餐廳搜尋別名（管理功能）
+func (h *RestaurantHandler) SetAliases(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.SetRestaurantAliasesRequest
+	if !bindAndValidateJSON(c, &req, "設定餐廳別名請求參數錯誤") {
+		return
+	}
+
+	aliases, err := h.restaurantUseCase.SetAliases(c.Request.Context(), id, &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "設定餐廳別名成功",
+		"aliases": aliases,
+	})
+}
+
+// respondOpeningHoursError 回傳營業時間錯誤
+func (h *RestaurantHandler) respondOpeningHoursError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrRestaurantNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidOpeningHours), errors.Is(err, domain.ErrInvalidTimeZone):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrRestaurantNoProvider):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrExternalAPIFailed):
+		status = http.StatusBadGateway
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
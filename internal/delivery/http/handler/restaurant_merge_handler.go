@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+)
+
+// RestaurantMergeHandler 重複餐廳偵測與合併 HTTP 處理器（管理功能）
+type RestaurantMergeHandler struct {
+	mergeUseCase *usecase.RestaurantMergeUseCase
+}
+
+// NewRestaurantMergeHandler 建立餐廳合併處理器
+func NewRestaurantMergeHandler(mergeUseCase *usecase.RestaurantMergeUseCase) *RestaurantMergeHandler {
+	return &RestaurantMergeHandler{
+		mergeUseCase: mergeUseCase,
+	}
+}
+
+// ListDuplicates 取得疑似重複餐廳清單
+func (h *RestaurantMergeHandler) ListDuplicates(c *gin.Context) {
+	var params domain.DuplicateCandidateParams
+	if !bindAndValidateQuery(c, &params, "取得疑似重複餐廳請求參數錯誤") {
+		return
+	}
+
+	candidates, total, err := h.mergeUseCase.ListDuplicates(c.Request.Context(), &params)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"duplicates": candidates,
+		"total":      total,
+	})
+}
+
+// DetectDuplicates 立即執行重複餐廳偵測
+func (h *RestaurantMergeHandler) DetectDuplicates(c *gin.Context) {
+	count, err := h.mergeUseCase.DetectDuplicates(c.Request.Context())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "偵測重複餐廳完成",
+		"detected": count,
+	})
+}
+
+// DismissDuplicate 將疑似重複的組合標記為非重複
+func (h *RestaurantMergeHandler) DismissDuplicate(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	var req domain.DismissDuplicateRequest
+	if !bindAndValidateJSON(c, &req, "略過疑似重複餐廳請求參數錯誤") {
+		return
+	}
+
+	if err := h.mergeUseCase.DismissDuplicate(c.Request.Context(), userID.(int), &req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "已略過疑似重複餐廳",
+	})
+}
+
+// PreviewMerge 預覽合併將移動的資料
+func (h *RestaurantMergeHandler) PreviewMerge(c *gin.Context) {
+	var req domain.RestaurantMergeRequest
+	if !bindAndValidateJSON(c, &req, "預覽餐廳合併請求參數錯誤") {
+		return
+	}
+
+	preview, err := h.mergeUseCase.PreviewMerge(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Merge 合併重複餐廳
+func (h *RestaurantMergeHandler) Merge(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	var req domain.RestaurantMergeRequest
+	if !bindAndValidateJSON(c, &req, "合併餐廳請求參數錯誤") {
+		return
+	}
+
+	merge, err := h.mergeUseCase.Merge(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "合併餐廳成功",
+		"merge":   merge,
+	})
+}
+
+// UndoMerge 復原合併
+func (h *RestaurantMergeHandler) UndoMerge(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	mergeID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的合併記錄 ID",
+		})
+		return
+	}
+
+	merge, err := h.mergeUseCase.UndoMerge(c.Request.Context(), userID.(int), mergeID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "復原合併成功",
+		"merge":   merge,
+	})
+}
+
+// ListMerges 取得合併記錄
+func (h *RestaurantMergeHandler) ListMerges(c *gin.Context) {
+	var params domain.RestaurantMergeListParams
+	if !bindAndValidateQuery(c, &params, "取得合併記錄請求參數錯誤") {
+		return
+	}
+
+	merges, err := h.mergeUseCase.ListMerges(c.Request.Context(), &params)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"merges": merges,
+	})
+}
+
+// respondError 回傳餐廳合併相關錯誤
+func (h *RestaurantMergeHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrRestaurantNotFound),
+		errors.Is(err, domain.ErrRestaurantMergeNotFound),
+		errors.Is(err, domain.ErrDuplicateCandidateNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrMergeAlreadyUndone), errors.Is(err, domain.ErrMergeUndoBlocked):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidRestaurantMerge), errors.Is(err, domain.ErrInvalidDuplicateStatus):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// ReviewHandler 評論 HTTP 處理器
+type ReviewHandler struct {
+	reviewUseCase *usecase.ReviewUseCase
+}
+
+// NewReviewHandler 建立評論處理器
+func NewReviewHandler(reviewUseCase *usecase.ReviewUseCase) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUseCase: reviewUseCase,
+	}
+}
+
+// ListRestaurantReviews 取得餐廳評論
+func (h *ReviewHandler) ListRestaurantReviews(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var params domain.ReviewListParams
+	if !bindAndValidateQuery(c, &params, "取得評論請求參數錯誤") {
+		return
+	}
+
+	result, err := h.reviewUseCase.ListRestaurantReviews(c.Request.Context(), restaurantID, &params)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpsertReview 新增或編輯自己對餐廳的評論
+func (h *ReviewHandler) UpsertReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.UpsertReviewRequest
+	if !bindAndValidateJSON(c, &req, "評論請求參數錯誤") {
+		return
+	}
+
+	review, created, err := h.reviewUseCase.UpsertReview(c.Request.Context(), userID.(int), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	status, message := http.StatusOK, "更新評論成功"
+	if created {
+		status, message = http.StatusCreated, "新增評論成功"
+	}
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"review":  review,
+	})
+}
+
+// DeleteReview 刪除自己對餐廳的評論
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	if err := h.reviewUseCase.DeleteReview(c.Request.Context(), userID.(int), restaurantID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除評論成功",
+	})
+}
+
+// FlagReview 檢舉評論
+func (h *ReviewHandler) FlagReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	reviewID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的評論 ID",
+		})
+		return
+	}
+
+	var req domain.FlagReviewRequest
+	if !bindAndValidateJSON(c, &req, "檢舉評論請求參數錯誤") {
+		return
+	}
+
+	if err := h.reviewUseCase.FlagReview(c.Request.Context(), userID.(int), reviewID, &req); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "檢舉評論成功",
+	})
+}
+
+// ListFlaggedReviews 取得待審核評論（版主功能）
+func (h *ReviewHandler) ListFlaggedReviews(c *gin.Context) {
+	var params domain.ReviewListParams
+	if !bindAndValidateQuery(c, &params, "取得待審核評論請求參數錯誤") {
+		return
+	}
+
+	result, err := h.reviewUseCase.ListFlaggedReviews(c.Request.Context(), &params)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ModerateReview 審核評論（版主功能）
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	reviewID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的評論 ID",
+		})
+		return
+	}
+
+	var req domain.ModerateReviewRequest
+	if !bindAndValidateJSON(c, &req, "審核評論請求參數錯誤") {
+		return
+	}
+
+	review, err := h.reviewUseCase.ModerateReview(c.Request.Context(), userID.(int), reviewID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "審核評論成功",
+		"review":  review,
+	})
+}
+
+// respondError 回傳評論相關錯誤
+func (h *ReviewHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrReviewNotFound), errors.Is(err, domain.ErrRestaurantNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrReviewAlreadyFlagged):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrCannotFlagOwnReview):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrInvalidVisitDate), errors.Is(err, domain.ErrInvalidModerationAction):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
+
+// bindAndValidateQuery 綁定並驗證查詢參數，失敗時回傳 400
+func bindAndValidateQuery(c *gin.Context, req interface{}, logMessage string) bool {
+	if err := c.ShouldBindQuery(req); err != nil {
+		logger.Error(logMessage, zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return false
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return false
+	}
+
+	return true
+}
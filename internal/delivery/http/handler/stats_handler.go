@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// StatsHandler 統計 HTTP 處理器
+type StatsHandler struct {
+	statsUseCase *usecase.StatsUseCase
+}
+
+// NewStatsHandler 建立統計處理器
+func NewStatsHandler(statsUseCase *usecase.StatsUseCase) *StatsHandler {
+	return &StatsHandler{
+		statsUseCase: statsUseCase,
+	}
+}
+
+// GetUserStats 取得個人飲食統計
+func (h *StatsHandler) GetUserStats(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "未認證的使用者",
+		})
+		return
+	}
+
+	var req domain.UserStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("個人統計請求參數錯誤", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.statsUseCase.GetUserStats(c.Request.Context(), userID.(int), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidTimeZone) || errors.Is(err, domain.ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats": stats,
+	})
+}
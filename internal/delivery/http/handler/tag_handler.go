@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/internal/usecase"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"github.com/shaunchuang/food-roulette-backend/pkg/validator"
+	"go.uber.org/zap"
+)
+
+// TagHandler 標籤 HTTP 處理器
+type TagHandler struct {
+	tagUseCase *usecase.TagUseCase
+}
+
+// NewTagHandler 建立標籤處理器
+func NewTagHandler(tagUseCase *usecase.TagUseCase) *TagHandler {
+	return &TagHandler{
+		tagUseCase: tagUseCase,
+	}
+}
+
+// ListTags 取得標籤清單
+func (h *TagHandler) ListTags(c *gin.Context) {
+	tags, err := h.tagUseCase.ListTags(c.Request.Context(), domain.TagCategory(c.Query("category")))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  tags,
+		"count": len(tags),
+	})
+}
+
+// CreateTag 建立標籤（管理功能）
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req domain.CreateTagRequest
+	if !bindAndValidateJSON(c, &req, "建立標籤請求參數錯誤") {
+		return
+	}
+
+	tag, err := h.tagUseCase.CreateTag(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "建立標籤成功",
+		"tag":     tag,
+	})
+}
+
+// UpdateTag 更新標籤（管理功能）
+func (h *TagHandler) UpdateTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的標籤 ID",
+		})
+		return
+	}
+
+	var req domain.UpdateTagRequest
+	if !bindAndValidateJSON(c, &req, "更新標籤請求參數錯誤") {
+		return
+	}
+
+	tag, err := h.tagUseCase.UpdateTag(c.Request.Context(), id, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "更新標籤成功",
+		"tag":     tag,
+	})
+}
+
+// DeleteTag 刪除標籤（管理功能）
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的標籤 ID",
+		})
+		return
+	}
+
+	if err := h.tagUseCase.DeleteTag(c.Request.Context(), id); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "刪除標籤成功",
+	})
+}
+
+// GetRestaurantTags 取得餐廳標籤
+func (h *TagHandler) GetRestaurantTags(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	tags, err := h.tagUseCase.GetRestaurantTags(c.Request.Context(), restaurantID)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  tags,
+		"count": len(tags),
+	})
+}
+
+// SetRestaurantTags 設定餐廳標籤（管理功能）
+func (h *TagHandler) SetRestaurantTags(c *gin.Context) {
+	restaurantID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "無效的餐廳 ID",
+		})
+		return
+	}
+
+	var req domain.SetRestaurantTagsRequest
+	if !bindAndValidateJSON(c, &req, "設定餐廳標籤請求參數錯誤") {
+		return
+	}
+
+	tags, err := h.tagUseCase.SetRestaurantTags(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "設定餐廳標籤成功",
+		"tags":    tags,
+	})
+}
+
+// respondError 回傳標籤相關錯誤
+func (h *TagHandler) respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrRestaurantNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrTagExists):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidTagCategory), errors.Is(err, domain.ErrUnknownTag), errors.Is(err, domain.ErrInvalidTagSlug):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
+
+// bindAndValidateJSON 綁定並驗證 JSON 請求，失敗時回傳 400
+func bindAndValidateJSON(c *gin.Context, req interface{}, logMessage string) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		logger.Error(logMessage, zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": err.Error(),
+		})
+		return false
+	}
+
+	if err := validator.ValidateStruct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "請求參數錯誤",
+			"details": validator.GetValidationErrors(err),
+		})
+		return false
+	}
+
+	return true
+}
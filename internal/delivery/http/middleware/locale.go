@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// LocaleMiddleware 依 Accept-Language 決定回應語系並存入請求 context
+func LocaleMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		locale := domain.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+
+		c.Set("locale", locale)
+		c.Request = c.Request.WithContext(domain.WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", locale)
+		c.Header("Vary", "Accept-Language")
+
+		c.Next()
+	})
+}
@@ -15,6 +15,18 @@ type Router struct {
 	restaurantHandler *handler.RestaurantHandler
 	gameHandler       *handler.GameHandler
 	adHandler         *handler.AdvertisementHandler
+	statsHandler      *handler.StatsHandler
+	analyticsHandler  *handler.AnalyticsHandler
+	closureHandler    *handler.ClosureHandler
+	tagHandler        *handler.TagHandler
+	cuisineHandler    *handler.CuisineHandler
+	menuHandler       *handler.MenuHandler
+	reviewHandler     *handler.ReviewHandler
+	queryHandler      *handler.NaturalQueryHandler
+	mergeHandler      *handler.RestaurantMergeHandler
+	placeCacheHandler *handler.PlaceCacheHandler
+	apiUsageHandler   *handler.APIUsageHandler
+	mediaHandler      *handler.MediaHandler
 }
 
 // NewRouter 建立新的路由器
@@ -23,12 +35,36 @@ func NewRouter(
 	restaurantHandler *handler.RestaurantHandler,
 	gameHandler *handler.GameHandler,
 	adHandler *handler.AdvertisementHandler,
+	statsHandler *handler.StatsHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	closureHandler *handler.ClosureHandler,
+	tagHandler *handler.TagHandler,
+	cuisineHandler *handler.CuisineHandler,
+	menuHandler *handler.MenuHandler,
+	reviewHandler *handler.ReviewHandler,
+	queryHandler *handler.NaturalQueryHandler,
+	mergeHandler *handler.RestaurantMergeHandler,
+	placeCacheHandler *handler.PlaceCacheHandler,
+	apiUsageHandler *handler.APIUsageHandler,
+	mediaHandler *handler.MediaHandler,
 ) *Router {
 	return &Router{
 		userHandler:       userHandler,
 		restaurantHandler: restaurantHandler,
 		gameHandler:       gameHandler,
 		adHandler:         adHandler,
+		statsHandler:      statsHandler,
+		analyticsHandler:  analyticsHandler,
+		closureHandler:    closureHandler,
+		tagHandler:        tagHandler,
+		cuisineHandler:    cuisineHandler,
+		menuHandler:       menuHandler,
+		reviewHandler:     reviewHandler,
+		queryHandler:      queryHandler,
+		mergeHandler:      mergeHandler,
+		placeCacheHandler: placeCacheHandler,
+		apiUsageHandler:   apiUsageHandler,
+		mediaHandler:      mediaHandler,
 	}
 }
 
@@ -38,6 +74,7 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 	engine.Use(middleware.CORSMiddleware())
 	engine.Use(middleware.LoggerMiddleware())
 	engine.Use(middleware.ErrorMiddleware())
+	engine.Use(middleware.LocaleMiddleware())
 
 	// 健康檢查
 	engine.GET("/health", func(c *gin.Context) {
@@ -47,6 +84,9 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 		})
 	})
 
+	// 餐廳照片代理（圖片網址直接給前端使用，不放在 API 版本群組內）
+	engine.GET("/media/places/:ref", r.mediaHandler.GetPlacePhoto)
+
 	// API 版本群組
 	v1 := engine.Group("/api/v1")
 	{
@@ -64,9 +104,24 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 			restaurants := public.Group("/restaurants")
 			{
 				restaurants.GET("/search", r.restaurantHandler.SearchNearby)
+				restaurants.GET("/viewport", r.restaurantHandler.SearchViewport)
+				restaurants.GET("/text-search", r.restaurantHandler.SearchText)
+				restaurants.GET("/autocomplete", r.restaurantHandler.Autocomplete)
+				restaurants.GET("/query", r.queryHandler.Search)
 				restaurants.GET("/:id", r.restaurantHandler.GetRestaurant)
+				restaurants.GET("/:id/opening-hours", r.restaurantHandler.GetOpeningHours)
+				restaurants.GET("/:id/closures", r.closureHandler.ListClosures)
+				restaurants.GET("/:id/tags", r.tagHandler.GetRestaurantTags)
+				restaurants.GET("/:id/menu", r.menuHandler.GetMenu)
+				restaurants.GET("/:id/reviews", r.reviewHandler.ListRestaurantReviews)
 			}
 
+			// 標籤相關（公開）
+			public.GET("/tags", r.tagHandler.ListTags)
+
+			// 料理分類（公開）
+			public.GET("/cuisines", r.cuisineHandler.ListCuisines)
+
 			// 廣告相關（公開瀏覽）
 			ads := public.Group("/advertisements")
 			{
@@ -87,6 +142,7 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 				users.GET("/profile", r.userHandler.GetProfile)
 				users.PUT("/location", r.userHandler.UpdateLocation)
 				users.GET("/location", r.userHandler.GetLocation)
+				users.GET("/stats", r.statsHandler.GetUserStats)
 			}
 
 			// 最愛餐廳
@@ -97,6 +153,14 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 				favorites.DELETE("/:restaurant_id", r.restaurantHandler.RemoveFromFavorites)
 			}
 
+			// 餐廳休業回報與評論
+			restaurantReports := protected.Group("/restaurants")
+			{
+				restaurantReports.POST("/:id/closure-reports", r.closureHandler.ReportClosed)
+				restaurantReports.PUT("/:id/reviews", r.reviewHandler.UpsertReview)
+				restaurantReports.DELETE("/:id/reviews", r.reviewHandler.DeleteReview)
+			}
+
 			// 遊戲相關
 			games := protected.Group("/games")
 			{
@@ -110,6 +174,9 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 			{
 				adStats.GET("/:id/statistics", r.adHandler.GetStatistics)
 			}
+
+			// 評論檢舉
+			protected.POST("/reviews/:id/flags", r.reviewHandler.FlagReview)
 		}
 
 		// 管理員路由（需要管理員權限）
@@ -122,6 +189,66 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 				adminRestaurants.POST("/", r.restaurantHandler.CreateRestaurant)
 				adminRestaurants.GET("/", r.restaurantHandler.GetAllRestaurants)
 				adminRestaurants.PUT("/:id", r.restaurantHandler.UpdateRestaurant)
+				adminRestaurants.PUT("/:id/opening-hours", r.restaurantHandler.UpdateOpeningHours)
+				adminRestaurants.POST("/:id/opening-hours/import", r.restaurantHandler.ImportOpeningHours)
+				adminRestaurants.POST("/:id/closures", r.closureHandler.CreateClosure)
+				adminRestaurants.DELETE("/:id/closures/:closure_id", r.closureHandler.DeleteClosure)
+				adminRestaurants.PUT("/:id/tags", r.tagHandler.SetRestaurantTags)
+				adminRestaurants.PUT("/:id/aliases", r.restaurantHandler.SetAliases)
+				adminRestaurants.POST("/:id/menu/sections", r.menuHandler.CreateSection)
+				adminRestaurants.POST("/:id/menu/items", r.menuHandler.CreateItem)
+				adminRestaurants.POST("/:id/menu/import", r.menuHandler.ImportMenu)
+				adminRestaurants.PUT("/:id/menu/settings", r.menuHandler.UpdateSettings)
+			}
+
+			// 重複餐廳偵測
+			adminDuplicates := admin.Group("/restaurant-duplicates")
+			{
+				adminDuplicates.GET("/", r.mergeHandler.ListDuplicates)
+				adminDuplicates.POST("/detect", r.mergeHandler.DetectDuplicates)
+				adminDuplicates.POST("/dismiss", r.mergeHandler.DismissDuplicate)
+			}
+
+			// 餐廳合併（含稽核記錄與復原）
+			adminMerges := admin.Group("/restaurant-merges")
+			{
+				adminMerges.GET("/", r.mergeHandler.ListMerges)
+				adminMerges.POST("/", r.mergeHandler.Merge)
+				adminMerges.POST("/preview", r.mergeHandler.PreviewMerge)
+				adminMerges.POST("/:id/undo", r.mergeHandler.UndoMerge)
+			}
+
+			// 菜單管理
+			adminMenu := admin.Group("/menu")
+			{
+				adminMenu.PUT("/sections/:id", r.menuHandler.UpdateSection)
+				adminMenu.DELETE("/sections/:id", r.menuHandler.DeleteSection)
+				adminMenu.PUT("/items/:id", r.menuHandler.UpdateItem)
+				adminMenu.DELETE("/items/:id", r.menuHandler.DeleteItem)
+			}
+
+			// 標籤管理
+			adminTags := admin.Group("/tags")
+			{
+				adminTags.POST("/", r.tagHandler.CreateTag)
+				adminTags.PUT("/:id", r.tagHandler.UpdateTag)
+				adminTags.DELETE("/:id", r.tagHandler.DeleteTag)
+			}
+
+			// 料理分類管理
+			adminCuisines := admin.Group("/cuisines")
+			{
+				adminCuisines.POST("/", r.cuisineHandler.CreateCuisine)
+				adminCuisines.PUT("/:id", r.cuisineHandler.UpdateCuisine)
+				adminCuisines.PUT("/provider-types", r.cuisineHandler.SetProviderType)
+				adminCuisines.DELETE("/provider-types/:provider/:type", r.cuisineHandler.DeleteProviderType)
+			}
+
+			// 評論審核（管理員與版主）
+			adminReviews := admin.Group("/reviews")
+			{
+				adminReviews.GET("/flagged", r.reviewHandler.ListFlaggedReviews)
+				adminReviews.PUT("/:id/moderation", r.reviewHandler.ModerateReview)
 			}
 
 			// 廣告管理
@@ -132,6 +259,22 @@ func (r *Router) SetupRoutes(engine *gin.Engine, authService middleware.AuthServ
 				adminAds.PUT("/:id", r.adHandler.UpdateAd)
 				adminAds.DELETE("/:id", r.adHandler.DeleteAd)
 			}
+
+			// 分析報表
+			adminAnalytics := admin.Group("/analytics")
+			{
+				adminAnalytics.GET("/coverage", r.analyticsHandler.GetCoverage)
+				adminAnalytics.POST("/coverage/refresh", r.analyticsHandler.RefreshCoverage)
+				adminAnalytics.GET("/heatmap", r.analyticsHandler.GetActivityHeatmap)
+				adminAnalytics.POST("/heatmap/refresh", r.analyticsHandler.RefreshActivity)
+				adminAnalytics.GET("/restaurants/:id/heatmap", r.analyticsHandler.GetRestaurantHeatmap)
+			}
+
+			// 外部地點搜尋快取
+			admin.GET("/place-cache/stats", r.placeCacheHandler.GetStats)
+
+			// 外部 API 用量與額度
+			admin.GET("/api-usage", r.apiUsageHandler.GetUsage)
 		}
 	}
 }
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"time"
+)
+
+// GeoJSONFeatureCollection GeoJSON 要素集合
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"` // 固定為 FeatureCollection
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature GeoJSON 要素
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"` // 固定為 Feature
+	Geometry   GeoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONGeometry GeoJSON 幾何形狀
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`        // Point 或 Polygon
+	Coordinates interface{} `json:"coordinates"` // 座標順序為 [經度, 緯度]
+}
+
+// NewFeatureCollection 建立 GeoJSON 要素集合
+func NewFeatureCollection(features []GeoJSONFeature) *GeoJSONFeatureCollection {
+	if features == nil {
+		features = []GeoJSONFeature{}
+	}
+	return &GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: features,
+	}
+}
+
+// NewBoxFeature 建立矩形範圍的 GeoJSON 要素
+func NewBoxFeature(minLat, minLng, maxLat, maxLng float64, properties map[string]interface{}) GeoJSONFeature {
+	ring := [][]float64{
+		{minLng, minLat},
+		{maxLng, minLat},
+		{maxLng, maxLat},
+		{minLng, maxLat},
+		{minLng, minLat},
+	}
+	return GeoJSONFeature{
+		Type: "Feature",
+		Geometry: GeoJSONGeometry{
+			Type:        "Polygon",
+			Coordinates: [][][]float64{ring},
+		},
+		Properties: properties,
+	}
+}
+
+// CoverageCell 美食沙漠覆蓋格網
+type CoverageCell struct {
+	Geohash             string         `json:"geohash" db:"geohash"`
+	CenterLatitude      float64        `json:"center_latitude" db:"center_latitude"`
+	CenterLongitude     float64        `json:"center_longitude" db:"center_longitude"`
+	RestaurantCount     int            `json:"restaurant_count" db:"restaurant_count"`
+	CuisineCounts       map[string]int `json:"cuisine_counts" db:"cuisine_counts"` // 以料理分類識別碼為鍵
+	SearchCount         int            `json:"search_count" db:"search_count"`
+	FailedSearchCount   int            `json:"failed_search_count" db:"failed_search_count"`
+	ExpandedSearchCount int            `json:"expanded_search_count" db:"expanded_search_count"`
+	RefreshedAt         time.Time      `json:"refreshed_at" db:"refreshed_at"`
+}
+
+// FailedRate 搜尋失敗比例
+func (c *CoverageCell) FailedRate() float64 {
+	if c.SearchCount == 0 {
+		return 0
+	}
+	return float64(c.FailedSearchCount) / float64(c.SearchCount)
+}
+
+// ExpandedRate 需要擴大搜尋半徑的比例
+func (c *CoverageCell) ExpandedRate() float64 {
+	if c.SearchCount == 0 {
+		return 0
+	}
+	return float64(c.ExpandedSearchCount) / float64(c.SearchCount)
+}
+
+// SearchCellStats 單一格網的搜尋統計
+type SearchCellStats struct {
+	SearchCount         int
+	FailedSearchCount   int
+	ExpandedSearchCount int
+}
+
+// RestaurantLocation 餐廳位置與料理分類（格網統計用）
+type RestaurantLocation struct {
+	ID        int
+	Latitude  float64
+	Longitude float64
+	Cuisine   string // 料理分類識別碼，未分類時為空字串
+}
+
+// CoverageQuery 覆蓋地圖查詢參數
+type CoverageQuery struct {
+	MinLat    float64 `form:"min_lat" json:"min_lat" validate:"latitude"`
+	MinLng    float64 `form:"min_lng" json:"min_lng" validate:"longitude"`
+	MaxLat    float64 `form:"max_lat" json:"max_lat" validate:"latitude"`
+	MaxLng    float64 `form:"max_lng" json:"max_lng" validate:"longitude"`
+	Precision int     `form:"precision" json:"precision" validate:"min=0,max=6"` // geohash 精度，0 表示使用預設精度
+}
+
+// HasBounds 是否有指定地圖範圍
+func (q *CoverageQuery) HasBounds() bool {
+	return q.MinLat != 0 || q.MinLng != 0 || q.MaxLat != 0 || q.MaxLng != 0
+}
+
+// ActivityKind 遊戲活動熱度類型
+type ActivityKind string
+
+const (
+	ActivityKindStart  ActivityKind = "start"  // 遊戲開始位置
+	ActivityKindWin    ActivityKind = "win"    // 獲選餐廳位置
+	ActivityKindOrigin ActivityKind = "origin" // 獲選餐廳的玩家來源位置
+)
+
+// GameActivity 單場遊戲的位置資訊（熱度彙總用）
+type GameActivity struct {
+	StartGeohash       string
+	ResultRestaurantID *int
+	ResultLatitude     float64
+	ResultLongitude    float64
+	StartedAt          time.Time
+}
+
+// ActivityCell 遊戲活動熱度每日彙總格網
+type ActivityCell struct {
+	ActivityDate time.Time    `json:"activity_date" db:"activity_date"`
+	Kind         ActivityKind `json:"kind" db:"kind"`
+	RestaurantID int          `json:"restaurant_id" db:"restaurant_id"` // 僅 origin 使用，其餘為 0
+	Geohash      string       `json:"geohash" db:"geohash"`
+	Count        int          `json:"count" db:"count"`
+}
+
+// HeatmapRequest 遊戲活動熱度地圖請求
+type HeatmapRequest struct {
+	From      string  `form:"from" json:"from"`                                  // 起始日期 YYYY-MM-DD（伺服器時區）
+	To        string  `form:"to" json:"to"`                                      // 結束日期 YYYY-MM-DD（含當日）
+	Kind      string  `form:"kind" json:"kind"`                                  // start 或 win，預設 start
+	MinLat    float64 `form:"min_lat" json:"min_lat" validate:"latitude"`        // 地圖範圍
+	MinLng    float64 `form:"min_lng" json:"min_lng" validate:"longitude"`       // 地圖範圍
+	MaxLat    float64 `form:"max_lat" json:"max_lat" validate:"latitude"`        // 地圖範圍
+	MaxLng    float64 `form:"max_lng" json:"max_lng" validate:"longitude"`       // 地圖範圍
+	Precision int     `form:"precision" json:"precision" validate:"min=0,max=6"` // geohash 精度，0 表示使用預設精度
+}
+
+// HasBounds 是否有指定地圖範圍
+func (r *HeatmapRequest) HasBounds() bool {
+	return r.MinLat != 0 || r.MinLng != 0 || r.MaxLat != 0 || r.MaxLng != 0
+}
+
+// ActivityQuery 遊戲活動熱度查詢參數
+type ActivityQuery struct {
+	From         time.Time    // 起始日期（含）
+	To           time.Time    // 結束日期（不含）
+	Kind         ActivityKind // 熱度類型
+	RestaurantID int          // 僅 origin 使用
+}
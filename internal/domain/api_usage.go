@@ -0,0 +1,27 @@
+package domain
+
+// 外部 API 用量統計週期
+const (
+	APIUsagePeriodDay   = "day"
+	APIUsagePeriodMonth = "month"
+)
+
+// 斷路器狀態
+const (
+	CircuitClosed   = "closed"    // 正常送出請求
+	CircuitOpen     = "open"      // 連續失敗，暫停送出請求
+	CircuitHalfOpen = "half_open" // 暫停時間結束，允許一個試探請求
+)
+
+// APIUsage 外部 API 目前的請求用量（週期以 UTC 計算）
+type APIUsage struct {
+	Provider      string `json:"provider"`
+	Day           string `json:"day"` // YYYY-MM-DD
+	DailyCount    int    `json:"daily_count"`
+	DailyBudget   int    `json:"daily_budget"` // 0 表示不限
+	Month         string `json:"month"`        // YYYY-MM
+	MonthlyCount  int    `json:"monthly_count"`
+	MonthlyBudget int    `json:"monthly_budget"` // 0 表示不限
+	Exhausted     bool   `json:"exhausted"`
+	CircuitState  string `json:"circuit_state,omitempty"`
+}
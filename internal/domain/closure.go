@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// ClosureSource 休業資料來源
+type ClosureSource string
+
+const (
+	ClosureSourceAdmin      ClosureSource = "admin"       // 管理員設定
+	ClosureSourceUserReport ClosureSource = "user_report" // 使用者回報達門檻自動建立
+)
+
+// RestaurantClosure 餐廳臨時休業（日期以餐廳時區計算，含起訖日）
+type RestaurantClosure struct {
+	ID           int           `json:"id" db:"id"`
+	RestaurantID int           `json:"restaurant_id" db:"restaurant_id"`
+	StartDate    string        `json:"start_date" db:"start_date"` // YYYY-MM-DD
+	EndDate      string        `json:"end_date" db:"end_date"`     // YYYY-MM-DD
+	Reason       string        `json:"reason" db:"reason"`
+	Source       ClosureSource `json:"source" db:"source"`
+	CreatedBy    *int          `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+}
+
+// CreateClosureRequest 建立休業請求
+type CreateClosureRequest struct {
+	StartDate string `json:"start_date" validate:"required"` // YYYY-MM-DD
+	EndDate   string `json:"end_date"`                       // YYYY-MM-DD，空白表示只有一天
+	Reason    string `json:"reason" validate:"max=255"`
+}
+
+// ClosureReportResult 使用者休業回報結果
+type ClosureReportResult struct {
+	RestaurantID int                `json:"restaurant_id"`
+	ReportDate   string             `json:"report_date"`       // 餐廳時區的回報日期
+	ReportCount  int                `json:"report_count"`      // 當日回報人數
+	Closure      *RestaurantClosure `json:"closure,omitempty"` // 達門檻後建立的休業記錄
+}
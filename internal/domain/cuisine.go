@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CuisineProviderGoogle Google Places 地點類型
+	CuisineProviderGoogle = "google"
+	// UncategorizedCuisineSlug 未分類餐廳在統計中使用的識別碼
+	UncategorizedCuisineSlug = "uncategorized"
+)
+
+// uncategorizedCuisineNames 未分類餐廳的顯示名稱
+var uncategorizedCuisineNames = map[string]string{
+	LocaleZhTW: "餐廳",
+	LocaleEn:   "Restaurant",
+}
+
+// Cuisine 料理分類節點
+type Cuisine struct {
+	ID        int               `json:"id"`
+	Slug      string            `json:"slug"`
+	ParentID  *int              `json:"parent_id"`
+	Name      string            `json:"name"`               // 依請求語系顯示的名稱
+	Names     map[string]string `json:"names,omitempty"`    // 各語系名稱
+	Children  []Cuisine         `json:"children,omitempty"` // 子分類
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// CuisineProviderType 外部資料來源地點類型與料理分類的對應
+type CuisineProviderType struct {
+	Provider     string `json:"provider" validate:"required,max=20"`
+	ProviderType string `json:"provider_type" validate:"required,max=100"`
+	CuisineID    int    `json:"cuisine_id" validate:"required"`
+}
+
+// CreateCuisineRequest 建立料理分類請求
+type CreateCuisineRequest struct {
+	Slug     string            `json:"slug" validate:"required,max=50"`
+	ParentID *int              `json:"parent_id"`
+	Names    map[string]string `json:"names" validate:"required"` // 至少需包含預設語系名稱
+}
+
+// UpdateCuisineRequest 更新料理分類請求
+type UpdateCuisineRequest struct {
+	ParentID *int              `json:"parent_id"`
+	Names    map[string]string `json:"names" validate:"required"`
+}
+
+// CuisineTaxonomy 料理分類樹，提供名稱翻譯、子分類展開與外部類型對應
+type CuisineTaxonomy struct {
+	byID          map[int]*Cuisine
+	bySlug        map[string]*Cuisine
+	children      map[int][]int
+	roots         []int
+	providerTypes map[string]int
+}
+
+// NewCuisineTaxonomy 由料理分類與外部類型對應建立分類樹
+func NewCuisineTaxonomy(cuisines []Cuisine, providerTypes []CuisineProviderType) *CuisineTaxonomy {
+	t := &CuisineTaxonomy{
+		byID:          make(map[int]*Cuisine, len(cuisines)),
+		bySlug:        make(map[string]*Cuisine, len(cuisines)),
+		children:      make(map[int][]int),
+		providerTypes: make(map[string]int, len(providerTypes)),
+	}
+
+	for i := range cuisines {
+		cuisine := cuisines[i]
+		cuisine.Children = nil
+		t.byID[cuisine.ID] = &cuisine
+		t.bySlug[cuisine.Slug] = &cuisine
+	}
+
+	for _, id := range t.sortedIDs() {
+		cuisine := t.byID[id]
+		if cuisine.ParentID != nil {
+			if _, ok := t.byID[*cuisine.ParentID]; ok {
+				t.children[*cuisine.ParentID] = append(t.children[*cuisine.ParentID], id)
+				continue
+			}
+		}
+		t.roots = append(t.roots, id)
+	}
+
+	for _, mapping := range providerTypes {
+		t.providerTypes[providerTypeKey(mapping.Provider, mapping.ProviderType)] = mapping.CuisineID
+	}
+
+	return t
+}
+
+// Get 依 ID 取得料理分類
+func (t *CuisineTaxonomy) Get(id int) (*Cuisine, bool) {
+	cuisine, ok := t.byID[id]
+	return cuisine, ok
+}
+
+// Name 取得料理分類在指定語系的名稱，缺少翻譯時依序使用預設語系名稱與識別碼
+func (t *CuisineTaxonomy) Name(id *int, locale string) string {
+	if id == nil {
+		return ""
+	}
+	cuisine, ok := t.byID[*id]
+	if !ok {
+		return ""
+	}
+	if name := cuisine.Names[locale]; name != "" {
+		return name
+	}
+	if name := cuisine.Names[DefaultLocale]; name != "" {
+		return name
+	}
+	return cuisine.Slug
+}
+
+// Resolve 依 ID、識別碼或任一語系名稱（不分大小寫）找出料理分類
+func (t *CuisineTaxonomy) Resolve(value string) (*Cuisine, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, false
+	}
+
+	if id, err := strconv.Atoi(value); err == nil {
+		return t.Get(id)
+	}
+	if cuisine, ok := t.bySlug[strings.ToLower(value)]; ok {
+		return cuisine, true
+	}
+
+	for _, id := range t.sortedIDs() {
+		cuisine := t.byID[id]
+		for _, name := range cuisine.Names {
+			if strings.EqualFold(name, value) {
+				return cuisine, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Suggest 依輸入文字建議料理分類：識別碼或任一語系名稱開頭相符者優先，其次為包含輸入文字者
+func (t *CuisineTaxonomy) Suggest(query, locale string, limit int) []Cuisine {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return nil
+	}
+
+	var prefixMatches, containsMatches []Cuisine
+	for _, id := range t.sortedIDs() {
+		cuisine := t.byID[id]
+		candidates := []string{cuisine.Slug}
+		for _, name := range cuisine.Names {
+			candidates = append(candidates, name)
+		}
+
+		prefix, contains := false, false
+		for _, candidate := range candidates {
+			candidate = strings.ToLower(candidate)
+			if strings.HasPrefix(candidate, query) {
+				prefix = true
+				break
+			}
+			if strings.Contains(candidate, query) {
+				contains = true
+			}
+		}
+
+		suggestion := *cuisine
+		suggestion.Name = t.Name(&suggestion.ID, locale)
+		suggestion.Children = nil
+		switch {
+		case prefix:
+			prefixMatches = append(prefixMatches, suggestion)
+		case contains:
+			containsMatches = append(containsMatches, suggestion)
+		}
+	}
+
+	suggestions := append(prefixMatches, containsMatches...)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// DescendantIDs 取得料理分類本身與所有子孫分類的 ID
+func (t *CuisineTaxonomy) DescendantIDs(id int) []int {
+	ids := []int{id}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, t.children[ids[i]]...)
+	}
+	return ids
+}
+
+// IsDescendant 判斷 id 是否為 ancestorID 本身或其子孫分類
+func (t *CuisineTaxonomy) IsDescendant(id, ancestorID int) bool {
+	for _, descendant := range t.DescendantIDs(ancestorID) {
+		if descendant == id {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchProviderTypes 依外部地點類型找出料理分類，多個類型符合時取層級最深（最細）的分類
+func (t *CuisineTaxonomy) MatchProviderTypes(provider string, types []string) *int {
+	var matched *int
+	matchedDepth := -1
+
+	for _, placeType := range types {
+		id, ok := t.providerTypes[providerTypeKey(provider, placeType)]
+		if !ok {
+			continue
+		}
+		if depth := t.depth(id); depth > matchedDepth {
+			matchedID := id
+			matched = &matchedID
+			matchedDepth = depth
+		}
+	}
+
+	return matched
+}
+
+// Tree 取得指定語系的完整分類樹
+func (t *CuisineTaxonomy) Tree(locale string) []Cuisine {
+	return t.buildTree(t.roots, locale)
+}
+
+// buildTree 遞迴建立分類節點
+func (t *CuisineTaxonomy) buildTree(ids []int, locale string) []Cuisine {
+	nodes := make([]Cuisine, 0, len(ids))
+	for _, id := range ids {
+		node := *t.byID[id]
+		node.Name = t.Name(&node.ID, locale)
+		node.Children = t.buildTree(t.children[id], locale)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// depth 計算料理分類的層級（最上層為 0）
+func (t *CuisineTaxonomy) depth(id int) int {
+	depth := 0
+	cuisine, ok := t.byID[id]
+	// 以分類數量為上限，避免資料異常形成循環時無限迴圈
+	for ok && cuisine.ParentID != nil && depth < len(t.byID) {
+		cuisine, ok = t.byID[*cuisine.ParentID]
+		depth++
+	}
+	return depth
+}
+
+// sortedIDs 依 ID 排序的料理分類清單
+func (t *CuisineTaxonomy) sortedIDs() []int {
+	ids := make([]int, 0, len(t.byID))
+	for id := range t.byID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// UncategorizedCuisineName 未分類餐廳在指定語系的顯示名稱
+func UncategorizedCuisineName(locale string) string {
+	if name, ok := uncategorizedCuisineNames[locale]; ok {
+		return name
+	}
+	return uncategorizedCuisineNames[DefaultLocale]
+}
+
+// providerTypeKey 外部類型對應的查詢鍵
+func providerTypeKey(provider, providerType string) string {
+	return provider + ":" + providerType
+}
@@ -23,9 +23,71 @@ var (
 
 // 餐廳相關錯誤
 var (
-	ErrRestaurantNotFound = errors.New("餐廳不存在")
-	ErrInvalidLocation    = errors.New("地理位置無效")
-	ErrInvalidRadius      = errors.New("搜尋半徑無效")
+	ErrRestaurantNotFound         = errors.New("餐廳不存在")
+	ErrInvalidLocation            = errors.New("地理位置無效")
+	ErrInvalidRadius              = errors.New("搜尋半徑無效")
+	ErrInvalidViewport            = errors.New("地圖範圍無效")
+	ErrInvalidSearchQuery         = errors.New("無效的搜尋條件")
+	ErrRestaurantProviderIDExists = errors.New("此外部地點 ID 已被其他餐廳使用")
+	ErrRestaurantNoProvider       = errors.New("餐廳沒有外部資料來源")
+)
+
+// 餐廳合併相關錯誤
+var (
+	ErrInvalidRestaurantMerge     = errors.New("無法合併：保留與重複的餐廳必須是兩間不同且營業中的餐廳")
+	ErrRestaurantMergeNotFound    = errors.New("合併記錄不存在")
+	ErrMergeAlreadyUndone         = errors.New("此合併已復原")
+	ErrMergeUndoBlocked           = errors.New("保留的餐廳已再被合併，請先復原較新的合併")
+	ErrDuplicateCandidateNotFound = errors.New("疑似重複餐廳記錄不存在")
+	ErrInvalidDuplicateStatus     = errors.New("無效的處理狀態")
+)
+
+// 營業時間相關錯誤
+var (
+	ErrInvalidOpeningHours = errors.New("無效的營業時間")
+	ErrInvalidOpenAt       = errors.New("無效的營業時間查詢時間")
+)
+
+// 標籤相關錯誤
+var (
+	ErrTagNotFound        = errors.New("標籤不存在")
+	ErrTagExists          = errors.New("標籤已存在")
+	ErrInvalidTagCategory = errors.New("無效的標籤分類")
+	ErrUnknownTag         = errors.New("包含不存在的標籤")
+	ErrInvalidTagSlug     = errors.New("標籤識別碼只能包含小寫英數字與底線")
+)
+
+// 料理分類相關錯誤
+var (
+	ErrCuisineNotFound      = errors.New("料理分類不存在")
+	ErrCuisineExists        = errors.New("料理分類已存在")
+	ErrInvalidCuisine       = errors.New("無效的料理類型")
+	ErrInvalidCuisineParent = errors.New("無效的上層料理分類")
+	ErrInvalidCuisineSlug   = errors.New("料理分類識別碼只能包含小寫英數字與底線")
+	ErrMissingCuisineName   = errors.New("料理分類必須包含預設語系名稱")
+)
+
+// 評論相關錯誤
+var (
+	ErrReviewNotFound          = errors.New("評論不存在")
+	ErrInvalidVisitDate        = errors.New("無效的造訪日期")
+	ErrReviewAlreadyFlagged    = errors.New("已檢舉過此評論")
+	ErrCannotFlagOwnReview     = errors.New("無法檢舉自己的評論")
+	ErrInvalidModerationAction = errors.New("無效的審核動作")
+)
+
+// 菜單相關錯誤
+var (
+	ErrMenuSectionNotFound = errors.New("菜單分類不存在")
+	ErrMenuItemNotFound    = errors.New("菜單品項不存在")
+	ErrInvalidMenuImport   = errors.New("菜單匯入格式錯誤")
+	ErrMenuImportTooLarge  = errors.New("菜單匯入品項數量超過上限")
+)
+
+// 休業相關錯誤
+var (
+	ErrClosureNotFound    = errors.New("休業記錄不存在")
+	ErrInvalidClosureDate = errors.New("無效的休業日期")
 )
 
 // 最愛餐廳相關錯誤
@@ -42,6 +104,17 @@ var (
 	ErrGameAlreadyComplete = errors.New("遊戲已完成")
 )
 
+// 統計相關錯誤
+var (
+	ErrInvalidTimeZone  = errors.New("無效的時區")
+	ErrInvalidDateRange = errors.New("無效的日期範圍")
+)
+
+// 分析報表相關錯誤
+var (
+	ErrInvalidActivityKind = errors.New("無效的活動熱度類型")
+)
+
 // 廣告相關錯誤
 var (
 	ErrAdvertisementNotFound = errors.New("廣告不存在")
@@ -63,6 +136,10 @@ var (
 	ErrExternalAPIFailed = errors.New("外部 API 請求失敗")
 	ErrGoogleAPIFailed   = errors.New("google API 請求失敗")
 	ErrAPIQuotaExceeded  = errors.New("API 配額已用完")
+
+	ErrProviderPlaceNotFound = errors.New("外部資料來源查無此地點")
+	ErrProviderUnavailable   = errors.New("外部資料來源連續失敗，暫停請求")
+	ErrInvalidPhotoReference = errors.New("無效的照片參照")
 )
 
 // 檔案上傳錯誤
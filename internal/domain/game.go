@@ -25,6 +25,10 @@ type GameSession struct {
 	Result             *RestaurantWithDistance  `json:"result"`                                         // 遊戲結果
 	Restaurants        []RestaurantWithDistance `json:"restaurants"`                                    // 參與遊戲的餐廳列表
 	Advertisements     []Advertisement          `json:"advertisements"`                                 // 顯示的廣告
+	VetoedIDs          []int                    `json:"vetoed_restaurant_ids,omitempty"`                // 使用者否決的餐廳
+	Search             *SearchSummary           `json:"search,omitempty"`                               // 候選餐廳搜尋範圍
+	Debug              *GameDebugInfo           `json:"debug,omitempty"`                                // 權重調校資訊
+	StartGeohash       string                   `json:"-" db:"start_geohash"`                           // 遊戲開始位置（粗化後的 geohash 格網）
 	StartedAt          time.Time                `json:"started_at" db:"started_at"`
 	CompletedAt        *time.Time               `json:"completed_at" db:"completed_at"`
 	CreatedAt          time.Time                `json:"created_at" db:"created_at"`
@@ -36,6 +40,11 @@ type StartGameRequest struct {
 	Latitude  float64  `json:"latitude" validate:"required,latitude"`
 	Longitude float64  `json:"longitude" validate:"required,longitude"`
 	Radius    int      `json:"radius" validate:"min=100,max=10000"` // 搜尋半徑（公尺）
+	Debug     bool     `json:"debug"`                               // 回傳候選餐廳權重
+	OpenNow   bool     `json:"open_now"`                            // 只抽選目前營業中的餐廳
+	OpenAt    string   `json:"open_at"`                             // 只抽選指定時間營業的餐廳（RFC3339）
+	TagsAll   []string `json:"tags_all"`                            // 必須同時符合所有標籤
+	TagsAny   []string `json:"tags_any"`                            // 符合任一標籤即可
 }
 
 // GameResult 遊戲結果
@@ -50,5 +59,31 @@ type GameResult struct {
 type CompleteGameRequest struct {
 	SessionID            string `json:"session_id" validate:"required"`
 	SelectedRestaurantID int    `json:"selected_restaurant_id" validate:"required"`
-	ClickedAdID          *int   `json:"clicked_ad_id,omitempty"` // 如果有點擊廣告
+	ClickedAdID          *int   `json:"clicked_ad_id,omitempty"`         // 如果有點擊廣告
+	VetoedRestaurantIDs  []int  `json:"vetoed_restaurant_ids,omitempty"` // 遊戲中被否決的餐廳
+}
+
+// RestaurantHistory 使用者與特定餐廳的遊戲歷史
+type RestaurantHistory struct {
+	RestaurantID int        `json:"restaurant_id"`
+	ServedCount  int        `json:"served_count"`             // 出現在候選名單的次數
+	LastServedAt *time.Time `json:"last_served_at,omitempty"` // 最近一次出現
+	LastWonAt    *time.Time `json:"last_won_at,omitempty"`    // 最近一次被選中
+	LastVetoedAt *time.Time `json:"last_vetoed_at,omitempty"` // 最近一次被否決
+}
+
+// CandidateWeight 候選餐廳的抽選權重
+type CandidateWeight struct {
+	RestaurantID int     `json:"restaurant_id"`
+	Weight       float64 `json:"weight"`
+	Novel        bool    `json:"novel"`         // 從未出現在候選名單
+	WinDecay     float64 `json:"win_decay"`     // 近期被選中的衰減係數
+	VetoDecay    float64 `json:"veto_decay"`    // 近期被否決的衰減係數
+	CuisineBoost float64 `json:"cuisine_boost"` // 偏好料理加權係數
+}
+
+// GameDebugInfo 遊戲抽選調校資訊
+type GameDebugInfo struct {
+	Strategy string            `json:"strategy"`
+	Weights  []CandidateWeight `json:"weights"`
 }
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LocaleZhTW 繁體中文
+	LocaleZhTW = "zh-TW"
+	// LocaleEn 英文
+	LocaleEn = "en"
+	// DefaultLocale 未指定或不支援的語系時使用的預設語系
+	DefaultLocale = LocaleZhTW
+)
+
+// SupportedLocales 支援的顯示語系
+var SupportedLocales = []string{LocaleZhTW, LocaleEn}
+
+type localeContextKey struct{}
+
+// WithLocale 將顯示語系存入 context
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext 取得 context 中的顯示語系，未設定時回傳預設語系
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeContextKey{}).(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// ParseAcceptLanguage 依 Accept-Language 標頭的權重選出支援的語系
+// 完全相符優先，其次以主要語言比對（例如 en-US 對應 en、zh-Hant 對應 zh-TW）
+func ParseAcceptLanguage(header string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	for _, c := range candidates {
+		if locale := matchLocale(c.tag); locale != "" {
+			return locale
+		}
+	}
+
+	return DefaultLocale
+}
+
+// matchLocale 將語言標籤對應到支援的語系，無法對應時回傳空字串
+func matchLocale(tag string) string {
+	for _, locale := range SupportedLocales {
+		if strings.EqualFold(tag, locale) {
+			return locale
+		}
+	}
+
+	primary := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+	for _, locale := range SupportedLocales {
+		if strings.ToLower(strings.SplitN(locale, "-", 2)[0]) == primary {
+			return locale
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMenuCurrency 未指定幣別時使用的預設幣別
+	DefaultMenuCurrency = "TWD"
+	// MaxMenuImportItems 單次匯入的菜單品項上限
+	MaxMenuImportItems = 1000
+)
+
+// MenuSection 菜單分類（例如 主餐、飲料）
+type MenuSection struct {
+	ID           int        `json:"id" db:"id"`
+	RestaurantID int        `json:"restaurant_id" db:"restaurant_id"`
+	Name         string     `json:"name" db:"name" validate:"required,max=100"`
+	Position     int        `json:"position" db:"position"`
+	Items        []MenuItem `json:"items" db:"-"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// MenuItem 菜單品項
+type MenuItem struct {
+	ID           int       `json:"id" db:"id"`
+	RestaurantID int       `json:"restaurant_id" db:"restaurant_id"`
+	SectionID    *int      `json:"section_id" db:"section_id"`
+	Name         string    `json:"name" db:"name" validate:"required,max=200"`
+	Description  string    `json:"description" db:"description" validate:"max=1000"`
+	Price        float64   `json:"price" db:"price" validate:"min=0"`
+	Currency     string    `json:"currency" db:"currency" validate:"omitempty,len=3"` // ISO 4217，例如 TWD
+	ImageURL     string    `json:"image_url" db:"image_url" validate:"omitempty,url,max=500"`
+	IsAvailable  bool      `json:"is_available" db:"is_available"`
+	Position     int       `json:"position" db:"position"`
+	Tags         []string  `json:"tags" db:"-"` // 飲食標籤識別碼，例如 vegetarian
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Menu 餐廳完整菜單
+type Menu struct {
+	RestaurantID     int           `json:"restaurant_id"`
+	Sections         []MenuSection `json:"sections"`
+	Items            []MenuItem    `json:"items"`              // 未分類的品項
+	DerivePriceLevel bool          `json:"derive_price_level"` // 價位等級是否由菜單價格計算
+	PriceLevel       int           `json:"price_level"`        // 餐廳目前的價位等級
+}
+
+// MenuSectionRequest 建立或更新菜單分類請求
+type MenuSectionRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Position int    `json:"position"`
+}
+
+// MenuItemRequest 建立或更新菜單品項請求
+type MenuItemRequest struct {
+	SectionID   *int     `json:"section_id"`
+	Name        string   `json:"name" validate:"required,max=200"`
+	Description string   `json:"description" validate:"max=1000"`
+	Price       float64  `json:"price" validate:"min=0"`
+	Currency    string   `json:"currency" validate:"omitempty,len=3"`
+	ImageURL    string   `json:"image_url" validate:"omitempty,url,max=500"`
+	IsAvailable *bool    `json:"is_available"` // 未指定時預設供應中
+	Position    int      `json:"position"`
+	Tags        []string `json:"tags"`
+}
+
+// MenuSettingsRequest 菜單設定請求
+type MenuSettingsRequest struct {
+	DerivePriceLevel bool `json:"derive_price_level"`
+}
+
+// MenuImport 菜單批次匯入內容（會取代餐廳原有菜單）
+type MenuImport struct {
+	Sections []MenuImportSection `json:"sections" validate:"dive"`
+	Items    []MenuItemRequest   `json:"items" validate:"dive"` // 未分類的品項
+}
+
+// MenuImportSection 批次匯入的菜單分類
+type MenuImportSection struct {
+	Name  string            `json:"name" validate:"required,max=100"`
+	Items []MenuItemRequest `json:"items" validate:"dive"`
+}
+
+// ItemCount 匯入內容的品項總數
+func (m *MenuImport) ItemCount() int {
+	count := len(m.Items)
+	for _, section := range m.Sections {
+		count += len(section.Items)
+	}
+	return count
+}
+
+// TagSlugs 匯入內容使用到的所有標籤識別碼
+func (m *MenuImport) TagSlugs() []string {
+	var slugs []string
+	for _, item := range m.Items {
+		slugs = append(slugs, item.Tags...)
+	}
+	for _, section := range m.Sections {
+		for _, item := range section.Items {
+			slugs = append(slugs, item.Tags...)
+		}
+	}
+	return NormalizeTagSlugs(slugs)
+}
+
+// ParseMenuCSV 解析菜單 CSV，第一列為欄位名稱
+// 欄位：section, name, description, price, currency, image_url, tags（以 | 分隔）, available；name 與 price 為必填
+func ParseMenuCSV(r io.Reader) (*MenuImport, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrInvalidMenuImport
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// 去除 Excel 匯出 CSV 開頭的 BOM
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, ErrInvalidMenuImport
+	}
+	if _, ok := columns["price"]; !ok {
+		return nil, ErrInvalidMenuImport
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	menu := &MenuImport{}
+	sectionIndex := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidMenuImport
+		}
+
+		price, err := strconv.ParseFloat(field(record, "price"), 64)
+		if err != nil {
+			return nil, ErrInvalidMenuImport
+		}
+
+		item := MenuItemRequest{
+			Name:        field(record, "name"),
+			Description: field(record, "description"),
+			Price:       price,
+			Currency:    strings.ToUpper(field(record, "currency")),
+			ImageURL:    field(record, "image_url"),
+		}
+		if tags := field(record, "tags"); tags != "" {
+			item.Tags = NormalizeTagSlugs(strings.Split(tags, "|"))
+		}
+		if available := field(record, "available"); available != "" {
+			value, err := strconv.ParseBool(available)
+			if err != nil {
+				return nil, ErrInvalidMenuImport
+			}
+			item.IsAvailable = &value
+		}
+
+		section := field(record, "section")
+		if section == "" {
+			menu.Items = append(menu.Items, item)
+			continue
+		}
+		i, ok := sectionIndex[section]
+		if !ok {
+			i = len(menu.Sections)
+			sectionIndex[section] = i
+			menu.Sections = append(menu.Sections, MenuImportSection{Name: section})
+		}
+		menu.Sections[i].Items = append(menu.Sections[i].Items, item)
+	}
+
+	return menu, nil
+}
+
+// DerivePriceLevel 依菜單品項價格中位數計算價位等級（1-4）
+// thresholds 為等級 1/2、2/3、3/4 的分界價格，沒有價格資料時回傳 0
+func DerivePriceLevel(prices []float64, thresholds []float64) int {
+	if len(prices) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), prices...)
+	sort.Float64s(sorted)
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	level := 1
+	for _, threshold := range thresholds {
+		if median >= threshold && level < 4 {
+			level++
+		}
+	}
+	return level
+}
@@ -0,0 +1,504 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// WalkingMetersPerMinute 步行速度（公尺／分鐘），用於將步行時間換算為搜尋半徑
+	WalkingMetersPerMinute = 80
+	// DefaultWalkingMinutes 「走路可到」等未指定時間的步行距離
+	DefaultWalkingMinutes = 10
+	// MinParsedRadius 解析出的搜尋半徑下限（公尺）
+	MinParsedRadius = 100
+	// MaxParsedRadius 解析出的搜尋半徑上限（公尺）
+	MaxParsedRadius = 10000
+)
+
+// QueryTermKind 自然語言查詢中可辨識的條件類型
+type QueryTermKind string
+
+const (
+	QueryTermCuisine      QueryTermKind = "cuisine"
+	QueryTermPriceLevel   QueryTermKind = "price_level"
+	QueryTermMaxItemPrice QueryTermKind = "max_item_price"
+	QueryTermDistance     QueryTermKind = "distance"
+	QueryTermWalkingTime  QueryTermKind = "walking_time"
+	QueryTermOpenNow      QueryTermKind = "open_now"
+	QueryTermDietary      QueryTermKind = "dietary"
+)
+
+// QueryTerm 自然語言查詢中被辨識的片段
+type QueryTerm struct {
+	Text  string        `json:"text"`  // 原始文字片段
+	Kind  QueryTermKind `json:"kind"`  // 條件類型
+	Value string        `json:"value"` // 解析結果，例如 ramen、800、vegetarian
+}
+
+// QueryInterpretation 自然語言查詢的解析結果
+type QueryInterpretation struct {
+	Query          string      `json:"query"`
+	Cuisine        string      `json:"cuisine,omitempty"` // 料理分類識別碼
+	CuisineID      *int        `json:"cuisine_id,omitempty"`
+	MinPriceLevel  int         `json:"min_price_level,omitempty"`
+	MaxPriceLevel  int         `json:"max_price_level,omitempty"`
+	MaxItemPrice   float64     `json:"max_item_price,omitempty"`
+	Radius         int         `json:"radius,omitempty"` // 公尺
+	WalkingMinutes int         `json:"walking_minutes,omitempty"`
+	OpenNow        bool        `json:"open_now"`
+	Tags           []string    `json:"tags"`     // 飲食限制標籤識別碼
+	Terms          []QueryTerm `json:"terms"`    // 依原文順序排列的辨識片段
+	Keywords       []string    `json:"keywords"` // 未能辨識的字詞
+}
+
+// ApplyTo 將解析結果套用到餐廳搜尋參數（只覆寫有解析出的條件）
+func (q *QueryInterpretation) ApplyTo(params *RestaurantSearchParams) {
+	if q.Cuisine != "" {
+		params.Cuisine = q.Cuisine
+	}
+	if q.MinPriceLevel > 0 {
+		params.MinPriceLevel = q.MinPriceLevel
+	}
+	if q.MaxPriceLevel > 0 {
+		params.MaxPriceLevel = q.MaxPriceLevel
+	}
+	if q.MaxItemPrice > 0 {
+		params.MaxItemPrice = q.MaxItemPrice
+	}
+	if q.Radius > 0 {
+		params.Radius = q.Radius
+	}
+	if q.OpenNow {
+		params.OpenNow = true
+	}
+	if len(q.Tags) > 0 {
+		params.TagsAll = NormalizeTagSlugs(append(params.TagsAll, q.Tags...))
+	}
+}
+
+// NaturalQueryParams 自然語言餐廳搜尋參數
+type NaturalQueryParams struct {
+	Query     string  `form:"q" json:"q" validate:"required,max=200"`
+	Latitude  float64 `form:"latitude" json:"latitude" validate:"required,latitude"`
+	Longitude float64 `form:"longitude" json:"longitude" validate:"required,longitude"`
+	Radius    int     `form:"radius" json:"radius" validate:"omitempty,min=100,max=10000"` // 查詢未指定距離時使用
+	Limit     int     `form:"limit" json:"limit" validate:"min=0,max=50"`
+}
+
+// NaturalQueryResult 自然語言餐廳搜尋結果
+type NaturalQueryResult struct {
+	Interpretation *QueryInterpretation     `json:"interpretation"`
+	Restaurants    []RestaurantWithDistance `json:"restaurants"`
+	Count          int                      `json:"count"`
+}
+
+// queryPhrase 詞彙表中的片語
+type queryPhrase struct {
+	text  string
+	kind  QueryTermKind
+	value string
+}
+
+// QueryVocabulary 自然語言查詢詞彙表（料理分類、飲食標籤與內建的價格、營業狀態用語）
+type QueryVocabulary struct {
+	taxonomy *CuisineTaxonomy
+	phrases  []queryPhrase
+}
+
+// 內建用語：營業中
+var openNowPhrases = []string{
+	"現在有開", "現在營業", "營業中", "還有開", "有開", "開著",
+	"open now", "open right now", "currently open", "still open", "open",
+}
+
+// 內建用語：價位等級，正值為最高等級，負值為最低等級
+var priceLevelPhrases = map[string]int{
+	"便宜": 1, "平價": 1, "銅板": 1, "小資": 1, "省錢": 1,
+	"cheap": 1, "inexpensive": 1, "budget": 1, "affordable": 1,
+	"不貴": 2, "中價位": 2, "價格適中": 2,
+	"moderate": 2, "mid range": 2, "reasonably priced": 2, "not expensive": 2, "not too expensive": 2,
+	"高級": -3, "高檔": -3, "奢華": -3, "精緻": -3,
+	"expensive": -3, "fancy": -3, "upscale": -3, "fine dining": -3, "luxury": -3, "high end": -3,
+}
+
+// 內建料理分類同義詞（依分類識別碼），分類不存在時略過
+var cuisineSynonyms = map[string][]string{
+	"chinese":    {"中式", "中餐", "中菜"},
+	"japanese":   {"日式", "日本料理", "日本菜", "日料"},
+	"korean":     {"韓式", "韓國料理", "韓國菜"},
+	"thai":       {"泰式", "泰國菜", "泰國料理"},
+	"vietnamese": {"越式", "越南菜", "河粉", "pho"},
+	"indian":     {"印度菜"},
+	"italian":    {"義式", "義大利菜", "義大利麵", "pasta"},
+	"french":     {"法式", "法國菜"},
+	"american":   {"美式", "burger", "漢堡"},
+	"mexican":    {"墨西哥菜", "taco"},
+	"ramen":      {"らーめん"},
+	"pizza":      {"比薩", "pizzeria"},
+	"steakhouse": {"steak", "牛排館"},
+	"seafood":    {"海產"},
+	"fast_food":  {"速食"},
+	"cafe":       {"咖啡", "coffee", "café"},
+	"bakery":     {"麵包", "bread"},
+	"bar":        {"pub", "居酒屋"},
+}
+
+// 內建飲食標籤同義詞（依標籤識別碼），標籤不存在時略過
+var dietarySynonyms = map[string][]string{
+	"vegetarian":  {"素食", "吃素", "veggie", "veg"},
+	"vegan":       {"全素", "純素", "plant based"},
+	"halal":       {"清真"},
+	"gluten_free": {"無麩質", "gluten free"},
+}
+
+// 停用詞：解析後不列入未辨識字詞
+var (
+	queryStopPhrasesZh = []string{
+		"我想吃", "想吃", "我要", "有沒有", "推薦", "附近", "餐廳", "一家", "一間",
+		"以內", "之內", "以下", "低於", "不超過", "少於", "左右", "可以", "的", "找", "吃", "店", "內",
+	}
+	queryStopWordsEn = map[string]bool{
+		"a": true, "an": true, "the": true, "some": true, "me": true, "i": true, "want": true, "wanna": true,
+		"find": true, "for": true, "food": true, "place": true, "places": true, "restaurant": true,
+		"restaurants": true, "near": true, "nearby": true, "around": true, "here": true, "within": true,
+		"in": true, "to": true, "eat": true, "with": true, "and": true, "that": true, "is": true, "are": true,
+		"under": true, "below": true, "less": true, "than": true, "of": true, "distance": true, "walk": true,
+		"something": true, "good": true, "best": true, "by": true, "close": true, "max": true, "up": true,
+	}
+)
+
+// 數字與距離、時間、金額樣式（在小寫且轉為半形的文字上比對）
+var (
+	walkingZhPattern       = regexp.MustCompile(`(?:走路|步行)\s*(\d+|[一二兩三四五六七八九十]+)\s*分(?:鐘)?(?:以內|之內|內)?`)
+	walkingEnPattern       = regexp.MustCompile(`(\d+)\s*-?\s*(?:min|mins|minute|minutes)\s+(?:walk|walking)(?:\s+distance)?\b`)
+	walkingEnPrefixPattern = regexp.MustCompile(`(?:walk|walking)\s+(?:distance\s+)?(?:of\s+|within\s+)?(\d+)\s*(?:min|mins|minute|minutes)\b`)
+	walkingVaguePattern    = regexp.MustCompile(`walking distance|走路可到|走路就到|步行可到`)
+	distanceZhPattern      = regexp.MustCompile(`(\d+(?:\.\d+)?|[一二兩三四五六七八九十]+)\s*(公里|千米|公尺|米)(?:以內|之內|內)?`)
+	distanceEnPattern      = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(km|kilometers?|kilometres?|meters?|metres?|m|miles?|mi)\b`)
+	moneyZhPattern         = regexp.MustCompile(`(?:(?:nt\$|\$|新台幣|台幣)\s*(\d+)\s*(?:元|塊)?|(\d+)\s*(?:元|塊))\s*(?:以下|以內|之內|內|有找)?`)
+	moneyEnPattern         = regexp.MustCompile(`(?:under|below|less than|up to|max|<)\s*(?:nt\$|\$|ntd\s*)?(\d+)(?:\s*(?:ntd|nt|dollars?))?\b`)
+)
+
+// NewQueryVocabulary 由料理分類樹與飲食標籤建立詞彙表
+func NewQueryVocabulary(taxonomy *CuisineTaxonomy, dietaryTags []Tag) *QueryVocabulary {
+	v := &QueryVocabulary{taxonomy: taxonomy}
+
+	for _, phrase := range openNowPhrases {
+		v.add(phrase, QueryTermOpenNow, "true")
+	}
+	for phrase, level := range priceLevelPhrases {
+		v.add(phrase, QueryTermPriceLevel, strconv.Itoa(level))
+	}
+
+	for _, tag := range dietaryTags {
+		v.add(tag.Name, QueryTermDietary, tag.Slug)
+		v.add(tag.Slug, QueryTermDietary, tag.Slug)
+		for _, synonym := range dietarySynonyms[tag.Slug] {
+			v.add(synonym, QueryTermDietary, tag.Slug)
+		}
+	}
+
+	if taxonomy != nil {
+		for _, id := range taxonomy.sortedIDs() {
+			cuisine := taxonomy.byID[id]
+			v.add(cuisine.Slug, QueryTermCuisine, cuisine.Slug)
+			for _, name := range cuisine.Names {
+				v.add(name, QueryTermCuisine, cuisine.Slug)
+				// 「日式料理」也接受「日式」
+				if stem := strings.TrimSuffix(name, "料理"); stem != name && utf8.RuneCountInString(stem) >= 2 {
+					v.add(stem, QueryTermCuisine, cuisine.Slug)
+				}
+			}
+			for _, synonym := range cuisineSynonyms[cuisine.Slug] {
+				v.add(synonym, QueryTermCuisine, cuisine.Slug)
+			}
+		}
+	}
+
+	// 較長的片語優先比對，避免「不貴」被拆成「貴」、「全素」被拆成「素」
+	sort.SliceStable(v.phrases, func(i, j int) bool {
+		return len(v.phrases[i].text) > len(v.phrases[j].text)
+	})
+	return v
+}
+
+// add 加入片語（以正規化後的文字儲存，底線與連字號視為空白）
+func (v *QueryVocabulary) add(text string, kind QueryTermKind, value string) {
+	text = strings.Join(strings.Fields(normalizeQueryText(strings.NewReplacer("_", " ", "-", " ").Replace(text))), " ")
+	if text == "" {
+		return
+	}
+	for _, phrase := range v.phrases {
+		if phrase.text == text && phrase.kind == kind {
+			return
+		}
+	}
+	v.phrases = append(v.phrases, queryPhrase{text: text, kind: kind, value: value})
+}
+
+// queryMatch 解析過程中辨識到的片段與其位置
+type queryMatch struct {
+	term  QueryTerm
+	index int
+}
+
+// ParseNaturalQuery 以規則解析自然語言查詢（支援繁體中文與英文）
+// 依序辨識步行時間、距離、金額，再以詞彙表比對營業中、價位、飲食限制與料理分類，其餘字詞列為未辨識
+func ParseNaturalQuery(query string, vocabulary *QueryVocabulary) *QueryInterpretation {
+	result := &QueryInterpretation{
+		Query:    strings.TrimSpace(query),
+		Tags:     []string{},
+		Terms:    []QueryTerm{},
+		Keywords: []string{},
+	}
+
+	// 比對過的片段以等長空白取代，保留其他片段的位置
+	text := normalizeQueryText(query)
+	var matches []queryMatch
+	consume := func(start, end int, term QueryTerm) {
+		term.Text = strings.TrimSpace(text[start:end])
+		matches = append(matches, queryMatch{term: term, index: start})
+		text = text[:start] + strings.Repeat(" ", end-start) + text[end:]
+	}
+
+	// 步行時間
+	for _, pattern := range []*regexp.Regexp{walkingZhPattern, walkingEnPattern, walkingEnPrefixPattern} {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			minutes, ok := parseQueryNumber(text[loc[2]:loc[3]])
+			if !ok || minutes <= 0 {
+				continue
+			}
+			result.WalkingMinutes = int(minutes)
+			result.Radius = clampRadius(minutes * WalkingMetersPerMinute)
+			consume(loc[0], loc[1], QueryTerm{Kind: QueryTermWalkingTime, Value: strconv.Itoa(result.Radius)})
+		}
+	}
+	if loc := walkingVaguePattern.FindStringIndex(text); loc != nil && result.Radius == 0 {
+		result.WalkingMinutes = DefaultWalkingMinutes
+		result.Radius = clampRadius(DefaultWalkingMinutes * WalkingMetersPerMinute)
+		consume(loc[0], loc[1], QueryTerm{Kind: QueryTermWalkingTime, Value: strconv.Itoa(result.Radius)})
+	}
+
+	// 距離
+	for _, pattern := range []*regexp.Regexp{distanceZhPattern, distanceEnPattern} {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			value, ok := parseQueryNumber(text[loc[2]:loc[3]])
+			if !ok || value <= 0 {
+				continue
+			}
+			meters := value * distanceUnitMeters(text[loc[4]:loc[5]])
+			if result.Radius == 0 {
+				result.Radius = clampRadius(meters)
+			}
+			consume(loc[0], loc[1], QueryTerm{Kind: QueryTermDistance, Value: strconv.Itoa(clampRadius(meters))})
+		}
+	}
+
+	// 金額上限
+	for _, pattern := range []*regexp.Regexp{moneyZhPattern, moneyEnPattern} {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			var amount string
+			for group := 1; group*2+1 < len(loc); group++ {
+				if loc[group*2] >= 0 {
+					amount = text[loc[group*2]:loc[group*2+1]]
+					break
+				}
+			}
+			value, err := strconv.ParseFloat(amount, 64)
+			if err != nil || value <= 0 {
+				continue
+			}
+			result.MaxItemPrice = value
+			consume(loc[0], loc[1], QueryTerm{Kind: QueryTermMaxItemPrice, Value: amount})
+		}
+	}
+
+	// 詞彙表片語：英文需對齊字詞邊界（可加複數 s／es），中文以子字串比對
+	for _, phrase := range vocabulary.phrases {
+		for {
+			start, end := findQueryPhrase(text, phrase.text)
+			if start < 0 {
+				break
+			}
+			consume(start, end, QueryTerm{Kind: phrase.kind, Value: phrase.value})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].index < matches[j].index
+	})
+
+	for _, match := range matches {
+		term := match.term
+		switch term.Kind {
+		case QueryTermOpenNow:
+			result.OpenNow = true
+		case QueryTermPriceLevel:
+			level, _ := strconv.Atoi(term.Value)
+			if level > 0 {
+				result.MaxPriceLevel = level
+				term.Value = "<=" + term.Value
+			} else {
+				result.MinPriceLevel = -level
+				term.Value = ">=" + strconv.Itoa(-level)
+			}
+		case QueryTermDietary:
+			result.Tags = NormalizeTagSlugs(append(result.Tags, term.Value))
+		case QueryTermCuisine:
+			// 只採用第一個出現的料理分類，其餘列為未辨識字詞
+			if result.Cuisine != "" && result.Cuisine != term.Value {
+				result.Keywords = append(result.Keywords, term.Text)
+				continue
+			}
+			result.Cuisine = term.Value
+			if vocabulary.taxonomy != nil {
+				if cuisine, ok := vocabulary.taxonomy.bySlug[term.Value]; ok {
+					id := cuisine.ID
+					result.CuisineID = &id
+				}
+			}
+		}
+		result.Terms = append(result.Terms, term)
+	}
+
+	result.Keywords = append(result.Keywords, queryKeywords(text)...)
+	return result
+}
+
+// normalizeQueryText 轉為小寫、全形字元轉半形，標點符號（金額與小數點除外）轉為空白
+func normalizeQueryText(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == '　':
+			r = ' '
+		case r >= '！' && r <= '～':
+			r = unicode.ToLower(r - 0xfee0)
+		}
+		if unicode.IsPunct(r) && r != '$' && r != '.' && r != '<' && r != '-' && r != '\'' {
+			r = ' '
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// findQueryPhrase 在文字中尋找片語，回傳位元組位置；找不到時回傳 -1
+func findQueryPhrase(text, phrase string) (int, int) {
+	if !isASCII(phrase) {
+		start := strings.Index(text, phrase)
+		if start < 0 {
+			return -1, -1
+		}
+		return start, start + len(phrase)
+	}
+
+	// 連字號在英文中視為空白，例如 gluten-free
+	spaced := strings.ReplaceAll(text, "-", " ")
+	for offset := 0; offset < len(spaced); {
+		i := strings.Index(spaced[offset:], phrase)
+		if i < 0 {
+			break
+		}
+		start := offset + i
+		end := start + len(phrase)
+		for _, suffix := range []string{"es", "s"} {
+			if strings.HasPrefix(spaced[end:], suffix) && isQueryWordBoundary(spaced, end+len(suffix)) {
+				end += len(suffix)
+				break
+			}
+		}
+		if isQueryWordBoundary(spaced, start-1) && isQueryWordBoundary(spaced, end) {
+			return start, end
+		}
+		offset = start + 1
+	}
+	return -1, -1
+}
+
+// isQueryWordBoundary 判斷位置是否為英文字詞邊界（字串頭尾或非英數字元）
+func isQueryWordBoundary(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return true
+	}
+	c := text[i]
+	return !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '\'')
+}
+
+// queryKeywords 取出未辨識的字詞（去除停用詞）
+func queryKeywords(text string) []string {
+	for _, stop := range queryStopPhrasesZh {
+		text = strings.ReplaceAll(text, stop, " ")
+	}
+
+	keywords := []string{}
+	for _, word := range strings.Fields(strings.ReplaceAll(text, "-", " ")) {
+		word = strings.Trim(word, ".$<'")
+		if word == "" || queryStopWordsEn[word] {
+			continue
+		}
+		keywords = append(keywords, word)
+	}
+	return keywords
+}
+
+// parseQueryNumber 解析阿拉伯數字或 99 以內的中文數字
+func parseQueryNumber(text string) (float64, bool) {
+	if value, err := strconv.ParseFloat(text, 64); err == nil {
+		return value, true
+	}
+
+	digits := map[rune]int{'一': 1, '二': 2, '兩': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	runes := []rune(text)
+	switch {
+	case len(runes) == 1 && runes[0] == '十':
+		return 10, true
+	case len(runes) == 1:
+		value, ok := digits[runes[0]]
+		return float64(value), ok
+	case len(runes) == 2 && runes[0] == '十':
+		value, ok := digits[runes[1]]
+		return float64(10 + value), ok
+	case len(runes) == 2 && runes[1] == '十':
+		value, ok := digits[runes[0]]
+		return float64(value * 10), ok
+	case len(runes) == 3 && runes[1] == '十':
+		tens, ok1 := digits[runes[0]]
+		ones, ok2 := digits[runes[2]]
+		return float64(tens*10 + ones), ok1 && ok2
+	}
+	return 0, false
+}
+
+// distanceUnitMeters 距離單位換算為公尺
+func distanceUnitMeters(unit string) float64 {
+	switch {
+	case unit == "公里" || unit == "千米" || strings.HasPrefix(unit, "k"):
+		return 1000
+	case strings.HasPrefix(unit, "mi"):
+		return 1609
+	default:
+		return 1
+	}
+}
+
+// clampRadius 將搜尋半徑限制在允許範圍內
+func clampRadius(meters float64) int {
+	return int(math.Max(MinParsedRadius, math.Min(MaxParsedRadius, math.Round(meters))))
+}
+
+// isASCII 判斷字串是否只包含 ASCII 字元
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testQueryVocabulary 以預設料理分類與飲食標籤建立測試用詞彙表
+func testQueryVocabulary() *QueryVocabulary {
+	intPtr := func(v int) *int { return &v }
+	cuisines := []Cuisine{
+		{ID: 1, Slug: "asian", Names: map[string]string{LocaleZhTW: "亞洲料理", LocaleEn: "Asian"}},
+		{ID: 2, Slug: "western", Names: map[string]string{LocaleZhTW: "西式料理", LocaleEn: "Western"}},
+		{ID: 3, Slug: "fast_food", Names: map[string]string{LocaleZhTW: "快餐", LocaleEn: "Fast Food"}},
+		{ID: 4, Slug: "bakery", Names: map[string]string{LocaleZhTW: "烘焙", LocaleEn: "Bakery"}},
+		{ID: 10, Slug: "japanese", ParentID: intPtr(1), Names: map[string]string{LocaleZhTW: "日式料理", LocaleEn: "Japanese"}},
+		{ID: 11, Slug: "thai", ParentID: intPtr(1), Names: map[string]string{LocaleZhTW: "泰式料理", LocaleEn: "Thai"}},
+		{ID: 12, Slug: "italian", ParentID: intPtr(2), Names: map[string]string{LocaleZhTW: "義式料理", LocaleEn: "Italian"}},
+		{ID: 20, Slug: "ramen", ParentID: intPtr(10), Names: map[string]string{LocaleZhTW: "拉麵", LocaleEn: "Ramen"}},
+		{ID: 21, Slug: "sushi", ParentID: intPtr(10), Names: map[string]string{LocaleZhTW: "壽司", LocaleEn: "Sushi"}},
+		{ID: 22, Slug: "pizza", ParentID: intPtr(12), Names: map[string]string{LocaleZhTW: "披薩", LocaleEn: "Pizza"}},
+	}
+	tags := []Tag{
+		{ID: 1, Slug: "vegetarian", Name: "素食", Category: TagCategoryDietary},
+		{ID: 2, Slug: "vegan", Name: "全素", Category: TagCategoryDietary},
+		{ID: 3, Slug: "halal", Name: "清真", Category: TagCategoryDietary},
+		{ID: 4, Slug: "gluten_free", Name: "無麩質", Category: TagCategoryDietary},
+	}
+	return NewQueryVocabulary(NewCuisineTaxonomy(cuisines, nil), tags)
+}
+
+func TestParseNaturalQuery(t *testing.T) {
+	vocabulary := testQueryVocabulary()
+
+	tests := []struct {
+		name           string
+		query          string
+		cuisine        string
+		minPriceLevel  int
+		maxPriceLevel  int
+		maxItemPrice   float64
+		radius         int
+		walkingMinutes int
+		openNow        bool
+		tags           []string
+		keywords       []string
+	}{
+		{
+			name:           "中文：便宜拉麵走路十分鐘",
+			query:          "便宜的拉麵 走路10分鐘內",
+			cuisine:        "ramen",
+			maxPriceLevel:  1,
+			radius:         800,
+			walkingMinutes: 10,
+			tags:           []string{},
+			keywords:       []string{},
+		},
+		{
+			name:           "中文：中文數字步行時間",
+			query:          "步行十五分鐘 壽司",
+			cuisine:        "sushi",
+			radius:         1200,
+			walkingMinutes: 15,
+			tags:           []string{},
+			keywords:       []string{},
+		},
+		{
+			name:     "中文：營業中素食與公里距離",
+			query:    "附近3公里內營業中的素食餐廳",
+			radius:   3000,
+			openNow:  true,
+			tags:     []string{"vegetarian"},
+			keywords: []string{},
+		},
+		{
+			name:         "中文：全素不被拆成素食，金額上限",
+			query:        "我想吃全素 200元以下",
+			maxItemPrice: 200,
+			tags:         []string{"vegan"},
+			keywords:     []string{},
+		},
+		{
+			name:          "中文：高級日式料理與公尺",
+			query:         "高級日式料理 500公尺",
+			cuisine:       "japanese",
+			minPriceLevel: 3,
+			radius:        500,
+			tags:          []string{},
+			keywords:      []string{},
+		},
+		{
+			name:          "中文：不貴不被拆成其他詞，未辨識字詞保留",
+			query:         "不貴的泰式 酸辣",
+			cuisine:       "thai",
+			maxPriceLevel: 2,
+			tags:          []string{},
+			keywords:      []string{"酸辣"},
+		},
+		{
+			name:          "中文：全形字元與有找",
+			query:         "ＮＴ＄３００有找 便宜披薩",
+			cuisine:       "pizza",
+			maxPriceLevel: 1,
+			maxItemPrice:  300,
+			tags:          []string{},
+			keywords:      []string{},
+		},
+		{
+			name:          "英文：cheap spicy noodles open now",
+			query:         "cheap spicy noodles open now",
+			maxPriceLevel: 1,
+			openNow:       true,
+			tags:          []string{},
+			keywords:      []string{"spicy", "noodles"},
+		},
+		{
+			name:           "英文：步行分鐘與料理",
+			query:          "Ramen within a 5 minute walk",
+			cuisine:        "ramen",
+			radius:         400,
+			walkingMinutes: 5,
+			tags:           []string{},
+			keywords:       []string{},
+		},
+		{
+			name:     "英文：英里距離與複數料理",
+			query:    "pizzas within 2 miles",
+			cuisine:  "pizza",
+			radius:   3218,
+			tags:     []string{},
+			keywords: []string{},
+		},
+		{
+			name:          "英文：多個料理時採用第一個",
+			query:         "upscale sushi or italian",
+			cuisine:       "sushi",
+			minPriceLevel: 3,
+			tags:          []string{},
+			keywords:      []string{"italian", "or"},
+		},
+		{
+			name:         "英文：飲食限制與金額上限",
+			query:        "Gluten-free vegan bakery under $15",
+			cuisine:      "bakery",
+			maxItemPrice: 15,
+			tags:         []string{"gluten_free", "vegan"},
+			keywords:     []string{},
+		},
+		{
+			name:           "英文：步行可到與多字料理名稱",
+			query:          "halal fast food walking distance",
+			cuisine:        "fast_food",
+			radius:         800,
+			walkingMinutes: 10,
+			tags:           []string{"halal"},
+			keywords:       []string{},
+		},
+		{
+			name:     "英文：公尺距離並限制半徑",
+			query:    "thai food 50m",
+			cuisine:  "thai",
+			radius:   MinParsedRadius,
+			tags:     []string{},
+			keywords: []string{},
+		},
+		{
+			name:     "無法辨識的查詢",
+			query:    "something tasty",
+			tags:     []string{},
+			keywords: []string{"tasty"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseNaturalQuery(tt.query, vocabulary)
+
+			if got.Cuisine != tt.cuisine {
+				t.Errorf("Cuisine = %q, want %q", got.Cuisine, tt.cuisine)
+			}
+			if (got.CuisineID != nil) != (tt.cuisine != "") {
+				t.Errorf("CuisineID = %v, want set = %v", got.CuisineID, tt.cuisine != "")
+			}
+			if got.MinPriceLevel != tt.minPriceLevel {
+				t.Errorf("MinPriceLevel = %d, want %d", got.MinPriceLevel, tt.minPriceLevel)
+			}
+			if got.MaxPriceLevel != tt.maxPriceLevel {
+				t.Errorf("MaxPriceLevel = %d, want %d", got.MaxPriceLevel, tt.maxPriceLevel)
+			}
+			if got.MaxItemPrice != tt.maxItemPrice {
+				t.Errorf("MaxItemPrice = %v, want %v", got.MaxItemPrice, tt.maxItemPrice)
+			}
+			if got.Radius != tt.radius {
+				t.Errorf("Radius = %d, want %d", got.Radius, tt.radius)
+			}
+			if got.WalkingMinutes != tt.walkingMinutes {
+				t.Errorf("WalkingMinutes = %d, want %d", got.WalkingMinutes, tt.walkingMinutes)
+			}
+			if got.OpenNow != tt.openNow {
+				t.Errorf("OpenNow = %v, want %v", got.OpenNow, tt.openNow)
+			}
+			if !reflect.DeepEqual(got.Tags, tt.tags) {
+				t.Errorf("Tags = %v, want %v", got.Tags, tt.tags)
+			}
+			if !reflect.DeepEqual(got.Keywords, tt.keywords) {
+				t.Errorf("Keywords = %v, want %v", got.Keywords, tt.keywords)
+			}
+		})
+	}
+}
+
+func TestQueryInterpretationApplyTo(t *testing.T) {
+	params := &RestaurantSearchParams{
+		Radius:  1000,
+		Cuisine: "pizza",
+		TagsAll: []string{"halal"},
+	}
+
+	ParseNaturalQuery("便宜的拉麵 走路5分鐘 素食 營業中", testQueryVocabulary()).ApplyTo(params)
+
+	if params.Cuisine != "ramen" {
+		t.Errorf("Cuisine = %q, want ramen", params.Cuisine)
+	}
+	if params.Radius != 400 {
+		t.Errorf("Radius = %d, want 400", params.Radius)
+	}
+	if params.MaxPriceLevel != 1 || params.MinPriceLevel != 0 {
+		t.Errorf("price levels = %d-%d, want 0-1", params.MinPriceLevel, params.MaxPriceLevel)
+	}
+	if !params.OpenNow {
+		t.Error("OpenNow = false, want true")
+	}
+	if want := []string{"halal", "vegetarian"}; !reflect.DeepEqual(params.TagsAll, want) {
+		t.Errorf("TagsAll = %v, want %v", params.TagsAll, want)
+	}
+
+	unchanged := &RestaurantSearchParams{Radius: 1000, Cuisine: "pizza"}
+	ParseNaturalQuery("something tasty", testQueryVocabulary()).ApplyTo(unchanged)
+	if unchanged.Radius != 1000 || unchanged.Cuisine != "pizza" {
+		t.Errorf("unrecognized query changed params: %+v", unchanged)
+	}
+}
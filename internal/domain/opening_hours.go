@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+
+	// DefaultRestaurantTimeZone 未設定時區時使用的預設時區
+	DefaultRestaurantTimeZone = "Asia/Taipei"
+)
+
+// OpeningPeriod 營業時段，可跨越午夜（例如週五 18:00 至週六 02:00）
+type OpeningPeriod struct {
+	OpenDay   int    `json:"open_day" validate:"min=0,max=6"`  // 0 為星期日
+	OpenTime  string `json:"open_time" validate:"required"`    // HH:MM
+	CloseDay  int    `json:"close_day" validate:"min=0,max=6"` // 0 為星期日
+	CloseTime string `json:"close_time"`                       // HH:MM，空字串表示 24 小時營業
+}
+
+// IsAlwaysOpen 是否為 24 小時營業
+func (p *OpeningPeriod) IsAlwaysOpen() bool {
+	return p.CloseTime == ""
+}
+
+// OpenMinute 開始營業時間在一週內的分鐘數（星期日 00:00 為 0）
+func (p *OpeningPeriod) OpenMinute() (int, error) {
+	return minuteOfWeek(p.OpenDay, p.OpenTime)
+}
+
+// CloseMinute 結束營業時間在一週內的分鐘數，24 小時營業時回傳 -1
+func (p *OpeningPeriod) CloseMinute() (int, error) {
+	if p.IsAlwaysOpen() {
+		return -1, nil
+	}
+	return minuteOfWeek(p.CloseDay, p.CloseTime)
+}
+
+// NewOpeningPeriod 由一週內的分鐘數建立營業時段，closeMinute 為負數表示 24 小時營業
+func NewOpeningPeriod(openMinute, closeMinute int) OpeningPeriod {
+	period := OpeningPeriod{
+		OpenDay:  openMinute / minutesPerDay,
+		OpenTime: formatClock(openMinute % minutesPerDay),
+	}
+	if closeMinute >= 0 {
+		period.CloseDay = closeMinute / minutesPerDay
+		period.CloseTime = formatClock(closeMinute % minutesPerDay)
+	}
+	return period
+}
+
+// OpeningHours 餐廳每週營業時間
+type OpeningHours struct {
+	RestaurantID int             `json:"restaurant_id"`
+	TimeZone     string          `json:"time_zone"` // IANA 時區名稱，例如 Asia/Taipei
+	Periods      []OpeningPeriod `json:"periods"`
+	OpenNow      *bool           `json:"open_now,omitempty"` // 未設定營業時間時不回傳
+}
+
+// UpdateOpeningHoursRequest 更新營業時間請求
+type UpdateOpeningHoursRequest struct {
+	TimeZone string          `json:"time_zone"`
+	Periods  []OpeningPeriod `json:"periods" validate:"dive"`
+}
+
+// Validate 檢查時區與營業時段格式
+func (h *OpeningHours) Validate() error {
+	if _, err := time.LoadLocation(h.TimeZone); err != nil || h.TimeZone == "" {
+		return ErrInvalidTimeZone
+	}
+
+	for _, period := range h.Periods {
+		if period.OpenDay < 0 || period.OpenDay > 6 || period.CloseDay < 0 || period.CloseDay > 6 {
+			return ErrInvalidOpeningHours
+		}
+		if _, err := period.OpenMinute(); err != nil {
+			return ErrInvalidOpeningHours
+		}
+		if _, err := period.CloseMinute(); err != nil {
+			return ErrInvalidOpeningHours
+		}
+	}
+
+	return nil
+}
+
+// IsOpenAt 判斷指定時間是否營業（以餐廳時區計算），未設定營業時間時回傳 false
+func (h *OpeningHours) IsOpenAt(t time.Time) bool {
+	loc, err := time.LoadLocation(h.TimeZone)
+	if err != nil {
+		loc = time.Local
+	}
+
+	local := t.In(loc)
+	minute := int(local.Weekday())*minutesPerDay + local.Hour()*60 + local.Minute()
+
+	for _, period := range h.Periods {
+		open, err := period.OpenMinute()
+		if err != nil {
+			continue
+		}
+		closeAt, err := period.CloseMinute()
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case closeAt < 0:
+			return true
+		case open < closeAt:
+			if minute >= open && minute < closeAt {
+				return true
+			}
+		default:
+			// 跨越週六午夜的時段
+			if minute >= open || minute < closeAt {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ResolveOpenTime 將 open_now / open_at 篩選條件轉換為檢查時間，未指定時回傳 nil
+func ResolveOpenTime(openNow bool, openAt string, now time.Time) (*time.Time, error) {
+	if openAt != "" {
+		t, err := time.Parse(time.RFC3339, openAt)
+		if err != nil {
+			return nil, ErrInvalidOpenAt
+		}
+		return &t, nil
+	}
+	if openNow {
+		return &now, nil
+	}
+	return nil, nil
+}
+
+// minuteOfWeek 將星期與 HH:MM 轉換為一週內的分鐘數，24:00 視為隔日 00:00
+func minuteOfWeek(day int, clock string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &hour, &minute); err != nil || len(clock) != 5 {
+		return 0, fmt.Errorf("無效的時間格式: %s", clock)
+	}
+	if hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("無效的時間格式: %s", clock)
+	}
+
+	return (day*minutesPerDay + hour*60 + minute) % minutesPerWeek, nil
+}
+
+// formatClock 將一天內的分鐘數格式化為 HH:MM
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
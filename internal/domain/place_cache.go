@@ -0,0 +1,12 @@
+package domain
+
+// PlaceCacheStats 外部地點搜尋快取統計
+type PlaceCacheStats struct {
+	Enabled bool    `json:"enabled"`
+	Backend string  `json:"backend,omitempty"` // memory 或 redis
+	Hits    int64   `json:"hits"`              // 快取命中次數
+	Misses  int64   `json:"misses"`            // 未命中而查詢外部資料來源的次數
+	Shared  int64   `json:"shared"`            // 與進行中的相同查詢合併、未另外發出請求的次數
+	Errors  int64   `json:"errors"`            // 快取讀寫失敗次數
+	HitRate float64 `json:"hit_rate"`          // (命中 + 合併) / 總查詢次數
+}
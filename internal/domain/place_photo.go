@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PlacePhotoPathPrefix 餐廳照片代理路徑，照片由伺服器端以 API 金鑰下載，避免金鑰出現在圖片網址中
+const PlacePhotoPathPrefix = "/media/places/"
+
+// placePhotoRefPattern 照片參照格式：舊版 Places API 的 photo_reference，或 Places API (New) 的「地點 ID.照片 ID」
+var placePhotoRefPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)?$`)
+
+// maxPlacePhotoRefLength 照片參照的最大長度
+const maxPlacePhotoRefLength = 1024
+
+// PlacePhoto 餐廳照片
+type PlacePhoto struct {
+	Data        []byte
+	ContentType string
+	FetchedAt   time.Time // 自外部資料來源下載的時間
+	ExpiresAt   time.Time // 快取到期時間
+}
+
+// PlacePhotoPath 取得照片參照的代理路徑
+func PlacePhotoPath(ref string) string {
+	if ref == "" {
+		return ""
+	}
+	return PlacePhotoPathPrefix + ref
+}
+
+// NewAPIPhotoRef 將 Places API (New) 的照片名稱 places/{地點 ID}/photos/{照片 ID} 轉換為照片參照
+func NewAPIPhotoRef(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) != 4 || parts[0] != "places" || parts[2] != "photos" {
+		return ""
+	}
+	return parts[1] + "." + parts[3]
+}
+
+// ValidPlacePhotoRef 檢查照片參照格式
+func ValidPlacePhotoRef(ref string) bool {
+	return len(ref) <= maxPlacePhotoRefLength && placePhotoRefPattern.MatchString(ref)
+}
+
+// PlacePhotoName 取得照片參照對應的 Places API (New) 照片名稱，舊版 photo_reference 回傳 false
+func PlacePhotoName(ref string) (string, bool) {
+	placeID, photoID, found := strings.Cut(ref, ".")
+	if !found {
+		return "", false
+	}
+	return "places/" + placeID + "/photos/" + photoID, true
+}
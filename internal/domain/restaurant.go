@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
@@ -14,13 +15,28 @@ type Restaurant struct {
 	Phone       string    `json:"phone" db:"phone"`
 	Rating      float32   `json:"rating" db:"rating" validate:"min=0,max=5"`
 	PriceLevel  int       `json:"price_level" db:"price_level" validate:"min=1,max=4"` // 1-4 價位等級
-	Cuisine     string    `json:"cuisine" db:"cuisine"`                                // 料理類型
+	CuisineID   *int      `json:"cuisine_id" db:"cuisine_id"`                          // 料理分類 ID
+	Cuisine     string    `json:"cuisine" db:"-"`                                      // 料理分類名稱（依 Accept-Language 顯示）
 	IsActive    bool      `json:"is_active" db:"is_active"`
-	GoogleID    string    `json:"google_id" db:"google_id"`     // Google Places ID
+	GoogleID    string    `json:"google_id" db:"google_id"`     // 外部地點 ID（Google Places ID，其他資料來源加上「來源:」前綴）
+	Source      string    `json:"source" db:"source"`           // 資料來源：manual、google、osm、foursquare
 	ImageURL    string    `json:"image_url" db:"image_url"`     // 餐廳圖片
 	Description string    `json:"description" db:"description"` // 餐廳描述
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	ReviewCount   int      `json:"review_count" db:"review_count"`             // 使用者評論數（不含已隱藏的評論）
+	ReviewRating  float32  `json:"review_rating" db:"review_rating"`           // 使用者評論平均評分
+	BlendedRating float32  `json:"blended_rating" db:"blended_rating"`         // 綜合使用者評論與外部評分（rating）的分數
+	LockedFields  []string `json:"locked_fields,omitempty" db:"locked_fields"` // 管理員修改過的欄位，外部資料同步時不覆寫
+
+	OpeningHours   []OpeningPeriod `json:"opening_hours,omitempty" db:"-"` // 營業時段（建立餐廳或外部匯入時使用）
+	ProviderTypes  []string        `json:"-" db:"-"`                       // 外部資料來源的地點類型（匯入時對應料理分類）
+	BusinessStatus string          `json:"-" db:"-"`                       // 外部資料來源回報的營業狀態（同步時使用）
+	ProviderTags   []string        `json:"-" db:"-"`                       // 外部資料來源提供的設施與飲食標籤識別碼（例如 wheelchair_accessible）
+
+	ProviderSyncStatus ProviderSyncStatus `json:"provider_sync_status,omitempty" db:"provider_sync_status"` // 外部資料來源同步狀態
+	ProviderSyncedAt   *time.Time         `json:"provider_synced_at,omitempty" db:"provider_synced_at"`     // 上次成功同步時間
 }
 
 // FavoriteRestaurant 使用者最愛餐廳
@@ -46,18 +62,31 @@ type FavoriteRestaurantWithDetails struct {
 	RestaurantPhone      string    `json:"restaurant_phone" db:"phone"`
 	RestaurantRating     float32   `json:"restaurant_rating" db:"rating"`
 	RestaurantPriceLevel int       `json:"restaurant_price_level" db:"price_level"`
-	RestaurantCuisine    string    `json:"restaurant_cuisine" db:"cuisine"`
+	RestaurantCuisineID  *int      `json:"restaurant_cuisine_id" db:"cuisine_id"`
+	RestaurantCuisine    string    `json:"restaurant_cuisine" db:"-"` // 料理分類名稱（依 Accept-Language 顯示）
 	RestaurantImageURL   string    `json:"restaurant_image_url" db:"image_url"`
 }
 
 // RestaurantSearchParams 餐廳搜尋參數
 type RestaurantSearchParams struct {
-	Latitude  float64 `json:"latitude" validate:"required,latitude"`
-	Longitude float64 `json:"longitude" validate:"required,longitude"`
-	Radius    int     `json:"radius" validate:"min=100,max=10000"` // 搜尋半徑（公尺）
-	Cuisine   string  `json:"cuisine"`                             // 料理類型篩選
-	MinRating float32 `json:"min_rating" validate:"min=0,max=5"`   // 最低評分
-	Limit     int     `json:"limit" validate:"min=1,max=50"`       // 結果數量限制
+	Latitude      float64  `form:"latitude" json:"latitude" validate:"required,latitude"`
+	Longitude     float64  `form:"longitude" json:"longitude" validate:"required,longitude"`
+	Radius        int      `form:"radius" json:"radius" validate:"min=100,max=10000"`             // 搜尋半徑（公尺）
+	Cuisine       string   `form:"cuisine" json:"cuisine"`                                        // 料理類型篩選（ID、識別碼或名稱，包含子分類）
+	MinRating     float32  `form:"min_rating" json:"min_rating" validate:"min=0,max=5"`           // 最低評分
+	Limit         int      `form:"limit" json:"limit" validate:"min=1,max=50"`                    // 結果數量限制
+	OpenNow       bool     `form:"open_now" json:"open_now"`                                      // 只搜尋目前營業中的餐廳
+	OpenAt        string   `form:"open_at" json:"open_at"`                                        // 只搜尋指定時間營業的餐廳（RFC3339）
+	TagsAll       []string `form:"tags_all" json:"tags_all"`                                      // 必須同時符合所有標籤
+	TagsAny       []string `form:"tags_any" json:"tags_any"`                                      // 符合任一標籤即可
+	MenuItem      string   `form:"menu_item" json:"menu_item" validate:"max=100"`                 // 只搜尋供應此品項的餐廳（名稱部分比對）
+	MaxItemPrice  float64  `form:"max_item_price" json:"max_item_price" validate:"min=0"`         // 品項價格上限，0 表示不限
+	MinPriceLevel int      `form:"min_price_level" json:"min_price_level" validate:"min=0,max=4"` // 最低價位等級，0 表示不限
+	MaxPriceLevel int      `form:"max_price_level" json:"max_price_level" validate:"min=0,max=4"` // 最高價位等級，0 表示不限
+
+	OpenTime      *time.Time `form:"-" json:"-"` // 由 OpenNow / OpenAt 解析出的檢查時間
+	ExcludeClosed bool       `form:"-" json:"-"` // 排除臨時休業的餐廳（遊戲候選餐廳使用）
+	CuisineIDs    []int      `form:"-" json:"-"` // 由 Cuisine 展開的料理分類 ID
 }
 
 // AddFavoriteRequest 新增最愛餐廳請求
@@ -70,4 +99,56 @@ type AddFavoriteRequest struct {
 type RestaurantWithDistance struct {
 	Restaurant
 	Distance float64 `json:"distance"` // 距離（公尺）
+	Closed   bool    `json:"closed"`   // 查詢日期是否臨時休業
+
+	MenuMatches []MenuItem `json:"menu_matches,omitempty"` // 符合菜單篩選條件的品項
+}
+
+// HasMenuFilter 是否有菜單品項或價格篩選條件
+func (p *RestaurantSearchParams) HasMenuFilter() bool {
+	return strings.TrimSpace(p.MenuItem) != "" || p.MaxItemPrice > 0
+}
+
+// ViewportSearchParams 地圖可視範圍餐廳搜尋參數
+type ViewportSearchParams struct {
+	MinLat    float64 `form:"min_lat" json:"min_lat" validate:"latitude"`
+	MinLng    float64 `form:"min_lng" json:"min_lng" validate:"longitude"`
+	MaxLat    float64 `form:"max_lat" json:"max_lat" validate:"latitude"`
+	MaxLng    float64 `form:"max_lng" json:"max_lng" validate:"longitude"` // 小於 min_lng 時表示跨越國際換日線
+	Zoom      int     `form:"zoom" json:"zoom" validate:"min=0,max=22"`    // 地圖縮放等級
+	Cuisine   string  `form:"cuisine" json:"cuisine"`                      // 料理類型篩選（ID、識別碼或名稱，包含子分類）
+	MinRating float32 `form:"min_rating" json:"min_rating" validate:"min=0,max=5"`
+	Limit     int     `form:"limit" json:"limit" validate:"min=0,max=500"` // 個別餐廳模式的結果數量上限
+
+	CuisineIDs []int `form:"-" json:"-"` // 由 Cuisine 展開的料理分類 ID
+}
+
+// CrossesAntimeridian 可視範圍是否跨越國際換日線
+func (p *ViewportSearchParams) CrossesAntimeridian() bool {
+	return p.MinLng > p.MaxLng
+}
+
+// RestaurantCluster 地圖上的餐廳群集
+type RestaurantCluster struct {
+	Latitude  float64 `json:"latitude"`  // 群集內餐廳的重心緯度
+	Longitude float64 `json:"longitude"` // 群集內餐廳的重心經度
+	Count     int     `json:"count"`     // 群集內餐廳數量
+	MinLat    float64 `json:"min_lat"`   // 群集內餐廳的範圍
+	MinLng    float64 `json:"min_lng"`
+	MaxLat    float64 `json:"max_lat"`
+	MaxLng    float64 `json:"max_lng"`
 }
+
+// ViewportSearchResult 地圖可視範圍搜尋結果
+type ViewportSearchResult struct {
+	Mode        string              `json:"mode"`                  // clusters 或 restaurants
+	Clusters    []RestaurantCluster `json:"clusters,omitempty"`    // 低縮放等級時的群集
+	Restaurants []Restaurant        `json:"restaurants,omitempty"` // 高縮放等級時的個別餐廳
+	Count       int                 `json:"count"`                 // 範圍內符合條件的餐廳總數
+	Truncated   bool                `json:"truncated"`             // 個別餐廳是否超過數量上限而被截斷
+}
+
+const (
+	ViewportModeClusters    = "clusters"
+	ViewportModeRestaurants = "restaurants"
+)
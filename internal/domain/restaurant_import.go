@@ -0,0 +1,201 @@
+package domain
+
+import "strings"
+
+// 可鎖定的餐廳欄位：管理員修改後，外部資料同步時不再覆寫
+const (
+	RestaurantFieldName       = "name"
+	RestaurantFieldAddress    = "address"
+	RestaurantFieldLocation   = "location" // 經緯度
+	RestaurantFieldPhone      = "phone"
+	RestaurantFieldRating     = "rating"
+	RestaurantFieldPriceLevel = "price_level"
+	RestaurantFieldCuisine    = "cuisine"
+	RestaurantFieldImageURL   = "image_url"
+)
+
+// lockableRestaurantFields 可鎖定的欄位（依顯示順序）
+var lockableRestaurantFields = []string{
+	RestaurantFieldName,
+	RestaurantFieldAddress,
+	RestaurantFieldLocation,
+	RestaurantFieldPhone,
+	RestaurantFieldRating,
+	RestaurantFieldPriceLevel,
+	RestaurantFieldCuisine,
+	RestaurantFieldImageURL,
+}
+
+// NormalizeLockedFields 去除重複與無效的鎖定欄位，依固定順序排列
+func NormalizeLockedFields(fields []string) []string {
+	result := []string{}
+	for _, lockable := range lockableRestaurantFields {
+		for _, field := range fields {
+			if strings.ToLower(strings.TrimSpace(field)) == lockable {
+				result = append(result, lockable)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// ProviderUpsertOutcome 依外部 ID 匯入餐廳的結果
+type ProviderUpsertOutcome string
+
+const (
+	ProviderUpsertCreated   ProviderUpsertOutcome = "created"   // 新增餐廳
+	ProviderUpsertUpdated   ProviderUpsertOutcome = "updated"   // 外部資料有變動，已更新
+	ProviderUpsertUnchanged ProviderUpsertOutcome = "unchanged" // 外部資料沒有變動
+)
+
+// 外部資料來源回報的營業狀態
+const (
+	ProviderBusinessOperational       = "OPERATIONAL"
+	ProviderBusinessClosedTemporarily = "CLOSED_TEMPORARILY"
+	ProviderBusinessClosedPermanently = "CLOSED_PERMANENTLY"
+)
+
+// ProviderSyncStatus 餐廳與外部資料來源的同步狀態
+type ProviderSyncStatus string
+
+const (
+	ProviderSyncOK                ProviderSyncStatus = "ok"                 // 已同步
+	ProviderSyncClosedTemporarily ProviderSyncStatus = "closed_temporarily" // 外部回報暫停營業
+	ProviderSyncClosed            ProviderSyncStatus = "closed"             // 外部回報永久歇業，餐廳已停用
+	ProviderSyncNotFound          ProviderSyncStatus = "not_found"          // 外部已查無此地點
+	ProviderSyncError             ProviderSyncStatus = "error"              // 同步失敗，下次排程重試
+)
+
+// ProviderSyncStatusFor 依外部回報的營業狀態決定同步狀態
+func ProviderSyncStatusFor(businessStatus string) ProviderSyncStatus {
+	switch businessStatus {
+	case ProviderBusinessClosedPermanently:
+		return ProviderSyncClosed
+	case ProviderBusinessClosedTemporarily:
+		return ProviderSyncClosedTemporarily
+	default:
+		return ProviderSyncOK
+	}
+}
+
+// ProviderSyncSummary 外部資料同步排程的單次執行統計
+type ProviderSyncSummary struct {
+	Checked   int `json:"checked"`   // 已向外部資料來源查詢的餐廳數
+	Updated   int `json:"updated"`   // 資料有變動的餐廳數
+	Unchanged int `json:"unchanged"` // 資料沒有變動的餐廳數
+	Closed    int `json:"closed"`    // 永久歇業而停用的餐廳數
+	NotFound  int `json:"not_found"` // 外部查無此地點的餐廳數
+	Failed    int `json:"failed"`    // 同步失敗的餐廳數
+}
+
+// ImportSummary 外部餐廳匯入統計
+type ImportSummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+}
+
+// Add 累計一筆匯入結果
+func (s *ImportSummary) Add(outcome ProviderUpsertOutcome) {
+	switch outcome {
+	case ProviderUpsertCreated:
+		s.Created++
+	case ProviderUpsertUpdated:
+		s.Updated++
+	case ProviderUpsertUnchanged:
+		s.Unchanged++
+	}
+}
+
+// Imported 新增與更新的餐廳數
+func (s *ImportSummary) Imported() int {
+	return s.Created + s.Updated
+}
+
+// IsFieldLocked 檢查欄位是否被管理員鎖定
+func (r *Restaurant) IsFieldLocked(field string) bool {
+	for _, locked := range r.LockedFields {
+		if locked == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedFields 列出與另一筆餐廳資料不同的可鎖定欄位
+func (r *Restaurant) ChangedFields(other *Restaurant) []string {
+	var fields []string
+	if r.Name != other.Name {
+		fields = append(fields, RestaurantFieldName)
+	}
+	if r.Address != other.Address {
+		fields = append(fields, RestaurantFieldAddress)
+	}
+	if r.Latitude != other.Latitude || r.Longitude != other.Longitude {
+		fields = append(fields, RestaurantFieldLocation)
+	}
+	if r.Phone != other.Phone {
+		fields = append(fields, RestaurantFieldPhone)
+	}
+	if r.Rating != other.Rating {
+		fields = append(fields, RestaurantFieldRating)
+	}
+	if r.PriceLevel != other.PriceLevel {
+		fields = append(fields, RestaurantFieldPriceLevel)
+	}
+	if !sameCuisineID(r.CuisineID, other.CuisineID) {
+		fields = append(fields, RestaurantFieldCuisine)
+	}
+	if r.ImageURL != other.ImageURL {
+		fields = append(fields, RestaurantFieldImageURL)
+	}
+	return fields
+}
+
+// MergeProviderData 以外部資料來源的資料更新餐廳，略過鎖定的欄位與外部資料缺少的值，回傳是否有變動
+func (r *Restaurant) MergeProviderData(incoming *Restaurant) bool {
+	changed := false
+	mergeString := func(field string, current *string, value string) {
+		if value != "" && *current != value && !r.IsFieldLocked(field) {
+			*current = value
+			changed = true
+		}
+	}
+
+	mergeString(RestaurantFieldName, &r.Name, incoming.Name)
+	mergeString(RestaurantFieldAddress, &r.Address, incoming.Address)
+	mergeString(RestaurantFieldPhone, &r.Phone, incoming.Phone)
+	mergeString(RestaurantFieldImageURL, &r.ImageURL, incoming.ImageURL)
+
+	if (incoming.Latitude != 0 || incoming.Longitude != 0) &&
+		(r.Latitude != incoming.Latitude || r.Longitude != incoming.Longitude) &&
+		!r.IsFieldLocked(RestaurantFieldLocation) {
+		r.Latitude, r.Longitude = incoming.Latitude, incoming.Longitude
+		changed = true
+	}
+	if incoming.Rating > 0 && r.Rating != incoming.Rating && !r.IsFieldLocked(RestaurantFieldRating) {
+		r.Rating = incoming.Rating
+		changed = true
+	}
+	if incoming.PriceLevel > 0 && r.PriceLevel != incoming.PriceLevel && !r.IsFieldLocked(RestaurantFieldPriceLevel) {
+		r.PriceLevel = incoming.PriceLevel
+		changed = true
+	}
+	if incoming.CuisineID != nil && !sameCuisineID(r.CuisineID, incoming.CuisineID) && !r.IsFieldLocked(RestaurantFieldCuisine) {
+		id := *incoming.CuisineID
+		r.CuisineID = &id
+		changed = true
+	}
+
+	return changed
+}
+
+// sameCuisineID 比較兩個可為空的料理分類 ID
+func sameCuisineID(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"time"
+)
+
+// DuplicateCandidateStatus 疑似重複餐廳的處理狀態
+type DuplicateCandidateStatus string
+
+const (
+	DuplicateCandidatePending   DuplicateCandidateStatus = "pending"   // 等待管理員處理
+	DuplicateCandidateDismissed DuplicateCandidateStatus = "dismissed" // 管理員確認不是重複
+	DuplicateCandidateMerged    DuplicateCandidateStatus = "merged"    // 已合併
+)
+
+// IsValid 檢查處理狀態是否有效
+func (s DuplicateCandidateStatus) IsValid() bool {
+	switch s {
+	case DuplicateCandidatePending, DuplicateCandidateDismissed, DuplicateCandidateMerged:
+		return true
+	default:
+		return false
+	}
+}
+
+// 合併時移動的資料類型
+const (
+	MergeEntityFavorite      = "favorite"       // 最愛餐廳
+	MergeEntityGameResult    = "game_result"    // 遊戲結果餐廳
+	MergeEntityGameCandidate = "game_candidate" // 遊戲候選餐廳
+	MergeEntityAdvertisement = "advertisement"  // 廣告
+	MergeEntityReview        = "review"         // 評論
+	MergeEntityTag           = "tag"            // 標籤
+)
+
+// MergeEntities 合併時依序移動的資料類型
+var MergeEntities = []string{
+	MergeEntityFavorite,
+	MergeEntityGameResult,
+	MergeEntityGameCandidate,
+	MergeEntityAdvertisement,
+	MergeEntityReview,
+	MergeEntityTag,
+}
+
+// RestaurantSummary 合併工具中顯示的餐廳摘要
+type RestaurantSummary struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	GoogleID  string  `json:"google_id,omitempty"`
+	IsActive  bool    `json:"is_active"`
+}
+
+// DuplicateCandidate 疑似重複的餐廳組合
+type DuplicateCandidate struct {
+	Restaurant     RestaurantSummary        `json:"restaurant"`
+	Duplicate      RestaurantSummary        `json:"duplicate"`
+	NameSimilarity float64                  `json:"name_similarity"` // 名稱三元組相似度 0-1
+	Distance       float64                  `json:"distance"`        // 公尺
+	Status         DuplicateCandidateStatus `json:"status"`
+	DetectedAt     time.Time                `json:"detected_at"`
+	ReviewedAt     *time.Time               `json:"reviewed_at,omitempty"`
+}
+
+// DuplicateCandidateParams 疑似重複餐廳清單查詢參數
+type DuplicateCandidateParams struct {
+	Status DuplicateCandidateStatus `form:"status"`
+	Limit  int                      `form:"limit" validate:"min=0,max=100"`
+	Offset int                      `form:"offset" validate:"min=0"`
+}
+
+// RestaurantMergeListParams 合併記錄查詢參數
+type RestaurantMergeListParams struct {
+	Limit  int `form:"limit" validate:"min=0,max=100"`
+	Offset int `form:"offset" validate:"min=0"`
+}
+
+// DuplicateDetectionSettings 重複餐廳偵測條件
+type DuplicateDetectionSettings struct {
+	MaxDistance       float64 // 兩間餐廳的最大距離（公尺）
+	MinNameSimilarity float64 // 名稱最低相似度 0-1
+}
+
+// RestaurantMergeRequest 合併餐廳請求：重複的餐廳資料移至保留的餐廳
+type RestaurantMergeRequest struct {
+	SurvivorID  int `json:"survivor_id" validate:"required,min=1"`
+	DuplicateID int `json:"duplicate_id" validate:"required,min=1"`
+}
+
+// DismissDuplicateRequest 略過疑似重複餐廳請求
+type DismissDuplicateRequest struct {
+	RestaurantID int `json:"restaurant_id" validate:"required,min=1"`
+	DuplicateID  int `json:"duplicate_id" validate:"required,min=1"`
+}
+
+// RestaurantMergePreview 合併預覽：各類資料將移動與因衝突保留在重複餐廳的數量
+type RestaurantMergePreview struct {
+	Survivor      RestaurantSummary `json:"survivor"`
+	Duplicate     RestaurantSummary `json:"duplicate"`
+	Moves         map[string]int    `json:"moves"`
+	Conflicts     map[string]int    `json:"conflicts"` // 例如同一使用者對兩間餐廳都有評論時，保留在重複餐廳的評論
+	MovesGoogleID bool              `json:"moves_google_id"`
+}
+
+// RestaurantMerge 餐廳合併記錄
+type RestaurantMerge struct {
+	ID            int            `json:"id"`
+	SurvivorID    int            `json:"survivor_id"`
+	DuplicateID   int            `json:"duplicate_id"`
+	MovedGoogleID string         `json:"moved_google_id,omitempty"`
+	Moves         map[string]int `json:"moves"`
+	MergedBy      *int           `json:"merged_by"`
+	MergedAt      time.Time      `json:"merged_at"`
+	UndoneBy      *int           `json:"undone_by,omitempty"`
+	UndoneAt      *time.Time     `json:"undone_at,omitempty"`
+}
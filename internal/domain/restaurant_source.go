@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// 餐廳資料來源
+const (
+	RestaurantSourceManual     = "manual"              // 管理員建立
+	RestaurantSourceGoogle     = CuisineProviderGoogle // Google Places
+	RestaurantSourceOSM        = "osm"                 // OpenStreetMap
+	RestaurantSourceFoursquare = "foursquare"          // Foursquare Places
+)
+
+// ProviderPlaceID 組合外部地點 ID：Google 地點直接使用原始 ID，其他資料來源加上「來源:」前綴
+// 所有資料來源共用 google_id 欄位的唯一索引，前綴可避免不同來源的 ID 衝突
+func ProviderPlaceID(source, id string) string {
+	if source == RestaurantSourceGoogle || id == "" {
+		return id
+	}
+	return source + ":" + id
+}
+
+// ParseProviderPlaceID 拆解外部地點 ID 的資料來源與原始 ID，沒有前綴時視為 Google 地點
+func ParseProviderPlaceID(placeID string) (string, string) {
+	if source, id, found := strings.Cut(placeID, ":"); found {
+		switch source {
+		case RestaurantSourceOSM, RestaurantSourceFoursquare:
+			return source, id
+		}
+	}
+	return RestaurantSourceGoogle, placeID
+}
+
+// IsDuplicate 判斷兩筆外部餐廳資料是否為同一間餐廳：距離在 MaxDistance 內且名稱相似度達 MinNameSimilarity
+func (s DuplicateDetectionSettings) IsDuplicate(a, b *Restaurant) bool {
+	if DistanceMeters(a.Latitude, a.Longitude, b.Latitude, b.Longitude) > s.MaxDistance {
+		return false
+	}
+	return NameSimilarity(a.Name, b.Name) >= s.MinNameSimilarity
+}
+
+// FillMissingFrom 以另一資料來源的同一間餐廳補齊空白欄位，不覆寫已有的資料
+func (r *Restaurant) FillMissingFrom(other *Restaurant) {
+	if r.Address == "" {
+		r.Address = other.Address
+	}
+	if r.Phone == "" {
+		r.Phone = other.Phone
+	}
+	if r.Rating == 0 {
+		r.Rating = other.Rating
+	}
+	if r.PriceLevel == 0 {
+		r.PriceLevel = other.PriceLevel
+	}
+	if r.ImageURL == "" {
+		r.ImageURL = other.ImageURL
+	}
+	if r.Cuisine == "" {
+		r.Cuisine = other.Cuisine
+	}
+	if len(r.OpeningHours) == 0 {
+		r.OpeningHours = other.OpeningHours
+	}
+	if len(r.ProviderTypes) == 0 {
+		r.ProviderTypes = other.ProviderTypes
+	}
+
+	seen := make(map[string]bool, len(r.ProviderTags))
+	for _, tag := range r.ProviderTags {
+		seen[tag] = true
+	}
+	for _, tag := range other.ProviderTags {
+		if !seen[tag] {
+			seen[tag] = true
+			r.ProviderTags = append(r.ProviderTags, tag)
+		}
+	}
+}
+
+// NameSimilarity 計算兩個名稱的三元組相似度 0-1，計算方式與 PostgreSQL pg_trgm 的 similarity 一致
+func NameSimilarity(a, b string) float64 {
+	setA, setB := nameTrigrams(a), nameTrigrams(b)
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range setA {
+		if setB[trigram] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(setA)+len(setB)-common)
+}
+
+// nameTrigrams 將名稱拆成單字後取三元組，每個單字前補兩個空白、後補一個空白
+func nameTrigrams(name string) map[string]bool {
+	trigrams := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			trigrams[string(runes[i:i+3])] = true
+		}
+	}
+	return trigrams
+}
+
+// DistanceMeters 計算兩點之間的距離（公尺，使用 Haversine 公式）
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000
+
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	deltaLat := (lat2 - lat1) * math.Pi / 180
+	deltaLng := (lng2 - lng1) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
+	return earthRadius * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
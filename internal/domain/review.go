@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+)
+
+// ReviewStatus 評論狀態
+type ReviewStatus string
+
+const (
+	ReviewStatusPublished ReviewStatus = "published" // 公開
+	ReviewStatusFlagged   ReviewStatus = "flagged"   // 遭檢舉，仍公開並等待版主審核
+	ReviewStatusHidden    ReviewStatus = "hidden"    // 版主隱藏，不計入餐廳評分
+)
+
+// ReviewModerationAction 版主審核動作
+type ReviewModerationAction string
+
+const (
+	ReviewModerationApprove ReviewModerationAction = "approve" // 保留評論並清除檢舉
+	ReviewModerationHide    ReviewModerationAction = "hide"    // 隱藏評論
+)
+
+// MaxReviewPhotos 每則評論的照片數量上限
+const MaxReviewPhotos = 10
+
+// Review 使用者對餐廳的評論（每位使用者對每間餐廳一則）
+type Review struct {
+	ID             int          `json:"id" db:"id"`
+	RestaurantID   int          `json:"restaurant_id" db:"restaurant_id"`
+	UserID         int          `json:"user_id" db:"user_id"`
+	Username       string       `json:"username" db:"username"`
+	Rating         int          `json:"rating" db:"rating"` // 1-5
+	Content        string       `json:"content" db:"content"`
+	PhotoURLs      []string     `json:"photo_urls" db:"photo_urls"`
+	VisitDate      string       `json:"visit_date,omitempty" db:"visit_date"` // YYYY-MM-DD
+	Status         ReviewStatus `json:"status" db:"status"`
+	FlagCount      int          `json:"flag_count,omitempty" db:"flag_count"`
+	FlagReasons    []string     `json:"flag_reasons,omitempty" db:"-"` // 審核佇列中顯示的檢舉原因
+	ModerationNote string       `json:"moderation_note,omitempty" db:"moderation_note"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// IsCounted 評論是否計入餐廳評分彙總
+func (r *Review) IsCounted() bool {
+	return r.Status != ReviewStatusHidden
+}
+
+// UpsertReviewRequest 新增或編輯評論請求
+type UpsertReviewRequest struct {
+	Rating    int      `json:"rating" validate:"required,min=1,max=5"`
+	Content   string   `json:"content" validate:"max=2000"`
+	PhotoURLs []string `json:"photo_urls" validate:"max=10,dive,url,max=500"`
+	VisitDate string   `json:"visit_date"` // YYYY-MM-DD，可省略
+}
+
+// FlagReviewRequest 檢舉評論請求
+type FlagReviewRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// ModerateReviewRequest 版主審核評論請求
+type ModerateReviewRequest struct {
+	Action ReviewModerationAction `json:"action" validate:"required,oneof=approve hide"`
+	Note   string                 `json:"note" validate:"max=255"`
+}
+
+// ReviewListParams 評論清單分頁參數
+type ReviewListParams struct {
+	Limit  int `form:"limit" validate:"min=0,max=100"`
+	Offset int `form:"offset" validate:"min=0"`
+}
+
+// ReviewList 評論清單
+type ReviewList struct {
+	Reviews []Review `json:"reviews"`
+	Total   int      `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+}
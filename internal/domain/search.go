@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+)
+
+// SearchSource 搜尋來源
+type SearchSource string
+
+const (
+	SearchSourceGame   SearchSource = "game"   // 開始遊戲時的候選餐廳搜尋
+	SearchSourceSearch SearchSource = "search" // 餐廳搜尋 API
+)
+
+// SearchExpansionStep 搜尋半徑擴大的單一步驟
+type SearchExpansionStep struct {
+	Step          int `json:"step"`           // 0 為原始半徑
+	Radius        int `json:"radius"`         // 本步驟的搜尋半徑（公尺）
+	LocalCount    int `json:"local_count"`    // 本地資料庫找到的餐廳數
+	ExternalCount int `json:"external_count"` // 外部 API 匯入的餐廳數
+}
+
+// SearchLog 搜尋記錄，用於美食沙漠分析（座標已粗化為 geohash 格網中心）
+type SearchLog struct {
+	ID              int                   `json:"id" db:"id"`
+	Source          SearchSource          `json:"source" db:"source"`
+	Geohash         string                `json:"geohash" db:"geohash"`
+	Latitude        float64               `json:"latitude" db:"latitude"`
+	Longitude       float64               `json:"longitude" db:"longitude"`
+	RequestedRadius int                   `json:"requested_radius" db:"requested_radius"`
+	FinalRadius     int                   `json:"final_radius" db:"final_radius"`
+	ResultCount     int                   `json:"result_count" db:"result_count"`
+	ExternalUsed    bool                  `json:"external_used" db:"external_used"`
+	Succeeded       bool                  `json:"succeeded" db:"succeeded"`
+	Steps           []SearchExpansionStep `json:"steps"`
+	CreatedAt       time.Time             `json:"created_at" db:"created_at"`
+}
+
+// ExpansionCount 取得半徑擴大的次數
+func (l *SearchLog) ExpansionCount() int {
+	if len(l.Steps) == 0 {
+		return 0
+	}
+	return len(l.Steps) - 1
+}
+
+// SearchSummary 回傳給使用者的搜尋範圍摘要
+type SearchSummary struct {
+	RequestedRadius int  `json:"requested_radius"` // 原始搜尋半徑（公尺）
+	FinalRadius     int  `json:"final_radius"`     // 實際找到餐廳時的半徑（公尺）
+	Expansions      int  `json:"expansions"`       // 半徑擴大次數
+	ExternalUsed    bool `json:"external_used"`    // 是否從外部 API 補充餐廳
+}
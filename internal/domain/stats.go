@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserStatsRequest 個人飲食統計請求
+type UserStatsRequest struct {
+	From     string `form:"from" json:"from"` // 起始日期 YYYY-MM-DD（使用者時區）
+	To       string `form:"to" json:"to"`     // 結束日期 YYYY-MM-DD（含當日）
+	TimeZone string `form:"tz" json:"tz"`     // IANA 時區名稱，例如 Asia/Taipei
+}
+
+// UserStatsParams 個人飲食統計查詢參數
+type UserStatsParams struct {
+	From     time.Time      // 起始時間（含）
+	To       time.Time      // 結束時間（不含）
+	Location *time.Location // 使用者時區，用於分組統計
+}
+
+// CuisineCount 料理類型統計
+type CuisineCount struct {
+	CuisineID  *int    `json:"cuisine_id"` // 未分類時為 null
+	Cuisine    string  `json:"cuisine"`    // 依 Accept-Language 顯示的名稱
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// RestaurantPickCount 餐廳被選中次數
+type RestaurantPickCount struct {
+	RestaurantID int    `json:"restaurant_id"`
+	Name         string `json:"name"`
+	CuisineID    *int   `json:"cuisine_id"`
+	Cuisine      string `json:"cuisine"`
+	Count        int    `json:"count"`
+}
+
+// GameTypeCount 遊戲類型統計
+type GameTypeCount struct {
+	GameType GameType `json:"game_type"`
+	Count    int      `json:"count"`
+}
+
+// UserStats 個人飲食統計
+type UserStats struct {
+	From                time.Time             `json:"from"`
+	To                  time.Time             `json:"to"`
+	TimeZone            string                `json:"time_zone"`
+	TotalGames          int                   `json:"total_games"`
+	CompletedGames      int                   `json:"completed_games"`
+	CuisineDistribution []CuisineCount        `json:"cuisine_distribution"`
+	AveragePriceLevel   float64               `json:"average_price_level"`
+	TopRestaurants      []RestaurantPickCount `json:"top_restaurants"`
+	FavoriteGameType    GameType              `json:"favorite_game_type,omitempty"`
+	GameTypeCounts      []GameTypeCount       `json:"game_type_counts"`
+	GamesByWeekday      [7]int                `json:"games_by_weekday"` // 0 = 星期日
+	GamesByHour         [24]int               `json:"games_by_hour"`
+	TotalDistance       float64               `json:"total_distance"`   // 起點到結果餐廳的累計距離（公尺）
+	AverageDistance     float64               `json:"average_distance"` // 平均距離（公尺）
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TagCategory 標籤分類
+type TagCategory string
+
+const (
+	TagCategoryDietary TagCategory = "dietary" // 飲食需求：素食、清真等
+	TagCategoryFeature TagCategory = "feature" // 設施服務：無線網路、寵物友善等
+	TagCategoryMeal    TagCategory = "meal"    // 用餐時段：早餐、宵夜等
+)
+
+// IsValid 檢查標籤分類是否有效
+func (c TagCategory) IsValid() bool {
+	switch c {
+	case TagCategoryDietary, TagCategoryFeature, TagCategoryMeal:
+		return true
+	default:
+		return false
+	}
+}
+
+// Tag 餐廳標籤
+type Tag struct {
+	ID        int         `json:"id" db:"id"`
+	Slug      string      `json:"slug" db:"slug"` // 篩選用的識別碼，例如 vegetarian
+	Name      string      `json:"name" db:"name"` // 顯示名稱
+	Category  TagCategory `json:"category" db:"category"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// CreateTagRequest 建立標籤請求
+type CreateTagRequest struct {
+	Slug     string      `json:"slug" validate:"required,max=50"`
+	Name     string      `json:"name" validate:"required,max=100"`
+	Category TagCategory `json:"category" validate:"required"`
+}
+
+// UpdateTagRequest 更新標籤請求
+type UpdateTagRequest struct {
+	Name     string      `json:"name" validate:"required,max=100"`
+	Category TagCategory `json:"category" validate:"required"`
+}
+
+// SetRestaurantTagsRequest 設定餐廳標籤請求
+type SetRestaurantTagsRequest struct {
+	Tags []string `json:"tags"` // 標籤識別碼
+}
+
+// NormalizeTagSlugs 整理標籤識別碼：支援逗號分隔、轉小寫並移除空白與重複
+func NormalizeTagSlugs(values []string) []string {
+	seen := make(map[string]bool)
+	slugs := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			slug := strings.ToLower(strings.TrimSpace(part))
+			if slug == "" || seen[slug] {
+				continue
+			}
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,72 @@
+package domain
+
+const (
+	// TextSearchSimilarityThreshold 模糊比對的最低字詞相似度（pg_trgm word_similarity）
+	TextSearchSimilarityThreshold = 0.3
+	// TextSearchRelevanceWeight 有定位時文字相關度占綜合分數的比重，其餘為距離分數
+	TextSearchRelevanceWeight = 0.7
+	// TextSearchDistanceScale 距離分數減半的距離（公尺）
+	TextSearchDistanceScale = 1000
+	// MaxRestaurantAliases 每間餐廳的別名數量上限
+	MaxRestaurantAliases = 20
+)
+
+// TextSearchParams 餐廳文字搜尋參數
+type TextSearchParams struct {
+	Query     string   `form:"q" json:"q" validate:"required,max=100"`
+	Latitude  *float64 `form:"latitude" json:"latitude" validate:"omitempty,latitude"`
+	Longitude *float64 `form:"longitude" json:"longitude" validate:"omitempty,longitude"`
+	Radius    int      `form:"radius" json:"radius" validate:"min=0,max=50000"` // 有定位時的搜尋半徑（公尺），0 表示不限
+	Limit     int      `form:"limit" json:"limit" validate:"min=0,max=50"`
+
+	CuisineIDs []int `form:"-" json:"-"` // 查詢字串對應的料理分類（含子分類），符合者提高相關度
+}
+
+// HasLocation 是否提供定位
+func (p *TextSearchParams) HasLocation() bool {
+	return p.Latitude != nil && p.Longitude != nil
+}
+
+// TextSearchResult 餐廳文字搜尋結果
+type TextSearchResult struct {
+	Restaurant
+	Distance  *float64 `json:"distance,omitempty"` // 距離（公尺），未提供定位時省略
+	Relevance float64  `json:"relevance"`          // 文字相關度 0-1
+	Score     float64  `json:"score"`              // 綜合文字相關度與距離的排序分數
+}
+
+// AutocompleteParams 自動完成參數
+type AutocompleteParams struct {
+	Query     string   `form:"q" json:"q" validate:"required,max=100"`
+	Latitude  *float64 `form:"latitude" json:"latitude" validate:"omitempty,latitude"`
+	Longitude *float64 `form:"longitude" json:"longitude" validate:"omitempty,longitude"`
+	Limit     int      `form:"limit" json:"limit" validate:"min=0,max=20"`
+}
+
+// RestaurantSuggestion 自動完成的餐廳建議
+type RestaurantSuggestion struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Address  string   `json:"address"`
+	Cuisine  string   `json:"cuisine"`
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+// CuisineSuggestion 自動完成的料理分類建議
+type CuisineSuggestion struct {
+	ID   int    `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// AutocompleteResult 自動完成結果
+type AutocompleteResult struct {
+	Query       string                 `json:"query"`
+	Restaurants []RestaurantSuggestion `json:"restaurants"`
+	Cuisines    []CuisineSuggestion    `json:"cuisines"`
+}
+
+// SetRestaurantAliasesRequest 設定餐廳別名請求
+type SetRestaurantAliasesRequest struct {
+	Aliases []string `json:"aliases" validate:"max=20,dive,required,max=200"`
+}
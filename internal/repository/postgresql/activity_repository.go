@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ActivityRepository PostgreSQL 遊戲活動熱度資料庫操作實作
+type ActivityRepository struct {
+	db *sql.DB
+}
+
+// NewActivityRepository 建立遊戲活動熱度 Repository
+func NewActivityRepository(db *sql.DB) *ActivityRepository {
+	return &ActivityRepository{
+		db: db,
+	}
+}
+
+// ListGameActivity 取得指定時間區間內開始的遊戲位置資訊
+func (r *ActivityRepository) ListGameActivity(ctx context.Context, from, to time.Time) ([]domain.GameActivity, error) {
+	query := `
+		SELECT COALESCE(gs.start_geohash, ''), gs.result_restaurant_id, r.latitude, r.longitude, gs.started_at
+		FROM game_sessions gs
+		LEFT JOIN restaurants r ON r.id = gs.result_restaurant_id
+		WHERE gs.started_at >= $1 AND gs.started_at < $2`
+
+	rows, err := r.db.QueryContext(ctx, query, from.In(time.Local), to.In(time.Local))
+	if err != nil {
+		logger.Error("取得遊戲活動失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activities []domain.GameActivity
+	for rows.Next() {
+		var activity domain.GameActivity
+		var resultRestaurantID sql.NullInt64
+		var resultLatitude, resultLongitude sql.NullFloat64
+
+		if err := rows.Scan(&activity.StartGeohash, &resultRestaurantID, &resultLatitude, &resultLongitude, &activity.StartedAt); err != nil {
+			logger.Error("掃描遊戲活動失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		if resultRestaurantID.Valid && resultLatitude.Valid && resultLongitude.Valid {
+			restaurantID := int(resultRestaurantID.Int64)
+			activity.ResultRestaurantID = &restaurantID
+			activity.ResultLatitude = resultLatitude.Float64
+			activity.ResultLongitude = resultLongitude.Float64
+		}
+		activity.StartedAt = fromLocalTimestamp(activity.StartedAt)
+
+		activities = append(activities, activity)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理遊戲活動查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+// ReplaceDay 以新的彙總結果取代指定日期的熱度格網
+func (r *ActivityRepository) ReplaceDay(ctx context.Context, day time.Time, cells []domain.ActivityCell) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	date := day.Format("2006-01-02")
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_activity_cells WHERE activity_date = $1`, date); err != nil {
+		logger.Error("清除遊戲活動熱度失敗", zap.Error(err), zap.String("date", date))
+		return err
+	}
+
+	query := `
+		INSERT INTO game_activity_cells (activity_date, kind, restaurant_id, geohash, count, refreshed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	now := time.Now()
+	for _, cell := range cells {
+		if _, err := tx.ExecContext(ctx, query, date, cell.Kind, cell.RestaurantID, cell.Geohash, cell.Count, now); err != nil {
+			logger.Error("寫入遊戲活動熱度失敗", zap.Error(err), zap.String("date", date), zap.String("geohash", cell.Geohash))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交遊戲活動熱度失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetCells 取得指定區間與類型的熱度格網，依格網加總各日數量
+func (r *ActivityRepository) GetCells(ctx context.Context, query *domain.ActivityQuery) ([]domain.ActivityCell, error) {
+	sqlQuery := `
+		SELECT geohash, SUM(count)
+		FROM game_activity_cells
+		WHERE kind = $1 AND restaurant_id = $2 AND activity_date >= $3 AND activity_date < $4
+		GROUP BY geohash
+		ORDER BY geohash`
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery,
+		query.Kind,
+		query.RestaurantID,
+		query.From.Format("2006-01-02"),
+		query.To.Format("2006-01-02"),
+	)
+	if err != nil {
+		logger.Error("取得遊戲活動熱度失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cells []domain.ActivityCell
+	for rows.Next() {
+		cell := domain.ActivityCell{
+			Kind:         query.Kind,
+			RestaurantID: query.RestaurantID,
+		}
+		if err := rows.Scan(&cell.Geohash, &cell.Count); err != nil {
+			logger.Error("掃描遊戲活動熱度失敗", zap.Error(err))
+			return nil, err
+		}
+		cells = append(cells, cell)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理遊戲活動熱度查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return cells, nil
+}
@@ -0,0 +1,91 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// APIUsageRepository PostgreSQL 外部 API 用量資料庫操作實作
+type APIUsageRepository struct {
+	db *sql.DB
+}
+
+// NewAPIUsageRepository 建立外部 API 用量 Repository
+func NewAPIUsageRepository(db *sql.DB) *APIUsageRepository {
+	return &APIUsageRepository{
+		db: db,
+	}
+}
+
+// Reserve 在每日與每月額度內各增加一次請求計數，任一週期已達額度時不增加並回傳 false
+// budget 小於等於 0 表示不限
+func (r *APIUsageRepository) Reserve(ctx context.Context, provider string, day, month time.Time, dailyBudget, monthlyBudget int) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO api_usage (provider, period, period_start, request_count, updated_at)
+		VALUES ($1, $2, $3, 1, CURRENT_TIMESTAMP)
+		ON CONFLICT (provider, period, period_start) DO UPDATE
+		SET request_count = api_usage.request_count + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE $4 <= 0 OR api_usage.request_count < $4
+		RETURNING request_count`
+
+	periods := []struct {
+		period string
+		start  time.Time
+		budget int
+	}{
+		{period: domain.APIUsagePeriodDay, start: day, budget: dailyBudget},
+		{period: domain.APIUsagePeriodMonth, start: month, budget: monthlyBudget},
+	}
+	for _, p := range periods {
+		var count int
+		err := tx.QueryRowContext(ctx, query, provider, p.period, p.start.Format("2006-01-02"), p.budget).Scan(&count)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		if err != nil {
+			logger.Error("記錄外部 API 用量失敗", zap.Error(err), zap.String("provider", provider), zap.String("period", p.period))
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交外部 API 用量失敗", zap.Error(err))
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetCounts 取得指定日期與月份的請求次數
+func (r *APIUsageRepository) GetCounts(ctx context.Context, provider string, day, month time.Time) (int, int, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(request_count) FILTER (WHERE period = $2 AND period_start = $3), 0),
+			COALESCE(SUM(request_count) FILTER (WHERE period = $4 AND period_start = $5), 0)
+		FROM api_usage
+		WHERE provider = $1`
+
+	var daily, monthly int
+	err := r.db.QueryRowContext(ctx, query, provider,
+		domain.APIUsagePeriodDay, day.Format("2006-01-02"),
+		domain.APIUsagePeriodMonth, month.Format("2006-01-02"),
+	).Scan(&daily, &monthly)
+	if err != nil {
+		logger.Error("查詢外部 API 用量失敗", zap.Error(err), zap.String("provider", provider))
+		return 0, 0, err
+	}
+
+	return daily, monthly, nil
+}
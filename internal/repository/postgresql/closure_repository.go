@@ -0,0 +1,217 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ClosureRepository PostgreSQL 餐廳休業資料庫操作實作
+type ClosureRepository struct {
+	db *sql.DB
+}
+
+// NewClosureRepository 建立餐廳休業 Repository
+func NewClosureRepository(db *sql.DB) *ClosureRepository {
+	return &ClosureRepository{
+		db: db,
+	}
+}
+
+// Create 建立休業記錄
+func (r *ClosureRepository) Create(ctx context.Context, closure *domain.RestaurantClosure) error {
+	query := `
+		INSERT INTO restaurant_closures (restaurant_id, start_date, end_date, reason, source, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	var createdBy interface{}
+	if closure.CreatedBy != nil {
+		createdBy = *closure.CreatedBy
+	}
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		closure.RestaurantID,
+		closure.StartDate,
+		closure.EndDate,
+		closure.Reason,
+		closure.Source,
+		createdBy,
+		now,
+	).Scan(&closure.ID)
+
+	if err != nil {
+		logger.Error("建立休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", closure.RestaurantID))
+		return err
+	}
+
+	closure.CreatedAt = now
+	logger.Info("休業記錄建立成功", zap.Int("closure_id", closure.ID), zap.Int("restaurant_id", closure.RestaurantID))
+	return nil
+}
+
+// Delete 刪除休業記錄
+func (r *ClosureRepository) Delete(ctx context.Context, restaurantID, closureID int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM restaurant_closures WHERE id = $1 AND restaurant_id = $2`, closureID, restaurantID)
+	if err != nil {
+		logger.Error("刪除休業記錄失敗", zap.Error(err), zap.Int("closure_id", closureID))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrClosureNotFound
+	}
+
+	logger.Info("休業記錄刪除成功", zap.Int("closure_id", closureID), zap.Int("restaurant_id", restaurantID))
+	return nil
+}
+
+// ListByRestaurant 取得餐廳在指定日期（含）之後仍有效的休業記錄
+func (r *ClosureRepository) ListByRestaurant(ctx context.Context, restaurantID int, fromDate string) ([]domain.RestaurantClosure, error) {
+	query := `
+		SELECT id, restaurant_id, start_date, end_date, reason, source, created_by, created_at
+		FROM restaurant_closures
+		WHERE restaurant_id = $1 AND end_date >= $2
+		ORDER BY start_date`
+
+	rows, err := r.db.QueryContext(ctx, query, restaurantID, fromDate)
+	if err != nil {
+		logger.Error("取得休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := []domain.RestaurantClosure{}
+	for rows.Next() {
+		var closure domain.RestaurantClosure
+		var startDate, endDate time.Time
+		var reason sql.NullString
+		var createdBy sql.NullInt64
+
+		err := rows.Scan(
+			&closure.ID,
+			&closure.RestaurantID,
+			&startDate,
+			&endDate,
+			&reason,
+			&closure.Source,
+			&createdBy,
+			&closure.CreatedAt,
+		)
+		if err != nil {
+			logger.Error("掃描休業記錄失敗", zap.Error(err))
+			return nil, err
+		}
+
+		closure.StartDate = startDate.Format("2006-01-02")
+		closure.EndDate = endDate.Format("2006-01-02")
+
+		// 處理可為空的欄位
+		if reason.Valid {
+			closure.Reason = reason.String
+		}
+		if createdBy.Valid {
+			userID := int(createdBy.Int64)
+			closure.CreatedBy = &userID
+		}
+
+		closures = append(closures, closure)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理休業記錄查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return closures, nil
+}
+
+// CreateIfUncovered 在指定日期尚無任何休業記錄時建立休業記錄，回傳是否有建立
+// 同一天同時有多個回報達門檻時，由 user_report 的唯一索引保證只建立一筆
+func (r *ClosureRepository) CreateIfUncovered(ctx context.Context, closure *domain.RestaurantClosure) (bool, error) {
+	query := `
+		INSERT INTO restaurant_closures (restaurant_id, start_date, end_date, reason, source, created_by, created_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE NOT EXISTS (
+			SELECT 1 FROM restaurant_closures
+			WHERE restaurant_id = $1 AND $2::DATE BETWEEN start_date AND end_date
+		)
+		ON CONFLICT (restaurant_id, start_date) WHERE source = 'user_report' DO NOTHING
+		RETURNING id`
+
+	var createdBy interface{}
+	if closure.CreatedBy != nil {
+		createdBy = *closure.CreatedBy
+	}
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		closure.RestaurantID,
+		closure.StartDate,
+		closure.EndDate,
+		closure.Reason,
+		closure.Source,
+		createdBy,
+		now,
+	).Scan(&closure.ID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		logger.Error("建立休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", closure.RestaurantID))
+		return false, err
+	}
+
+	closure.CreatedAt = now
+	return true, nil
+}
+
+// AddReport 記錄使用者回報餐廳今日休業（以餐廳時區的日期計算），回傳回報日期與當日回報人數
+func (r *ClosureRepository) AddReport(ctx context.Context, restaurantID, userID int) (string, int, error) {
+	var reportDate time.Time
+	err := r.db.QueryRowContext(ctx,
+		`SELECT (CURRENT_TIMESTAMP AT TIME ZONE time_zone)::DATE FROM restaurants WHERE id = $1 AND is_active = TRUE`,
+		restaurantID,
+	).Scan(&reportDate)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, domain.ErrRestaurantNotFound
+		}
+		logger.Error("取得餐廳當地日期失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return "", 0, err
+	}
+
+	date := reportDate.Format("2006-01-02")
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO restaurant_closure_reports (restaurant_id, user_id, report_date, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (restaurant_id, user_id, report_date) DO NOTHING`,
+		restaurantID, userID, date, time.Now(),
+	)
+	if err != nil {
+		logger.Error("記錄休業回報失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID), zap.Int("user_id", userID))
+		return "", 0, err
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM restaurant_closure_reports WHERE restaurant_id = $1 AND report_date = $2`,
+		restaurantID, date,
+	).Scan(&count)
+	if err != nil {
+		logger.Error("計算休業回報數量失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return "", 0, err
+	}
+
+	return date, count, nil
+}
@@ -0,0 +1,207 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CoverageRepository PostgreSQL 美食沙漠覆蓋格網資料庫操作實作
+type CoverageRepository struct {
+	db *sql.DB
+}
+
+// NewCoverageRepository 建立覆蓋格網 Repository
+func NewCoverageRepository(db *sql.DB) *CoverageRepository {
+	return &CoverageRepository{
+		db: db,
+	}
+}
+
+// ListActiveRestaurantLocations 依 ID 分批取得營業中餐廳的位置
+func (r *CoverageRepository) ListActiveRestaurantLocations(ctx context.Context, afterID, limit int) ([]domain.RestaurantLocation, error) {
+	query := `
+		SELECT r.id, r.latitude, r.longitude, COALESCE(c.slug, '')
+		FROM restaurants r
+		LEFT JOIN cuisines c ON c.id = r.cuisine_id
+		WHERE r.is_active = TRUE AND r.id > $1
+		ORDER BY r.id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		logger.Error("取得餐廳位置失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []domain.RestaurantLocation
+	for rows.Next() {
+		var location domain.RestaurantLocation
+		if err := rows.Scan(&location.ID, &location.Latitude, &location.Longitude, &location.Cuisine); err != nil {
+			logger.Error("掃描餐廳位置失敗", zap.Error(err))
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理餐廳位置查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+// GetSearchStatsByGeohash 取得指定時間後各格網的搜尋統計
+func (r *CoverageRepository) GetSearchStatsByGeohash(ctx context.Context, since time.Time) (map[string]domain.SearchCellStats, error) {
+	query := `
+		SELECT geohash,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE NOT succeeded),
+		       COUNT(*) FILTER (WHERE expansion_count > 0)
+		FROM search_logs
+		WHERE created_at >= $1
+		GROUP BY geohash`
+
+	rows, err := r.db.QueryContext(ctx, query, since.In(time.Local))
+	if err != nil {
+		logger.Error("取得搜尋統計失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make(map[string]domain.SearchCellStats)
+	for rows.Next() {
+		var hash string
+		var item domain.SearchCellStats
+		if err := rows.Scan(&hash, &item.SearchCount, &item.FailedSearchCount, &item.ExpandedSearchCount); err != nil {
+			logger.Error("掃描搜尋統計失敗", zap.Error(err))
+			return nil, err
+		}
+		stats[hash] = item
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理搜尋統計查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// ReplaceCells 以新的統計結果取代整個覆蓋格網
+func (r *CoverageRepository) ReplaceCells(ctx context.Context, cells []domain.CoverageCell) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM coverage_cells`); err != nil {
+		logger.Error("清除覆蓋格網失敗", zap.Error(err))
+		return err
+	}
+
+	query := `
+		INSERT INTO coverage_cells (geohash, center_latitude, center_longitude, restaurant_count, cuisine_counts,
+		                            search_count, failed_search_count, expanded_search_count, refreshed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	for _, cell := range cells {
+		cuisineCounts, err := json.Marshal(cell.CuisineCounts)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, query,
+			cell.Geohash,
+			cell.CenterLatitude,
+			cell.CenterLongitude,
+			cell.RestaurantCount,
+			cuisineCounts,
+			cell.SearchCount,
+			cell.FailedSearchCount,
+			cell.ExpandedSearchCount,
+			cell.RefreshedAt,
+		)
+		if err != nil {
+			logger.Error("寫入覆蓋格網失敗", zap.Error(err), zap.String("geohash", cell.Geohash))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交覆蓋格網失敗", zap.Error(err))
+		return err
+	}
+
+	logger.Info("覆蓋格網更新成功", zap.Int("cell_count", len(cells)))
+	return nil
+}
+
+// GetCells 取得覆蓋格網，可依地圖範圍篩選格網中心
+func (r *CoverageRepository) GetCells(ctx context.Context, query *domain.CoverageQuery) ([]domain.CoverageCell, error) {
+	baseQuery := `
+		SELECT geohash, center_latitude, center_longitude, restaurant_count, cuisine_counts,
+		       search_count, failed_search_count, expanded_search_count, refreshed_at
+		FROM coverage_cells`
+
+	var args []interface{}
+	if query.HasBounds() {
+		baseQuery += " WHERE center_latitude BETWEEN $1 AND $2 AND center_longitude BETWEEN $3 AND $4"
+		args = append(args, query.MinLat, query.MaxLat, query.MinLng, query.MaxLng)
+	}
+	baseQuery += " ORDER BY geohash"
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		logger.Error("取得覆蓋格網失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cells []domain.CoverageCell
+	for rows.Next() {
+		var cell domain.CoverageCell
+		var cuisineCounts []byte
+
+		err := rows.Scan(
+			&cell.Geohash,
+			&cell.CenterLatitude,
+			&cell.CenterLongitude,
+			&cell.RestaurantCount,
+			&cuisineCounts,
+			&cell.SearchCount,
+			&cell.FailedSearchCount,
+			&cell.ExpandedSearchCount,
+			&cell.RefreshedAt,
+		)
+		if err != nil {
+			logger.Error("掃描覆蓋格網失敗", zap.Error(err))
+			return nil, err
+		}
+
+		cell.CuisineCounts = make(map[string]int)
+		if len(cuisineCounts) > 0 {
+			if err := json.Unmarshal(cuisineCounts, &cell.CuisineCounts); err != nil {
+				logger.Error("解析料理類型統計失敗", zap.Error(err), zap.String("geohash", cell.Geohash))
+				return nil, err
+			}
+		}
+
+		cells = append(cells, cell)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理覆蓋格網查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return cells, nil
+}
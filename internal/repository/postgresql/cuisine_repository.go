@@ -0,0 +1,238 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// CuisineRepository PostgreSQL 料理分類資料庫操作實作
+type CuisineRepository struct {
+	db *sql.DB
+}
+
+// NewCuisineRepository 建立料理分類 Repository
+func NewCuisineRepository(db *sql.DB) *CuisineRepository {
+	return &CuisineRepository{
+		db: db,
+	}
+}
+
+// List 取得所有料理分類與各語系名稱
+func (r *CuisineRepository) List(ctx context.Context) ([]domain.Cuisine, error) {
+	query := `
+		SELECT c.id, c.slug, c.parent_id, c.created_at, c.updated_at, cn.locale, cn.name
+		FROM cuisines c
+		LEFT JOIN cuisine_names cn ON cn.cuisine_id = c.id
+		ORDER BY c.id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Error("取得料理分類失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	cuisines := []domain.Cuisine{}
+	for rows.Next() {
+		var cuisine domain.Cuisine
+		var locale, name sql.NullString
+		if err := rows.Scan(&cuisine.ID, &cuisine.Slug, &cuisine.ParentID, &cuisine.CreatedAt, &cuisine.UpdatedAt, &locale, &name); err != nil {
+			logger.Error("掃描料理分類失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 同一分類的多語系名稱會連續出現
+		if len(cuisines) == 0 || cuisines[len(cuisines)-1].ID != cuisine.ID {
+			cuisine.Names = make(map[string]string)
+			cuisines = append(cuisines, cuisine)
+		}
+		if locale.Valid {
+			cuisines[len(cuisines)-1].Names[locale.String] = name.String
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理料理分類查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return cuisines, nil
+}
+
+// ListProviderTypes 取得外部地點類型對應
+func (r *CuisineRepository) ListProviderTypes(ctx context.Context) ([]domain.CuisineProviderType, error) {
+	query := `
+		SELECT provider, provider_type, cuisine_id
+		FROM cuisine_provider_types
+		ORDER BY provider, provider_type`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Error("取得地點類型對應失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	mappings := []domain.CuisineProviderType{}
+	for rows.Next() {
+		var mapping domain.CuisineProviderType
+		if err := rows.Scan(&mapping.Provider, &mapping.ProviderType, &mapping.CuisineID); err != nil {
+			logger.Error("掃描地點類型對應失敗", zap.Error(err))
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理地點類型對應查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// Create 建立料理分類與各語系名稱
+func (r *CuisineRepository) Create(ctx context.Context, cuisine *domain.Cuisine) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO cuisines (slug, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	now := time.Now()
+	if err := tx.QueryRowContext(ctx, query, cuisine.Slug, cuisine.ParentID, now, now).Scan(&cuisine.ID); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrCuisineExists
+		}
+		logger.Error("建立料理分類失敗", zap.Error(err), zap.String("slug", cuisine.Slug))
+		return err
+	}
+
+	if err := replaceCuisineNames(ctx, tx, cuisine.ID, cuisine.Names); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交料理分類失敗", zap.Error(err))
+		return err
+	}
+
+	cuisine.CreatedAt = now
+	cuisine.UpdatedAt = now
+
+	logger.Info("料理分類建立成功", zap.Int("cuisine_id", cuisine.ID), zap.String("slug", cuisine.Slug))
+	return nil
+}
+
+// Update 更新料理分類的上層分類與各語系名稱
+func (r *CuisineRepository) Update(ctx context.Context, cuisine *domain.Cuisine) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE cuisines
+		SET parent_id = $1, updated_at = $2
+		WHERE id = $3
+		RETURNING slug, created_at`
+
+	now := time.Now()
+	if err := tx.QueryRowContext(ctx, query, cuisine.ParentID, now, cuisine.ID).Scan(&cuisine.Slug, &cuisine.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrCuisineNotFound
+		}
+		logger.Error("更新料理分類失敗", zap.Error(err), zap.Int("cuisine_id", cuisine.ID))
+		return err
+	}
+
+	if err := replaceCuisineNames(ctx, tx, cuisine.ID, cuisine.Names); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交料理分類失敗", zap.Error(err))
+		return err
+	}
+
+	cuisine.UpdatedAt = now
+
+	logger.Info("料理分類更新成功", zap.Int("cuisine_id", cuisine.ID))
+	return nil
+}
+
+// SetProviderType 建立或更新外部地點類型對應
+func (r *CuisineRepository) SetProviderType(ctx context.Context, mapping *domain.CuisineProviderType) error {
+	query := `
+		INSERT INTO cuisine_provider_types (provider, provider_type, cuisine_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (provider, provider_type) DO UPDATE SET cuisine_id = EXCLUDED.cuisine_id`
+
+	if _, err := r.db.ExecContext(ctx, query, mapping.Provider, mapping.ProviderType, mapping.CuisineID); err != nil {
+		logger.Error("設定地點類型對應失敗", zap.Error(err), zap.String("provider_type", mapping.ProviderType))
+		return err
+	}
+
+	logger.Info("地點類型對應設定成功",
+		zap.String("provider", mapping.Provider),
+		zap.String("provider_type", mapping.ProviderType),
+		zap.Int("cuisine_id", mapping.CuisineID),
+	)
+	return nil
+}
+
+// DeleteProviderType 刪除外部地點類型對應
+func (r *CuisineRepository) DeleteProviderType(ctx context.Context, provider, providerType string) error {
+	query := `DELETE FROM cuisine_provider_types WHERE provider = $1 AND provider_type = $2`
+
+	result, err := r.db.ExecContext(ctx, query, provider, providerType)
+	if err != nil {
+		logger.Error("刪除地點類型對應失敗", zap.Error(err), zap.String("provider_type", providerType))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrCuisineNotFound
+	}
+
+	return nil
+}
+
+// replaceCuisineNames 以指定的多語系名稱取代原有名稱
+func replaceCuisineNames(ctx context.Context, tx *sql.Tx, cuisineID int, names map[string]string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cuisine_names WHERE cuisine_id = $1`, cuisineID); err != nil {
+		logger.Error("清除料理分類名稱失敗", zap.Error(err), zap.Int("cuisine_id", cuisineID))
+		return err
+	}
+
+	query := `
+		INSERT INTO cuisine_names (cuisine_id, locale, name)
+		VALUES ($1, $2, $3)`
+
+	for locale, name := range names {
+		if _, err := tx.ExecContext(ctx, query, cuisineID, locale, name); err != nil {
+			logger.Error("儲存料理分類名稱失敗", zap.Error(err), zap.Int("cuisine_id", cuisineID), zap.String("locale", locale))
+			return err
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
 	"go.uber.org/zap"
@@ -25,16 +26,28 @@ func NewGameRepository(db *sql.DB) *GameRepository {
 
 // CreateSession 建立遊戲會話
 func (r *GameRepository) CreateSession(ctx context.Context, session *domain.GameSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO game_sessions (id, user_id, game_type, status, started_at, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO game_sessions (id, user_id, game_type, status, start_geohash, started_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	var startGeohash interface{}
+	if session.StartGeohash != "" {
+		startGeohash = session.StartGeohash
+	}
 
 	now := time.Now()
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		session.ID,
 		session.UserID,
 		session.GameType,
 		session.Status,
+		startGeohash,
 		session.StartedAt,
 		now,
 	)
@@ -44,6 +57,23 @@ func (r *GameRepository) CreateSession(ctx context.Context, session *domain.Game
 		return err
 	}
 
+	// 記錄參與遊戲的餐廳
+	restaurantQuery := `
+		INSERT INTO game_session_restaurants (session_id, restaurant_id, distance, position)
+		VALUES ($1, $2, $3, $4)`
+
+	for i, restaurant := range session.Restaurants {
+		if _, err := tx.ExecContext(ctx, restaurantQuery, session.ID, restaurant.ID, restaurant.Distance, i); err != nil {
+			logger.Error("記錄遊戲餐廳失敗", zap.Error(err), zap.String("session_id", session.ID), zap.Int("restaurant_id", restaurant.ID))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交遊戲會話失敗", zap.Error(err), zap.String("session_id", session.ID))
+		return err
+	}
+
 	logger.Info("遊戲會話建立成功", zap.String("session_id", session.ID), zap.Int("user_id", session.UserID))
 	return nil
 }
@@ -87,24 +117,43 @@ func (r *GameRepository) GetSessionByID(ctx context.Context, sessionID string) (
 		session.CompletedAt = &completedAt.Time
 	}
 
+	// 取得參與遊戲的餐廳
+	restaurants, err := r.getSessionRestaurants(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	session.Restaurants = restaurants
+
 	return session, nil
 }
 
 // UpdateSession 更新遊戲會話
 func (r *GameRepository) UpdateSession(ctx context.Context, session *domain.GameSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE game_sessions
-		SET status = $1, result_restaurant_id = $2, completed_at = $3
-		WHERE id = $4`
+		SET status = $1, result_restaurant_id = $2, result_distance = $3, completed_at = $4
+		WHERE id = $5`
 
 	var resultRestaurantID interface{}
 	if session.ResultRestaurantID != nil && *session.ResultRestaurantID > 0 {
 		resultRestaurantID = *session.ResultRestaurantID
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	var resultDistance interface{}
+	if session.Result != nil {
+		resultDistance = session.Result.Distance
+	}
+
+	_, err = tx.ExecContext(ctx, query,
 		session.Status,
 		resultRestaurantID,
+		resultDistance,
 		session.CompletedAt,
 		session.ID,
 	)
@@ -114,6 +163,24 @@ func (r *GameRepository) UpdateSession(ctx context.Context, session *domain.Game
 		return err
 	}
 
+	// 記錄被否決的餐廳
+	if len(session.VetoedIDs) > 0 {
+		vetoQuery := `
+			UPDATE game_session_restaurants
+			SET vetoed = TRUE
+			WHERE session_id = $1 AND restaurant_id = ANY($2)`
+
+		if _, err := tx.ExecContext(ctx, vetoQuery, session.ID, pq.Array(session.VetoedIDs)); err != nil {
+			logger.Error("記錄否決餐廳失敗", zap.Error(err), zap.String("session_id", session.ID))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交遊戲會話更新失敗", zap.Error(err), zap.String("session_id", session.ID))
+		return err
+	}
+
 	logger.Info("遊戲會話更新成功", zap.String("session_id", session.ID))
 	return nil
 }
@@ -177,3 +244,179 @@ func (r *GameRepository) GetUserSessions(ctx context.Context, userID int, limit,
 	logger.Info("取得使用者遊戲歷史成功", zap.Int("user_id", userID), zap.Int("count", len(sessions)))
 	return sessions, nil
 }
+
+// getSessionRestaurants 取得遊戲會話的候選餐廳
+func (r *GameRepository) getSessionRestaurants(ctx context.Context, sessionID string) ([]domain.RestaurantWithDistance, error) {
+	query := `
+		SELECT r.id, r.name, r.address, r.latitude, r.longitude, r.phone, r.rating, r.price_level, r.cuisine_id, r.is_active,
+		       r.google_id, r.image_url, r.description, r.created_at, r.updated_at, gsr.distance
+		FROM game_session_restaurants gsr
+		JOIN restaurants r ON gsr.restaurant_id = r.id
+		WHERE gsr.session_id = $1
+		ORDER BY gsr.position`
+
+	rows, err := r.db.QueryContext(ctx, query, sessionID)
+	if err != nil {
+		logger.Error("取得遊戲餐廳失敗", zap.Error(err), zap.String("session_id", sessionID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []domain.RestaurantWithDistance
+	for rows.Next() {
+		var restaurant domain.RestaurantWithDistance
+		var phone, googleID, imageURL, description sql.NullString
+
+		err := rows.Scan(
+			&restaurant.ID,
+			&restaurant.Name,
+			&restaurant.Address,
+			&restaurant.Latitude,
+			&restaurant.Longitude,
+			&phone,
+			&restaurant.Rating,
+			&restaurant.PriceLevel,
+			&restaurant.CuisineID,
+			&restaurant.IsActive,
+			&googleID,
+			&imageURL,
+			&description,
+			&restaurant.CreatedAt,
+			&restaurant.UpdatedAt,
+			&restaurant.Distance,
+		)
+
+		if err != nil {
+			logger.Error("掃描遊戲餐廳資料失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		if phone.Valid {
+			restaurant.Phone = phone.String
+		}
+		if googleID.Valid {
+			restaurant.GoogleID = googleID.String
+		}
+		if imageURL.Valid {
+			restaurant.ImageURL = imageURL.String
+		}
+		if description.Valid {
+			restaurant.Description = description.String
+		}
+
+		restaurants = append(restaurants, restaurant)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理遊戲餐廳查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return restaurants, nil
+}
+
+// GetRestaurantHistory 取得使用者與指定餐廳的遊戲歷史
+func (r *GameRepository) GetRestaurantHistory(ctx context.Context, userID int, restaurantIDs []int) (map[int]domain.RestaurantHistory, error) {
+	history := make(map[int]domain.RestaurantHistory)
+	if len(restaurantIDs) == 0 {
+		return history, nil
+	}
+
+	query := `
+		SELECT gsr.restaurant_id,
+		       COUNT(*),
+		       MAX(gs.started_at),
+		       MAX(gs.completed_at) FILTER (WHERE gs.result_restaurant_id = gsr.restaurant_id),
+		       MAX(COALESCE(gs.completed_at, gs.started_at)) FILTER (WHERE gsr.vetoed)
+		FROM game_session_restaurants gsr
+		JOIN game_sessions gs ON gs.id = gsr.session_id
+		WHERE gs.user_id = $1 AND gsr.restaurant_id = ANY($2)
+		GROUP BY gsr.restaurant_id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.Array(restaurantIDs))
+	if err != nil {
+		logger.Error("取得餐廳遊戲歷史失敗", zap.Error(err), zap.Int("user_id", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.RestaurantHistory
+		var lastServedAt, lastWonAt, lastVetoedAt sql.NullTime
+
+		if err := rows.Scan(&item.RestaurantID, &item.ServedCount, &lastServedAt, &lastWonAt, &lastVetoedAt); err != nil {
+			logger.Error("掃描餐廳遊戲歷史失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		if lastServedAt.Valid {
+			t := fromLocalTimestamp(lastServedAt.Time)
+			item.LastServedAt = &t
+		}
+		if lastWonAt.Valid {
+			t := fromLocalTimestamp(lastWonAt.Time)
+			item.LastWonAt = &t
+		}
+		if lastVetoedAt.Valid {
+			t := fromLocalTimestamp(lastVetoedAt.Time)
+			item.LastVetoedAt = &t
+		}
+
+		history[item.RestaurantID] = item
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理餐廳遊戲歷史結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetCuisinePreferences 取得使用者近期選中結果的料理分類比例（以料理分類 ID 為鍵）
+func (r *GameRepository) GetCuisinePreferences(ctx context.Context, userID int, since time.Time) (map[int]float64, error) {
+	query := `
+		SELECT r.cuisine_id, COUNT(*)
+		FROM game_sessions gs
+		JOIN restaurants r ON gs.result_restaurant_id = r.id
+		WHERE gs.user_id = $1 AND gs.completed_at >= $2 AND r.cuisine_id IS NOT NULL
+		GROUP BY 1`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since.In(time.Local))
+	if err != nil {
+		logger.Error("取得料理偏好失敗", zap.Error(err), zap.Int("user_id", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	total := 0
+	for rows.Next() {
+		var cuisineID, count int
+		if err := rows.Scan(&cuisineID, &count); err != nil {
+			logger.Error("掃描料理偏好失敗", zap.Error(err))
+			return nil, err
+		}
+		counts[cuisineID] = count
+		total += count
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理料理偏好結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	preferences := make(map[int]float64, len(counts))
+	for cuisineID, count := range counts {
+		preferences[cuisineID] = float64(count) / float64(total)
+	}
+
+	return preferences, nil
+}
+
+// fromLocalTimestamp 將不含時區的 TIMESTAMP 欄位解讀為伺服器本地時間
+func fromLocalTimestamp(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
@@ -0,0 +1,536 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// menuItemSelect 菜單品項查詢欄位（含標籤），呼叫端需加上 WHERE 與 GROUP BY mi.id
+const menuItemSelect = `
+	SELECT mi.id, mi.restaurant_id, mi.section_id, mi.name, COALESCE(mi.description, ''), mi.price, mi.currency,
+	       COALESCE(mi.image_url, ''), mi.is_available, mi.position, mi.created_at, mi.updated_at,
+	       COALESCE(ARRAY_AGG(t.slug ORDER BY t.slug) FILTER (WHERE t.slug IS NOT NULL), '{}')
+	FROM menu_items mi
+	LEFT JOIN menu_item_tags mit ON mit.menu_item_id = mi.id
+	LEFT JOIN tags t ON t.id = mit.tag_id`
+
+// likeEscaper 跳脫 LIKE 萬用字元
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// MenuRepository PostgreSQL 菜單資料庫操作實作
+type MenuRepository struct {
+	db *sql.DB
+}
+
+// NewMenuRepository 建立菜單 Repository
+func NewMenuRepository(db *sql.DB) *MenuRepository {
+	return &MenuRepository{
+		db: db,
+	}
+}
+
+// GetMenu 取得餐廳完整菜單
+func (r *MenuRepository) GetMenu(ctx context.Context, restaurantID int) (*domain.Menu, error) {
+	menu := &domain.Menu{
+		RestaurantID: restaurantID,
+		Sections:     []domain.MenuSection{},
+		Items:        []domain.MenuItem{},
+	}
+
+	err := r.db.QueryRowContext(ctx,
+		`SELECT derive_price_level, COALESCE(price_level, 1) FROM restaurants WHERE id = $1`,
+		restaurantID,
+	).Scan(&menu.DerivePriceLevel, &menu.PriceLevel)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRestaurantNotFound
+		}
+		logger.Error("取得餐廳菜單設定失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+
+	sectionRows, err := r.db.QueryContext(ctx, `
+		SELECT id, restaurant_id, name, position, created_at, updated_at
+		FROM menu_sections
+		WHERE restaurant_id = $1
+		ORDER BY position, id`, restaurantID)
+	if err != nil {
+		logger.Error("取得菜單分類失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+	defer sectionRows.Close()
+
+	sectionIndex := make(map[int]int)
+	for sectionRows.Next() {
+		var section domain.MenuSection
+		if err := sectionRows.Scan(&section.ID, &section.RestaurantID, &section.Name, &section.Position, &section.CreatedAt, &section.UpdatedAt); err != nil {
+			logger.Error("掃描菜單分類失敗", zap.Error(err))
+			return nil, err
+		}
+		section.Items = []domain.MenuItem{}
+		sectionIndex[section.ID] = len(menu.Sections)
+		menu.Sections = append(menu.Sections, section)
+	}
+	if err = sectionRows.Err(); err != nil {
+		logger.Error("處理菜單分類查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	items, err := r.queryItems(ctx, menuItemSelect+`
+		WHERE mi.restaurant_id = $1
+		GROUP BY mi.id
+		ORDER BY mi.position, mi.id`, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if item.SectionID != nil {
+			if i, ok := sectionIndex[*item.SectionID]; ok {
+				menu.Sections[i].Items = append(menu.Sections[i].Items, item)
+				continue
+			}
+		}
+		menu.Items = append(menu.Items, item)
+	}
+
+	return menu, nil
+}
+
+// GetSection 取得菜單分類
+func (r *MenuRepository) GetSection(ctx context.Context, id int) (*domain.MenuSection, error) {
+	section := &domain.MenuSection{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, restaurant_id, name, position, created_at, updated_at
+		FROM menu_sections
+		WHERE id = $1`, id,
+	).Scan(&section.ID, &section.RestaurantID, &section.Name, &section.Position, &section.CreatedAt, &section.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrMenuSectionNotFound
+		}
+		logger.Error("取得菜單分類失敗", zap.Error(err), zap.Int("section_id", id))
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// CreateSection 建立菜單分類
+func (r *MenuRepository) CreateSection(ctx context.Context, section *domain.MenuSection) error {
+	query := `
+		INSERT INTO menu_sections (restaurant_id, name, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	now := time.Now()
+	if err := r.db.QueryRowContext(ctx, query, section.RestaurantID, section.Name, section.Position, now, now).Scan(&section.ID); err != nil {
+		logger.Error("建立菜單分類失敗", zap.Error(err), zap.Int("restaurant_id", section.RestaurantID))
+		return err
+	}
+
+	section.CreatedAt = now
+	section.UpdatedAt = now
+	return nil
+}
+
+// UpdateSection 更新菜單分類名稱與排序
+func (r *MenuRepository) UpdateSection(ctx context.Context, section *domain.MenuSection) error {
+	query := `
+		UPDATE menu_sections
+		SET name = $1, position = $2, updated_at = $3
+		WHERE id = $4
+		RETURNING restaurant_id, created_at`
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query, section.Name, section.Position, now, section.ID).Scan(&section.RestaurantID, &section.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrMenuSectionNotFound
+		}
+		logger.Error("更新菜單分類失敗", zap.Error(err), zap.Int("section_id", section.ID))
+		return err
+	}
+
+	section.UpdatedAt = now
+	return nil
+}
+
+// DeleteSection 刪除菜單分類（分類內品項改為未分類），回傳所屬餐廳 ID
+func (r *MenuRepository) DeleteSection(ctx context.Context, id int) (int, error) {
+	var restaurantID int
+	err := r.db.QueryRowContext(ctx, `DELETE FROM menu_sections WHERE id = $1 RETURNING restaurant_id`, id).Scan(&restaurantID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrMenuSectionNotFound
+		}
+		logger.Error("刪除菜單分類失敗", zap.Error(err), zap.Int("section_id", id))
+		return 0, err
+	}
+
+	return restaurantID, nil
+}
+
+// GetItem 取得菜單品項
+func (r *MenuRepository) GetItem(ctx context.Context, id int) (*domain.MenuItem, error) {
+	items, err := r.queryItems(ctx, menuItemSelect+`
+		WHERE mi.id = $1
+		GROUP BY mi.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	return &items[0], nil
+}
+
+// CreateItem 建立菜單品項與標籤
+func (r *MenuRepository) CreateItem(ctx context.Context, item *domain.MenuItem, tagIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertMenuItem(ctx, tx, item, tagIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交菜單品項失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// UpdateItem 更新菜單品項與標籤
+func (r *MenuRepository) UpdateItem(ctx context.Context, item *domain.MenuItem, tagIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE menu_items
+		SET section_id = $1, name = $2, description = $3, price = $4, currency = $5, image_url = $6,
+		    is_available = $7, position = $8, updated_at = $9
+		WHERE id = $10
+		RETURNING restaurant_id, created_at`
+
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query,
+		item.SectionID,
+		item.Name,
+		item.Description,
+		item.Price,
+		item.Currency,
+		item.ImageURL,
+		item.IsAvailable,
+		item.Position,
+		now,
+		item.ID,
+	).Scan(&item.RestaurantID, &item.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrMenuItemNotFound
+		}
+		logger.Error("更新菜單品項失敗", zap.Error(err), zap.Int("item_id", item.ID))
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_item_tags WHERE menu_item_id = $1`, item.ID); err != nil {
+		logger.Error("清除菜單品項標籤失敗", zap.Error(err), zap.Int("item_id", item.ID))
+		return err
+	}
+	if err := insertMenuItemTags(ctx, tx, item.ID, tagIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交菜單品項失敗", zap.Error(err))
+		return err
+	}
+
+	item.UpdatedAt = now
+	return nil
+}
+
+// DeleteItem 刪除菜單品項，回傳所屬餐廳 ID
+func (r *MenuRepository) DeleteItem(ctx context.Context, id int) (int, error) {
+	var restaurantID int
+	err := r.db.QueryRowContext(ctx, `DELETE FROM menu_items WHERE id = $1 RETURNING restaurant_id`, id).Scan(&restaurantID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrMenuItemNotFound
+		}
+		logger.Error("刪除菜單品項失敗", zap.Error(err), zap.Int("item_id", id))
+		return 0, err
+	}
+
+	return restaurantID, nil
+}
+
+// ReplaceMenu 以匯入內容取代餐廳原有菜單
+func (r *MenuRepository) ReplaceMenu(ctx context.Context, restaurantID int, sections []domain.MenuSection, items []domain.MenuItem, tagIDs map[string]int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_items WHERE restaurant_id = $1`, restaurantID); err != nil {
+		logger.Error("清除菜單品項失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM menu_sections WHERE restaurant_id = $1`, restaurantID); err != nil {
+		logger.Error("清除菜單分類失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+
+	now := time.Now()
+	for i := range sections {
+		section := &sections[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO menu_sections (restaurant_id, name, position, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4)
+			RETURNING id`, restaurantID, section.Name, section.Position, now,
+		).Scan(&section.ID)
+		if err != nil {
+			logger.Error("匯入菜單分類失敗", zap.Error(err), zap.String("name", section.Name))
+			return err
+		}
+
+		for j := range section.Items {
+			item := &section.Items[j]
+			item.SectionID = &section.ID
+			if err := insertMenuItem(ctx, tx, item, slugsToIDs(item.Tags, tagIDs)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range items {
+		if err := insertMenuItem(ctx, tx, &items[i], slugsToIDs(items[i].Tags, tagIDs)); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交菜單匯入失敗", zap.Error(err))
+		return err
+	}
+
+	logger.Info("菜單匯入成功", zap.Int("restaurant_id", restaurantID), zap.Int("section_count", len(sections)))
+	return nil
+}
+
+// FindMatchingItems 取得各餐廳符合名稱與價格條件的供應中品項（依價格排序，每間最多 perRestaurant 項）
+func (r *MenuRepository) FindMatchingItems(ctx context.Context, restaurantIDs []int, name string, maxPrice float64, perRestaurant int) (map[int][]domain.MenuItem, error) {
+	query := `
+		WITH ranked AS (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY restaurant_id ORDER BY price, id) AS rn
+			FROM menu_items
+			WHERE restaurant_id = ANY($1) AND is_available = TRUE
+			  AND ($2 = '' OR name ILIKE $2)
+			  AND ($3 <= 0 OR price <= $3)
+		)` + menuItemSelect + `
+		JOIN ranked ON ranked.id = mi.id
+		WHERE ranked.rn <= $4
+		GROUP BY mi.id
+		ORDER BY mi.restaurant_id, mi.price, mi.id`
+
+	pattern := ""
+	if name != "" {
+		pattern = "%" + likeEscaper.Replace(name) + "%"
+	}
+
+	items, err := r.queryItems(ctx, query, pq.Array(restaurantIDs), pattern, maxPrice, perRestaurant)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make(map[int][]domain.MenuItem)
+	for _, item := range items {
+		matches[item.RestaurantID] = append(matches[item.RestaurantID], item)
+	}
+
+	return matches, nil
+}
+
+// SetDerivePriceLevel 設定餐廳價位等級是否由菜單價格計算
+func (r *MenuRepository) SetDerivePriceLevel(ctx context.Context, restaurantID int, derive bool) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE restaurants SET derive_price_level = $1, updated_at = $2 WHERE id = $3`,
+		derive, time.Now(), restaurantID,
+	)
+	if err != nil {
+		logger.Error("更新菜單設定失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRestaurantNotFound
+	}
+
+	return nil
+}
+
+// GetPriceLevelInputs 取得餐廳是否由菜單計算價位，以及供應中品項的價格
+func (r *MenuRepository) GetPriceLevelInputs(ctx context.Context, restaurantID int) (bool, []float64, error) {
+	var derive bool
+	err := r.db.QueryRowContext(ctx, `SELECT derive_price_level FROM restaurants WHERE id = $1`, restaurantID).Scan(&derive)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil, domain.ErrRestaurantNotFound
+		}
+		return false, nil, err
+	}
+	if !derive {
+		return false, nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT price FROM menu_items WHERE restaurant_id = $1 AND is_available = TRUE`, restaurantID)
+	if err != nil {
+		logger.Error("取得菜單價格失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return false, nil, err
+	}
+	defer rows.Close()
+
+	var prices []float64
+	for rows.Next() {
+		var price float64
+		if err := rows.Scan(&price); err != nil {
+			return false, nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	return true, prices, rows.Err()
+}
+
+// UpdatePriceLevel 更新餐廳價位等級
+func (r *MenuRepository) UpdatePriceLevel(ctx context.Context, restaurantID, priceLevel int) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE restaurants SET price_level = $1, updated_at = $2 WHERE id = $3`,
+		priceLevel, time.Now(), restaurantID,
+	)
+	if err != nil {
+		logger.Error("更新餐廳價位等級失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+
+	logger.Info("依菜單更新餐廳價位等級", zap.Int("restaurant_id", restaurantID), zap.Int("price_level", priceLevel))
+	return nil
+}
+
+// queryItems 執行菜單品項查詢
+func (r *MenuRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]domain.MenuItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("取得菜單品項失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.MenuItem{}
+	for rows.Next() {
+		var item domain.MenuItem
+		var tags pq.StringArray
+		err := rows.Scan(
+			&item.ID,
+			&item.RestaurantID,
+			&item.SectionID,
+			&item.Name,
+			&item.Description,
+			&item.Price,
+			&item.Currency,
+			&item.ImageURL,
+			&item.IsAvailable,
+			&item.Position,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&tags,
+		)
+		if err != nil {
+			logger.Error("掃描菜單品項失敗", zap.Error(err))
+			return nil, err
+		}
+		item.Tags = []string(tags)
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理菜單品項查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// insertMenuItem 在交易中新增菜單品項與標籤
+func insertMenuItem(ctx context.Context, tx *sql.Tx, item *domain.MenuItem, tagIDs []int) error {
+	query := `
+		INSERT INTO menu_items (restaurant_id, section_id, name, description, price, currency, image_url,
+		                        is_available, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		RETURNING id`
+
+	now := time.Now()
+	err := tx.QueryRowContext(ctx, query,
+		item.RestaurantID,
+		item.SectionID,
+		item.Name,
+		item.Description,
+		item.Price,
+		item.Currency,
+		item.ImageURL,
+		item.IsAvailable,
+		item.Position,
+		now,
+	).Scan(&item.ID)
+	if err != nil {
+		logger.Error("建立菜單品項失敗", zap.Error(err), zap.String("name", item.Name))
+		return err
+	}
+
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	return insertMenuItemTags(ctx, tx, item.ID, tagIDs)
+}
+
+// insertMenuItemTags 在交易中新增菜單品項標籤
+func insertMenuItemTags(ctx context.Context, tx *sql.Tx, itemID int, tagIDs []int) error {
+	for _, tagID := range tagIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO menu_item_tags (menu_item_id, tag_id) VALUES ($1, $2)`, itemID, tagID); err != nil {
+			logger.Error("設定菜單品項標籤失敗", zap.Error(err), zap.Int("item_id", itemID), zap.Int("tag_id", tagID))
+			return err
+		}
+	}
+	return nil
+}
+
+// slugsToIDs 將標籤識別碼轉換為標籤 ID
+func slugsToIDs(slugs []string, tagIDs map[string]int) []int {
+	ids := make([]int, 0, len(slugs))
+	for _, slug := range slugs {
+		if id, ok := tagIDs[slug]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
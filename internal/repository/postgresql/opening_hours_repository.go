@@ -0,0 +1,138 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// OpeningHoursRepository PostgreSQL 餐廳營業時間資料庫操作實作
+type OpeningHoursRepository struct {
+	db *sql.DB
+}
+
+// NewOpeningHoursRepository 建立營業時間 Repository
+func NewOpeningHoursRepository(db *sql.DB) *OpeningHoursRepository {
+	return &OpeningHoursRepository{
+		db: db,
+	}
+}
+
+// GetByRestaurantID 取得餐廳的時區與每週營業時段
+func (r *OpeningHoursRepository) GetByRestaurantID(ctx context.Context, restaurantID int) (*domain.OpeningHours, error) {
+	hours := &domain.OpeningHours{
+		RestaurantID: restaurantID,
+		Periods:      []domain.OpeningPeriod{},
+	}
+
+	err := r.db.QueryRowContext(ctx, `SELECT time_zone FROM restaurants WHERE id = $1`, restaurantID).Scan(&hours.TimeZone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRestaurantNotFound
+		}
+		logger.Error("取得餐廳時區失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+
+	query := `
+		SELECT open_minute, close_minute
+		FROM restaurant_opening_hours
+		WHERE restaurant_id = $1
+		ORDER BY open_minute`
+
+	rows, err := r.db.QueryContext(ctx, query, restaurantID)
+	if err != nil {
+		logger.Error("取得營業時間失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var openMinute int
+		var closeMinute sql.NullInt64
+		if err := rows.Scan(&openMinute, &closeMinute); err != nil {
+			logger.Error("掃描營業時間失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		closeAt := -1
+		if closeMinute.Valid {
+			closeAt = int(closeMinute.Int64)
+		}
+		hours.Periods = append(hours.Periods, domain.NewOpeningPeriod(openMinute, closeAt))
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理營業時間查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// Replace 以新的時區與營業時段取代餐廳原有的營業時間
+func (r *OpeningHoursRepository) Replace(ctx context.Context, hours *domain.OpeningHours) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE restaurants SET time_zone = $1, updated_at = $2 WHERE id = $3`,
+		hours.TimeZone, time.Now(), hours.RestaurantID)
+	if err != nil {
+		logger.Error("更新餐廳時區失敗", zap.Error(err), zap.Int("restaurant_id", hours.RestaurantID))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrRestaurantNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM restaurant_opening_hours WHERE restaurant_id = $1`, hours.RestaurantID); err != nil {
+		logger.Error("清除營業時間失敗", zap.Error(err), zap.Int("restaurant_id", hours.RestaurantID))
+		return err
+	}
+
+	query := `
+		INSERT INTO restaurant_opening_hours (restaurant_id, open_minute, close_minute)
+		VALUES ($1, $2, $3)`
+
+	for _, period := range hours.Periods {
+		openMinute, err := period.OpenMinute()
+		if err != nil {
+			return domain.ErrInvalidOpeningHours
+		}
+		closeAt, err := period.CloseMinute()
+		if err != nil {
+			return domain.ErrInvalidOpeningHours
+		}
+
+		var closeMinute interface{}
+		if closeAt >= 0 {
+			closeMinute = closeAt
+		}
+
+		if _, err := tx.ExecContext(ctx, query, hours.RestaurantID, openMinute, closeMinute); err != nil {
+			logger.Error("寫入營業時間失敗", zap.Error(err), zap.Int("restaurant_id", hours.RestaurantID))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交營業時間失敗", zap.Error(err))
+		return err
+	}
+
+	logger.Info("營業時間更新成功", zap.Int("restaurant_id", hours.RestaurantID), zap.Int("period_count", len(hours.Periods)))
+	return nil
+}
@@ -0,0 +1,589 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// mergeEntitySpec 合併時移動的資料表設定
+// column 為指向餐廳的欄位，key 為移動記錄使用的識別欄位，
+// conflict 為同一值在保留餐廳已存在時不移動的欄位（空字串表示不會衝突）
+type mergeEntitySpec struct {
+	entity   string
+	table    string
+	column   string
+	key      string
+	conflict string
+}
+
+// mergeEntitySpecs 各類資料的移動設定（依 domain.MergeEntities 順序）
+var mergeEntitySpecs = []mergeEntitySpec{
+	{entity: domain.MergeEntityFavorite, table: "favorite_restaurants", column: "restaurant_id", key: "id", conflict: "user_id"},
+	{entity: domain.MergeEntityGameResult, table: "game_sessions", column: "result_restaurant_id", key: "id"},
+	{entity: domain.MergeEntityGameCandidate, table: "game_session_restaurants", column: "restaurant_id", key: "session_id", conflict: "session_id"},
+	{entity: domain.MergeEntityAdvertisement, table: "advertisements", column: "restaurant_id", key: "id"},
+	{entity: domain.MergeEntityReview, table: "reviews", column: "restaurant_id", key: "id", conflict: "user_id"},
+	{entity: domain.MergeEntityTag, table: "restaurant_tags", column: "restaurant_id", key: "tag_id", conflict: "tag_id"},
+}
+
+// conflictCondition 資料列在目標餐廳已有相同衝突欄位值的條件（$n 為目標餐廳 ID）
+func (s mergeEntitySpec) conflictCondition(target string) string {
+	if s.conflict == "" {
+		return "FALSE"
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.%s = %s AND s.%s = t.%s)", s.table, s.column, target, s.conflict, s.conflict)
+}
+
+// countQuery 計算可移動與衝突的資料數（$1 保留餐廳、$2 重複餐廳）
+func (s mergeEntitySpec) countQuery() string {
+	return fmt.Sprintf(`
+		SELECT COUNT(*) FILTER (WHERE NOT %[1]s), COUNT(*) FILTER (WHERE %[1]s)
+		FROM %[2]s t
+		WHERE t.%[3]s = $2`, s.conflictCondition("$1"), s.table, s.column)
+}
+
+// moveQuery 將重複餐廳的資料移至保留餐廳並記錄（$1 保留餐廳、$2 重複餐廳、$3 合併記錄）
+func (s mergeEntitySpec) moveQuery() string {
+	return fmt.Sprintf(`
+		WITH moved AS (
+			UPDATE %[1]s t SET %[2]s = $1
+			WHERE t.%[2]s = $2 AND NOT %[3]s
+			RETURNING t.%[4]s
+		)
+		INSERT INTO restaurant_merge_moves (merge_id, entity, entity_key)
+		SELECT $3, '%[5]s', %[4]s::TEXT FROM moved`, s.table, s.column, s.conflictCondition("$1"), s.key, s.entity)
+}
+
+// undoQuery 將合併時移動的資料移回重複餐廳（$1 保留餐廳、$2 重複餐廳、$3 合併記錄）
+func (s mergeEntitySpec) undoQuery() string {
+	return fmt.Sprintf(`
+		UPDATE %[1]s t SET %[2]s = $2
+		WHERE t.%[2]s = $1
+		  AND t.%[3]s::TEXT IN (SELECT entity_key FROM restaurant_merge_moves WHERE merge_id = $3 AND entity = '%[4]s')
+		  AND NOT %[5]s`, s.table, s.column, s.key, s.entity, s.conflictCondition("$2"))
+}
+
+// RestaurantMergeRepository PostgreSQL 重複餐廳偵測與合併實作
+type RestaurantMergeRepository struct {
+	db *sql.DB
+}
+
+// NewRestaurantMergeRepository 建立餐廳合併 Repository
+func NewRestaurantMergeRepository(db *sql.DB) *RestaurantMergeRepository {
+	return &RestaurantMergeRepository{
+		db: db,
+	}
+}
+
+// DetectDuplicates 找出名稱相似且座標相近的營業中餐廳，寫入疑似重複清單，回傳偵測到的組數
+// 已略過或已合併的組合不會重新列入待處理
+func (r *RestaurantMergeRepository) DetectDuplicates(ctx context.Context, settings domain.DuplicateDetectionSettings) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// 移除已停用餐廳的待處理組合
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM restaurant_duplicate_candidates c
+		USING restaurants a, restaurants b
+		WHERE c.status = 'pending' AND a.id = c.restaurant_id AND b.id = c.duplicate_id
+		  AND (NOT a.is_active OR NOT b.is_active)`)
+	if err != nil {
+		logger.Error("清除過期的疑似重複餐廳失敗", zap.Error(err))
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO restaurant_duplicate_candidates (restaurant_id, duplicate_id, name_similarity, distance, detected_at)
+		SELECT a.id, b.id,
+		       ROUND(similarity(a.name, b.name)::NUMERIC, 3),
+		       earth_distance(ll_to_earth(a.latitude, a.longitude), ll_to_earth(b.latitude, b.longitude)),
+		       $3
+		FROM restaurants a
+		JOIN restaurants b
+		  ON b.id > a.id
+		 AND b.is_active = TRUE
+		 AND earth_box(ll_to_earth(a.latitude, a.longitude), $1) @> ll_to_earth(b.latitude, b.longitude)
+		 AND earth_distance(ll_to_earth(a.latitude, a.longitude), ll_to_earth(b.latitude, b.longitude)) <= $1
+		WHERE a.is_active = TRUE
+		  AND similarity(a.name, b.name) >= $2
+		ON CONFLICT (restaurant_id, duplicate_id) DO UPDATE
+		SET name_similarity = EXCLUDED.name_similarity, distance = EXCLUDED.distance, detected_at = EXCLUDED.detected_at
+		WHERE restaurant_duplicate_candidates.status = 'pending'`,
+		settings.MaxDistance, settings.MinNameSimilarity, time.Now(),
+	)
+	if err != nil {
+		logger.Error("偵測重複餐廳失敗", zap.Error(err))
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// ListDuplicateCandidates 取得疑似重複餐廳清單（依名稱相似度排序）與總數
+func (r *RestaurantMergeRepository) ListDuplicateCandidates(ctx context.Context, status domain.DuplicateCandidateStatus, limit, offset int) ([]domain.DuplicateCandidate, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM restaurant_duplicate_candidates WHERE status = $1`, status).Scan(&total); err != nil {
+		logger.Error("計算疑似重複餐廳數量失敗", zap.Error(err))
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, a.name, a.address, a.latitude, a.longitude, COALESCE(a.google_id, ''), a.is_active,
+		       b.id, b.name, b.address, b.latitude, b.longitude, COALESCE(b.google_id, ''), b.is_active,
+		       c.name_similarity, c.distance, c.status, c.detected_at, c.reviewed_at
+		FROM restaurant_duplicate_candidates c
+		JOIN restaurants a ON a.id = c.restaurant_id
+		JOIN restaurants b ON b.id = c.duplicate_id
+		WHERE c.status = $1
+		ORDER BY c.name_similarity DESC, c.distance, c.restaurant_id
+		LIMIT $2 OFFSET $3`, status, limit, offset)
+	if err != nil {
+		logger.Error("取得疑似重複餐廳失敗", zap.Error(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	candidates := []domain.DuplicateCandidate{}
+	for rows.Next() {
+		var candidate domain.DuplicateCandidate
+		var reviewedAt sql.NullTime
+		a, b := &candidate.Restaurant, &candidate.Duplicate
+		err := rows.Scan(
+			&a.ID, &a.Name, &a.Address, &a.Latitude, &a.Longitude, &a.GoogleID, &a.IsActive,
+			&b.ID, &b.Name, &b.Address, &b.Latitude, &b.Longitude, &b.GoogleID, &b.IsActive,
+			&candidate.NameSimilarity, &candidate.Distance, &candidate.Status, &candidate.DetectedAt, &reviewedAt,
+		)
+		if err != nil {
+			logger.Error("掃描疑似重複餐廳失敗", zap.Error(err))
+			return nil, 0, err
+		}
+		if reviewedAt.Valid {
+			candidate.ReviewedAt = &reviewedAt.Time
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return candidates, total, nil
+}
+
+// DismissDuplicate 將疑似重複的組合標記為非重複
+func (r *RestaurantMergeRepository) DismissDuplicate(ctx context.Context, restaurantID, duplicateID, userID int) error {
+	low, high := orderedPair(restaurantID, duplicateID)
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE restaurant_duplicate_candidates
+		SET status = 'dismissed', reviewed_by = $3, reviewed_at = $4
+		WHERE restaurant_id = $1 AND duplicate_id = $2 AND status = 'pending'`,
+		low, high, userID, time.Now())
+	if err != nil {
+		logger.Error("略過疑似重複餐廳失敗", zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDuplicateCandidateNotFound
+	}
+	return nil
+}
+
+// PreviewMerge 預覽合併：計算各類資料將移動與衝突的數量，不修改資料
+func (r *RestaurantMergeRepository) PreviewMerge(ctx context.Context, survivorID, duplicateID int) (*domain.RestaurantMergePreview, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	survivor, duplicate, err := loadMergeRestaurants(ctx, tx, survivorID, duplicateID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.RestaurantMergePreview{
+		Survivor:      *survivor,
+		Duplicate:     *duplicate,
+		Moves:         make(map[string]int, len(mergeEntitySpecs)),
+		Conflicts:     make(map[string]int, len(mergeEntitySpecs)),
+		MovesGoogleID: survivor.GoogleID == "" && duplicate.GoogleID != "",
+	}
+
+	for _, spec := range mergeEntitySpecs {
+		var moves, conflicts int
+		if err := tx.QueryRowContext(ctx, spec.countQuery(), survivorID, duplicateID).Scan(&moves, &conflicts); err != nil {
+			logger.Error("計算合併資料失敗", zap.Error(err), zap.String("entity", spec.entity))
+			return nil, err
+		}
+		preview.Moves[spec.entity] = moves
+		preview.Conflicts[spec.entity] = conflicts
+	}
+
+	return preview, nil
+}
+
+// Merge 將重複餐廳的資料移至保留餐廳，停用重複餐廳並記錄合併（可復原）
+// 會衝突的資料（例如同一使用者對兩間餐廳都有評論）保留在重複餐廳
+func (r *RestaurantMergeRepository) Merge(ctx context.Context, survivorID, duplicateID, userID int) (*domain.RestaurantMerge, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	survivor, duplicate, err := loadMergeRestaurants(ctx, tx, survivorID, duplicateID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	merge := &domain.RestaurantMerge{
+		SurvivorID:  survivorID,
+		DuplicateID: duplicateID,
+		MergedBy:    &userID,
+		MergedAt:    now,
+	}
+
+	// 保留餐廳沒有外部 ID 時接手重複餐廳的外部 ID，避免下次匯入再建立重複餐廳
+	if survivor.GoogleID == "" && duplicate.GoogleID != "" {
+		merge.MovedGoogleID = duplicate.GoogleID
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO restaurant_merges (survivor_id, duplicate_id, moved_google_id, merged_by, merged_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		RETURNING id`,
+		survivorID, duplicateID, merge.MovedGoogleID, userID, now,
+	).Scan(&merge.ID)
+	if err != nil {
+		logger.Error("建立合併記錄失敗", zap.Error(err))
+		return nil, err
+	}
+
+	for _, spec := range mergeEntitySpecs {
+		if _, err := tx.ExecContext(ctx, spec.moveQuery(), survivorID, duplicateID, merge.ID); err != nil {
+			logger.Error("移動合併資料失敗", zap.Error(err), zap.String("entity", spec.entity))
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE restaurants
+		SET is_active = FALSE, merged_into_id = $1, google_id = CASE WHEN $3 <> '' THEN NULL ELSE google_id END, updated_at = $4
+		WHERE id = $2`, survivorID, duplicateID, merge.MovedGoogleID, now); err != nil {
+		logger.Error("停用重複餐廳失敗", zap.Error(err), zap.Int("restaurant_id", duplicateID))
+		return nil, err
+	}
+	if merge.MovedGoogleID != "" {
+		if _, err := tx.ExecContext(ctx, `UPDATE restaurants SET google_id = $1, updated_at = $2 WHERE id = $3`,
+			merge.MovedGoogleID, now, survivorID); err != nil {
+			logger.Error("移動外部 ID 失敗", zap.Error(err), zap.Int("restaurant_id", survivorID))
+			return nil, err
+		}
+	}
+
+	if err := recomputeMergeAggregates(ctx, tx, survivorID, duplicateID); err != nil {
+		return nil, err
+	}
+	if err := setDuplicateCandidateStatus(ctx, tx, survivorID, duplicateID, domain.DuplicateCandidateMerged, &userID, now); err != nil {
+		return nil, err
+	}
+
+	if merge.Moves, err = countMergeMoves(ctx, tx, merge.ID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	logger.Info("餐廳合併成功", zap.Int("merge_id", merge.ID), zap.Int("survivor_id", survivorID), zap.Int("duplicate_id", duplicateID))
+	return merge, nil
+}
+
+// UndoMerge 復原合併：將移動的資料移回、重新啟用重複餐廳
+func (r *RestaurantMergeRepository) UndoMerge(ctx context.Context, mergeID, userID int) (*domain.RestaurantMerge, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	merge, err := scanRestaurantMerge(tx.QueryRowContext(ctx, restaurantMergeSelect+`
+		WHERE m.id = $1
+		FOR UPDATE`, mergeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRestaurantMergeNotFound
+		}
+		logger.Error("取得合併記錄失敗", zap.Error(err), zap.Int("merge_id", mergeID))
+		return nil, err
+	}
+	if merge.UndoneAt != nil {
+		return nil, domain.ErrMergeAlreadyUndone
+	}
+
+	// 保留的餐廳又被合併到其他餐廳時，資料已移走，須先復原較新的合併
+	var survivorMergedInto sql.NullInt64
+	err = tx.QueryRowContext(ctx, `
+		SELECT merged_into_id FROM restaurants WHERE id = $1 FOR UPDATE`, merge.SurvivorID,
+	).Scan(&survivorMergedInto)
+	if err != nil {
+		logger.Error("取得保留餐廳失敗", zap.Error(err), zap.Int("restaurant_id", merge.SurvivorID))
+		return nil, err
+	}
+	if survivorMergedInto.Valid {
+		return nil, domain.ErrMergeUndoBlocked
+	}
+
+	for _, spec := range mergeEntitySpecs {
+		if _, err := tx.ExecContext(ctx, spec.undoQuery(), merge.SurvivorID, merge.DuplicateID, merge.ID); err != nil {
+			logger.Error("移回合併資料失敗", zap.Error(err), zap.String("entity", spec.entity))
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if merge.MovedGoogleID != "" {
+		if _, err := tx.ExecContext(ctx, `UPDATE restaurants SET google_id = NULL, updated_at = $1 WHERE id = $2 AND google_id = $3`,
+			now, merge.SurvivorID, merge.MovedGoogleID); err != nil {
+			logger.Error("移回外部 ID 失敗", zap.Error(err), zap.Int("restaurant_id", merge.SurvivorID))
+			return nil, err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE restaurants
+		SET is_active = TRUE, merged_into_id = NULL, google_id = COALESCE(NULLIF($1, ''), google_id), updated_at = $2
+		WHERE id = $3`, merge.MovedGoogleID, now, merge.DuplicateID); err != nil {
+		logger.Error("重新啟用餐廳失敗", zap.Error(err), zap.Int("restaurant_id", merge.DuplicateID))
+		return nil, err
+	}
+
+	if err := recomputeMergeAggregates(ctx, tx, merge.SurvivorID, merge.DuplicateID); err != nil {
+		return nil, err
+	}
+	if err := setDuplicateCandidateStatus(ctx, tx, merge.SurvivorID, merge.DuplicateID, domain.DuplicateCandidatePending, nil, now); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE restaurant_merges SET undone_by = $1, undone_at = $2 WHERE id = $3`,
+		userID, now, mergeID); err != nil {
+		logger.Error("更新合併記錄失敗", zap.Error(err), zap.Int("merge_id", mergeID))
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	merge.UndoneBy = &userID
+	merge.UndoneAt = &now
+	logger.Info("餐廳合併已復原", zap.Int("merge_id", mergeID))
+	return merge, nil
+}
+
+// restaurantMergeSelect 合併記錄查詢欄位
+const restaurantMergeSelect = `
+	SELECT m.id, m.survivor_id, m.duplicate_id, COALESCE(m.moved_google_id, ''), m.merged_by, m.merged_at, m.undone_by, m.undone_at
+	FROM restaurant_merges m`
+
+// ListMerges 取得合併記錄（稽核用，新到舊）
+func (r *RestaurantMergeRepository) ListMerges(ctx context.Context, limit, offset int) ([]domain.RestaurantMerge, error) {
+	rows, err := r.db.QueryContext(ctx, restaurantMergeSelect+`
+		ORDER BY m.merged_at DESC, m.id DESC
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		logger.Error("取得合併記錄失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	merges := []domain.RestaurantMerge{}
+	for rows.Next() {
+		merge, err := scanRestaurantMerge(rows)
+		if err != nil {
+			logger.Error("掃描合併記錄失敗", zap.Error(err))
+			return nil, err
+		}
+		merges = append(merges, *merge)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range merges {
+		if merges[i].Moves, err = countMergeMoves(ctx, r.db, merges[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return merges, nil
+}
+
+// rowScanner 可掃描單筆資料列（*sql.Row 與 *sql.Rows）
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRestaurantMerge 掃描合併記錄
+func scanRestaurantMerge(row rowScanner) (*domain.RestaurantMerge, error) {
+	merge := &domain.RestaurantMerge{}
+	var mergedBy, undoneBy sql.NullInt64
+	var undoneAt sql.NullTime
+	err := row.Scan(&merge.ID, &merge.SurvivorID, &merge.DuplicateID, &merge.MovedGoogleID, &mergedBy, &merge.MergedAt, &undoneBy, &undoneAt)
+	if err != nil {
+		return nil, err
+	}
+	if mergedBy.Valid {
+		id := int(mergedBy.Int64)
+		merge.MergedBy = &id
+	}
+	if undoneBy.Valid {
+		id := int(undoneBy.Int64)
+		merge.UndoneBy = &id
+	}
+	if undoneAt.Valid {
+		merge.UndoneAt = &undoneAt.Time
+	}
+	return merge, nil
+}
+
+// queryer 可執行查詢的資料庫連線或交易
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// countMergeMoves 計算合併記錄中各類資料的移動數量
+func countMergeMoves(ctx context.Context, q queryer, mergeID int) (map[string]int, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT entity, COUNT(*)
+		FROM restaurant_merge_moves
+		WHERE merge_id = $1
+		GROUP BY entity`, mergeID)
+	if err != nil {
+		logger.Error("計算合併移動資料失敗", zap.Error(err), zap.Int("merge_id", mergeID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	moves := make(map[string]int, len(domain.MergeEntities))
+	for _, entity := range domain.MergeEntities {
+		moves[entity] = 0
+	}
+	for rows.Next() {
+		var entity string
+		var count int
+		if err := rows.Scan(&entity, &count); err != nil {
+			return nil, err
+		}
+		moves[entity] = count
+	}
+	return moves, rows.Err()
+}
+
+// loadMergeRestaurants 取得並檢查合併的兩間餐廳：必須不同、皆營業中且未被合併
+func loadMergeRestaurants(ctx context.Context, tx *sql.Tx, survivorID, duplicateID int, forUpdate bool) (*domain.RestaurantSummary, *domain.RestaurantSummary, error) {
+	if survivorID == duplicateID {
+		return nil, nil, domain.ErrInvalidRestaurantMerge
+	}
+
+	query := `
+		SELECT id, name, address, latitude, longitude, COALESCE(google_id, ''), is_active
+		FROM restaurants
+		WHERE id = ANY(ARRAY[$1, $2]::INTEGER[])
+		ORDER BY id`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	rows, err := tx.QueryContext(ctx, query, survivorID, duplicateID)
+	if err != nil {
+		logger.Error("取得合併餐廳失敗", zap.Error(err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	restaurants := make(map[int]*domain.RestaurantSummary, 2)
+	for rows.Next() {
+		restaurant := &domain.RestaurantSummary{}
+		if err := rows.Scan(&restaurant.ID, &restaurant.Name, &restaurant.Address, &restaurant.Latitude, &restaurant.Longitude, &restaurant.GoogleID, &restaurant.IsActive); err != nil {
+			return nil, nil, err
+		}
+		restaurants[restaurant.ID] = restaurant
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	survivor, duplicate := restaurants[survivorID], restaurants[duplicateID]
+	if survivor == nil || duplicate == nil {
+		return nil, nil, domain.ErrRestaurantNotFound
+	}
+	if !survivor.IsActive || !duplicate.IsActive {
+		return nil, nil, domain.ErrInvalidRestaurantMerge
+	}
+	return survivor, duplicate, nil
+}
+
+// recomputeMergeAggregates 合併或復原後重新計算兩間餐廳的評論彙總
+func recomputeMergeAggregates(ctx context.Context, tx *sql.Tx, restaurantIDs ...int) error {
+	for _, id := range restaurantIDs {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE restaurants r
+			SET review_count = s.count, review_rating_sum = s.rating_sum
+			FROM (
+				SELECT COUNT(*) AS count, COALESCE(SUM(rating), 0) AS rating_sum
+				FROM reviews
+				WHERE restaurant_id = $1 AND status <> 'hidden'
+			) s
+			WHERE r.id = $1`, id)
+		if err != nil {
+			logger.Error("重新計算餐廳評分彙總失敗", zap.Error(err), zap.Int("restaurant_id", id))
+			return err
+		}
+	}
+	return nil
+}
+
+// setDuplicateCandidateStatus 更新疑似重複組合的處理狀態（組合不存在時略過）
+func setDuplicateCandidateStatus(ctx context.Context, tx *sql.Tx, restaurantID, duplicateID int, status domain.DuplicateCandidateStatus, userID *int, at time.Time) error {
+	low, high := orderedPair(restaurantID, duplicateID)
+	_, err := tx.ExecContext(ctx, `
+		UPDATE restaurant_duplicate_candidates
+		SET status = $3, reviewed_by = $4, reviewed_at = CASE WHEN $3 = 'pending' THEN NULL ELSE $5::TIMESTAMP END
+		WHERE restaurant_id = $1 AND duplicate_id = $2`,
+		low, high, status, userID, at)
+	if err != nil {
+		logger.Error("更新疑似重複餐廳狀態失敗", zap.Error(err))
+	}
+	return err
+}
+
+// orderedPair 依大小排列兩個餐廳 ID（疑似重複組合的主鍵順序）
+func orderedPair(a, b int) (int, int) {
+	if a < b {
+		return a, b
+	}
+	return b, a
+}
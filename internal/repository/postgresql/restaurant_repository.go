@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
 	"go.uber.org/zap"
@@ -28,8 +31,8 @@ func NewRestaurantRepository(db *sql.DB) *RestaurantRepository {
 // Create 建立新餐廳
 func (r *RestaurantRepository) Create(ctx context.Context, restaurant *domain.Restaurant) error {
 	query := `
-		INSERT INTO restaurants (name, address, latitude, longitude, phone, rating, price_level, cuisine, is_active, google_id, image_url, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		INSERT INTO restaurants (name, address, latitude, longitude, phone, rating, price_level, cuisine_id, is_active, google_id, image_url, description, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, COALESCE(NULLIF($13, ''), 'manual'), $14, $15)
 		RETURNING id`
 
 	now := time.Now()
@@ -41,16 +44,21 @@ func (r *RestaurantRepository) Create(ctx context.Context, restaurant *domain.Re
 		restaurant.Phone,
 		restaurant.Rating,
 		restaurant.PriceLevel,
-		restaurant.Cuisine,
+		restaurant.CuisineID,
 		restaurant.IsActive,
 		restaurant.GoogleID,
 		restaurant.ImageURL,
 		restaurant.Description,
+		restaurant.Source,
 		now,
 		now,
 	).Scan(&restaurant.ID)
 
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrRestaurantProviderIDExists
+		}
 		logger.Error("建立餐廳失敗", zap.Error(err), zap.String("name", restaurant.Name))
 		return err
 	}
@@ -65,12 +73,12 @@ func (r *RestaurantRepository) Create(ctx context.Context, restaurant *domain.Re
 // GetByID 根據 ID 取得餐廳
 func (r *RestaurantRepository) GetByID(ctx context.Context, id int) (*domain.Restaurant, error) {
 	query := `
-		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine, is_active, google_id, image_url, description, created_at, updated_at
+		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine_id, is_active, google_id, image_url, description, review_count, COALESCE(review_rating, 0), blended_rating, locked_fields, source, provider_sync_status, provider_synced_at, created_at, updated_at
 		FROM restaurants
 		WHERE id = $1 AND is_active = TRUE`
 
 	restaurant := &domain.Restaurant{}
-	var phone, googleID, imageURL, description sql.NullString
+	var phone, googleID, imageURL, description, syncStatus sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&restaurant.ID,
@@ -81,11 +89,18 @@ func (r *RestaurantRepository) GetByID(ctx context.Context, id int) (*domain.Res
 		&phone,
 		&restaurant.Rating,
 		&restaurant.PriceLevel,
-		&restaurant.Cuisine,
+		&restaurant.CuisineID,
 		&restaurant.IsActive,
 		&googleID,
 		&imageURL,
 		&description,
+		&restaurant.ReviewCount,
+		&restaurant.ReviewRating,
+		&restaurant.BlendedRating,
+		(*pq.StringArray)(&restaurant.LockedFields),
+		&restaurant.Source,
+		&syncStatus,
+		&restaurant.ProviderSyncedAt,
 		&restaurant.CreatedAt,
 		&restaurant.UpdatedAt,
 	)
@@ -111,31 +126,111 @@ func (r *RestaurantRepository) GetByID(ctx context.Context, id int) (*domain.Res
 	if description.Valid {
 		restaurant.Description = description.String
 	}
+	restaurant.ProviderSyncStatus = domain.ProviderSyncStatus(syncStatus.String)
 
 	return restaurant, nil
 }
 
 // SearchNearby 搜尋附近餐廳
 func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.RestaurantSearchParams) ([]domain.RestaurantWithDistance, error) {
-	// 建立基本查詢
+	baseQuery, args := buildSearchNearbyQuery(params)
+
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	if err != nil {
+		logger.Error("搜尋附近餐廳失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []domain.RestaurantWithDistance
+	for rows.Next() {
+		var restaurant domain.RestaurantWithDistance
+		var phone, googleID, imageURL, description sql.NullString
+
+		err := rows.Scan(
+			&restaurant.ID,
+			&restaurant.Name,
+			&restaurant.Address,
+			&restaurant.Latitude,
+			&restaurant.Longitude,
+			&phone,
+			&restaurant.Rating,
+			&restaurant.PriceLevel,
+			&restaurant.CuisineID,
+			&restaurant.IsActive,
+			&googleID,
+			&imageURL,
+			&description,
+			&restaurant.ReviewCount,
+			&restaurant.ReviewRating,
+			&restaurant.BlendedRating,
+			&restaurant.Distance,
+			&restaurant.Closed,
+		)
+
+		if err != nil {
+			logger.Error("掃描餐廳資料失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		if phone.Valid {
+			restaurant.Phone = phone.String
+		}
+		if googleID.Valid {
+			restaurant.GoogleID = googleID.String
+		}
+		if imageURL.Valid {
+			restaurant.ImageURL = imageURL.String
+		}
+		if description.Valid {
+			restaurant.Description = description.String
+		}
+
+		restaurants = append(restaurants, restaurant)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理餐廳查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("搜尋附近餐廳成功",
+		zap.Float64("latitude", params.Latitude),
+		zap.Float64("longitude", params.Longitude),
+		zap.Int("radius", params.Radius),
+		zap.Int("count", len(restaurants)),
+	)
+
+	return restaurants, nil
+}
+
+// buildSearchNearbyQuery 建立附近餐廳查詢
+// 以 earth_box 配合 idx_restaurants_location 索引先篩選範圍，再以 earth_distance 精確過濾，距離單位為公尺
+func buildSearchNearbyQuery(params *domain.RestaurantSearchParams) (string, []interface{}) {
+	// $4 為營業與休業的檢查時間，未指定時使用目前時間
 	baseQuery := `
-		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine, is_active, google_id, image_url, description,
-		       (6371 * acos(cos(radians($1)) * cos(radians(latitude)) * cos(radians(longitude) - radians($2)) + sin(radians($1)) * sin(radians(latitude)))) AS distance
+		SELECT id, name, address, latitude, longitude, phone, COALESCE(rating, 0), COALESCE(price_level, 1), cuisine_id,
+		       is_active, google_id, image_url, description, review_count, COALESCE(review_rating, 0), blended_rating,
+		       earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) AS distance,
+		       restaurant_closed_on(id, time_zone, COALESCE($4::timestamptz, CURRENT_TIMESTAMP)) AS closed
 		FROM restaurants
-		WHERE is_active = TRUE`
+		WHERE is_active = TRUE
+		  AND earth_box(ll_to_earth($1, $2), $3) @> ll_to_earth(latitude, longitude)
+		  AND earth_distance(ll_to_earth($1, $2), ll_to_earth(latitude, longitude)) <= $3`
 
-	args := []interface{}{params.Latitude, params.Longitude}
-	argIndex := 2
+	var checkTime interface{}
+	if params.OpenTime != nil {
+		checkTime = *params.OpenTime
+	}
 
-	// 添加距離篩選
-	baseQuery += fmt.Sprintf(" AND (6371 * acos(cos(radians($1)) * cos(radians(latitude)) * cos(radians(longitude) - radians($2)) + sin(radians($1)) * sin(radians(latitude)))) <= $%d", argIndex+1)
-	args = append(args, float64(params.Radius)/1000) // 轉換為公里
-	argIndex++
+	args := []interface{}{params.Latitude, params.Longitude, float64(params.Radius), checkTime}
+	argIndex := 4
 
-	// 添加料理類型篩選
-	if params.Cuisine != "" {
-		baseQuery += fmt.Sprintf(" AND cuisine ILIKE $%d", argIndex+1)
-		args = append(args, "%"+params.Cuisine+"%")
+	// 添加料理分類篩選（已包含子分類）
+	if len(params.CuisineIDs) > 0 {
+		baseQuery += fmt.Sprintf(" AND cuisine_id = ANY($%d)", argIndex+1)
+		args = append(args, pq.Array(params.CuisineIDs))
 		argIndex++
 	}
 
@@ -146,6 +241,68 @@ func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.
 		argIndex++
 	}
 
+	// 添加價位等級篩選
+	if params.MinPriceLevel > 0 {
+		baseQuery += fmt.Sprintf(" AND COALESCE(price_level, 1) >= $%d", argIndex+1)
+		args = append(args, params.MinPriceLevel)
+		argIndex++
+	}
+	if params.MaxPriceLevel > 0 {
+		baseQuery += fmt.Sprintf(" AND COALESCE(price_level, 1) <= $%d", argIndex+1)
+		args = append(args, params.MaxPriceLevel)
+		argIndex++
+	}
+
+	// 添加標籤篩選：須符合全部標籤
+	if len(params.TagsAll) > 0 {
+		baseQuery += fmt.Sprintf(`
+		  AND id IN (
+		      SELECT rt.restaurant_id
+		      FROM restaurant_tags rt
+		      JOIN tags t ON t.id = rt.tag_id
+		      WHERE t.slug = ANY($%d)
+		      GROUP BY rt.restaurant_id
+		      HAVING COUNT(DISTINCT t.slug) = $%d)`, argIndex+1, argIndex+2)
+		args = append(args, pq.Array(params.TagsAll), len(params.TagsAll))
+		argIndex += 2
+	}
+
+	// 添加標籤篩選：符合任一標籤
+	if len(params.TagsAny) > 0 {
+		baseQuery += fmt.Sprintf(`
+		  AND EXISTS (
+		      SELECT 1
+		      FROM restaurant_tags rt
+		      JOIN tags t ON t.id = rt.tag_id
+		      WHERE rt.restaurant_id = restaurants.id AND t.slug = ANY($%d))`, argIndex+1)
+		args = append(args, pq.Array(params.TagsAny))
+		argIndex++
+	}
+
+	// 添加菜單篩選：供應指定品項且價格不超過上限
+	if params.HasMenuFilter() {
+		menuCondition := "mi.restaurant_id = restaurants.id AND mi.is_available = TRUE"
+		if name := strings.TrimSpace(params.MenuItem); name != "" {
+			menuCondition += fmt.Sprintf(" AND mi.name ILIKE $%d", argIndex+1)
+			args = append(args, "%"+likeEscaper.Replace(name)+"%")
+			argIndex++
+		}
+		if params.MaxItemPrice > 0 {
+			menuCondition += fmt.Sprintf(" AND mi.price <= $%d", argIndex+1)
+			args = append(args, params.MaxItemPrice)
+			argIndex++
+		}
+		baseQuery += " AND EXISTS (SELECT 1 FROM menu_items mi WHERE " + menuCondition + ")"
+	}
+
+	// 添加營業時間篩選（以餐廳時區計算），指定營業時間時一併排除休業餐廳
+	if params.OpenTime != nil {
+		baseQuery += " AND restaurant_open_at(id, time_zone, $4)"
+	}
+	if params.OpenTime != nil || params.ExcludeClosed {
+		baseQuery += " AND NOT restaurant_closed_on(id, time_zone, COALESCE($4::timestamptz, CURRENT_TIMESTAMP))"
+	}
+
 	// 排序和限制
 	baseQuery += " ORDER BY distance"
 	if params.Limit > 0 {
@@ -153,16 +310,48 @@ func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.
 		args = append(args, params.Limit)
 	}
 
-	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	return baseQuery, args
+}
+
+// CountInViewport 計算地圖可視範圍內符合條件的餐廳數量
+func (r *RestaurantRepository) CountInViewport(ctx context.Context, params *domain.ViewportSearchParams) (int, error) {
+	conditions, args := buildViewportConditions(params)
+	query := `SELECT COUNT(*) FROM restaurants WHERE ` + conditions
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		logger.Error("計算範圍內餐廳數量失敗", zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SearchInViewport 取得地圖可視範圍內的餐廳（依評分排序）
+func (r *RestaurantRepository) SearchInViewport(ctx context.Context, params *domain.ViewportSearchParams) ([]domain.Restaurant, error) {
+	conditions, args := buildViewportConditions(params)
+	query := `
+		SELECT id, name, address, latitude, longitude, phone, COALESCE(rating, 0), COALESCE(price_level, 1), cuisine_id,
+		       is_active, google_id, image_url, description, review_count, COALESCE(review_rating, 0), blended_rating, created_at, updated_at
+		FROM restaurants
+		WHERE ` + conditions + `
+		ORDER BY rating DESC NULLS LAST, id`
+
+	if params.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, params.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		logger.Error("搜尋附近餐廳失敗", zap.Error(err))
+		logger.Error("搜尋範圍內餐廳失敗", zap.Error(err))
 		return nil, err
 	}
 	defer rows.Close()
 
-	var restaurants []domain.RestaurantWithDistance
+	var restaurants []domain.Restaurant
 	for rows.Next() {
-		var restaurant domain.RestaurantWithDistance
+		var restaurant domain.Restaurant
 		var phone, googleID, imageURL, description sql.NullString
 
 		err := rows.Scan(
@@ -174,17 +363,20 @@ func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.
 			&phone,
 			&restaurant.Rating,
 			&restaurant.PriceLevel,
-			&restaurant.Cuisine,
+			&restaurant.CuisineID,
 			&restaurant.IsActive,
 			&googleID,
 			&imageURL,
 			&description,
-			&restaurant.Distance,
+			&restaurant.ReviewCount,
+			&restaurant.ReviewRating,
+			&restaurant.BlendedRating,
+			&restaurant.CreatedAt,
+			&restaurant.UpdatedAt,
 		)
-
 		if err != nil {
 			logger.Error("掃描餐廳資料失敗", zap.Error(err))
-			continue
+			return nil, err
 		}
 
 		// 處理可為空的欄位
@@ -201,9 +393,6 @@ func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.
 			restaurant.Description = description.String
 		}
 
-		// 轉換距離為公尺
-		restaurant.Distance = restaurant.Distance * 1000
-
 		restaurants = append(restaurants, restaurant)
 	}
 
@@ -212,24 +401,91 @@ func (r *RestaurantRepository) SearchNearby(ctx context.Context, params *domain.
 		return nil, err
 	}
 
-	logger.Info("搜尋附近餐廳成功",
-		zap.Float64("latitude", params.Latitude),
-		zap.Float64("longitude", params.Longitude),
-		zap.Int("radius", params.Radius),
-		zap.Int("count", len(restaurants)),
-	)
-
 	return restaurants, nil
 }
 
+// ClusterInViewport 將地圖可視範圍內的餐廳依固定大小的經緯度網格分群
+func (r *RestaurantRepository) ClusterInViewport(ctx context.Context, params *domain.ViewportSearchParams, cellSize float64) ([]domain.RestaurantCluster, error) {
+	conditions, args := buildViewportConditions(params)
+	cellIndex := len(args) + 1
+	args = append(args, cellSize)
+
+	query := fmt.Sprintf(`
+		SELECT AVG(latitude), AVG(longitude), COUNT(*),
+		       MIN(latitude), MIN(longitude), MAX(latitude), MAX(longitude)
+		FROM restaurants
+		WHERE %s
+		GROUP BY FLOOR(latitude / $%d), FLOOR(longitude / $%d)
+		ORDER BY COUNT(*) DESC`, conditions, cellIndex, cellIndex)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("餐廳分群失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clusters []domain.RestaurantCluster
+	for rows.Next() {
+		var cluster domain.RestaurantCluster
+		err := rows.Scan(
+			&cluster.Latitude,
+			&cluster.Longitude,
+			&cluster.Count,
+			&cluster.MinLat,
+			&cluster.MinLng,
+			&cluster.MaxLat,
+			&cluster.MaxLng,
+		)
+		if err != nil {
+			logger.Error("掃描餐廳群集失敗", zap.Error(err))
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理餐廳群集查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+// buildViewportConditions 建立地圖可視範圍與篩選條件，範圍跨越國際換日線時拆成兩段經度
+func buildViewportConditions(params *domain.ViewportSearchParams) (string, []interface{}) {
+	conditions := "is_active = TRUE AND latitude BETWEEN $1 AND $2"
+	args := []interface{}{params.MinLat, params.MaxLat, params.MinLng, params.MaxLng}
+
+	if params.CrossesAntimeridian() {
+		conditions += " AND (longitude >= $3 OR longitude <= $4)"
+	} else {
+		conditions += " AND longitude BETWEEN $3 AND $4"
+	}
+
+	// 添加料理分類篩選（已包含子分類）
+	if len(params.CuisineIDs) > 0 {
+		args = append(args, pq.Array(params.CuisineIDs))
+		conditions += fmt.Sprintf(" AND cuisine_id = ANY($%d)", len(args))
+	}
+
+	// 添加評分篩選
+	if params.MinRating > 0 {
+		args = append(args, params.MinRating)
+		conditions += fmt.Sprintf(" AND rating >= $%d", len(args))
+	}
+
+	return conditions, args
+}
+
 // Update 更新餐廳資訊
 func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Restaurant) error {
 	query := `
 		UPDATE restaurants
 		SET name = $1, address = $2, latitude = $3, longitude = $4, phone = $5, rating = $6, 
-		    price_level = $7, cuisine = $8, is_active = $9, google_id = $10, image_url = $11, 
-		    description = $12, updated_at = $13
-		WHERE id = $14`
+		    price_level = $7, cuisine_id = $8, is_active = $9, google_id = NULLIF($10, ''), image_url = $11, 
+		    description = $12, locked_fields = $13, updated_at = $14
+		WHERE id = $15`
 
 	now := time.Now()
 	result, err := r.db.ExecContext(ctx, query,
@@ -240,16 +496,21 @@ func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Re
 		restaurant.Phone,
 		restaurant.Rating,
 		restaurant.PriceLevel,
-		restaurant.Cuisine,
+		restaurant.CuisineID,
 		restaurant.IsActive,
 		restaurant.GoogleID,
 		restaurant.ImageURL,
 		restaurant.Description,
+		pq.Array(domain.NormalizeLockedFields(restaurant.LockedFields)),
 		now,
 		restaurant.ID,
 	)
 
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrRestaurantProviderIDExists
+		}
 		logger.Error("更新餐廳失敗", zap.Error(err), zap.Int("restaurant_id", restaurant.ID))
 		return err
 	}
@@ -268,10 +529,209 @@ func (r *RestaurantRepository) Update(ctx context.Context, restaurant *domain.Re
 	return nil
 }
 
+// UpsertByProviderID 依外部 ID（google_id）匯入餐廳：不存在時新增，存在時只更新有變動且未被管理員鎖定的欄位
+func (r *RestaurantRepository) UpsertByProviderID(ctx context.Context, restaurant *domain.Restaurant) (domain.ProviderUpsertOutcome, error) {
+	if restaurant.GoogleID == "" {
+		return "", errors.New("缺少外部地點 ID")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	insertQuery := `
+		INSERT INTO restaurants (name, address, latitude, longitude, phone, rating, price_level, cuisine_id, is_active, google_id, image_url, description, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE(NULLIF($13, ''), 'manual'), $14, $15)
+		ON CONFLICT (google_id) DO NOTHING
+		RETURNING id`
+
+	err = tx.QueryRowContext(ctx, insertQuery,
+		restaurant.Name,
+		restaurant.Address,
+		restaurant.Latitude,
+		restaurant.Longitude,
+		restaurant.Phone,
+		restaurant.Rating,
+		restaurant.PriceLevel,
+		restaurant.CuisineID,
+		restaurant.IsActive,
+		restaurant.GoogleID,
+		restaurant.ImageURL,
+		restaurant.Description,
+		restaurant.Source,
+		now,
+		now,
+	).Scan(&restaurant.ID)
+	if err == nil {
+		if err = tx.Commit(); err != nil {
+			return "", err
+		}
+		restaurant.CreatedAt = now
+		restaurant.UpdatedAt = now
+		return domain.ProviderUpsertCreated, nil
+	}
+	if err != sql.ErrNoRows {
+		logger.Error("匯入餐廳失敗", zap.Error(err), zap.String("google_id", restaurant.GoogleID))
+		return "", err
+	}
+
+	// 已存在：鎖定資料列後比較外部資料
+	selectQuery := `
+		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine_id, image_url, locked_fields, derive_price_level, created_at
+		FROM restaurants
+		WHERE google_id = $1
+		FOR UPDATE`
+
+	existing := &domain.Restaurant{GoogleID: restaurant.GoogleID}
+	var phone, imageURL sql.NullString
+	var derivePriceLevel bool
+	err = tx.QueryRowContext(ctx, selectQuery, restaurant.GoogleID).Scan(
+		&existing.ID,
+		&existing.Name,
+		&existing.Address,
+		&existing.Latitude,
+		&existing.Longitude,
+		&phone,
+		&existing.Rating,
+		&existing.PriceLevel,
+		&existing.CuisineID,
+		&imageURL,
+		(*pq.StringArray)(&existing.LockedFields),
+		&derivePriceLevel,
+		&existing.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("取得既有餐廳失敗", zap.Error(err), zap.String("google_id", restaurant.GoogleID))
+		return "", err
+	}
+	existing.Phone = phone.String
+	existing.ImageURL = imageURL.String
+
+	// 價位由菜單推算時不使用外部資料的價位
+	if derivePriceLevel {
+		existing.LockedFields = append(existing.LockedFields, domain.RestaurantFieldPriceLevel)
+	}
+
+	restaurant.ID = existing.ID
+	restaurant.CreatedAt = existing.CreatedAt
+	if !existing.MergeProviderData(restaurant) {
+		return domain.ProviderUpsertUnchanged, nil
+	}
+
+	updateQuery := `
+		UPDATE restaurants
+		SET name = $1, address = $2, latitude = $3, longitude = $4, phone = $5, rating = $6,
+		    price_level = $7, cuisine_id = $8, image_url = $9, updated_at = $10
+		WHERE id = $11`
+
+	_, err = tx.ExecContext(ctx, updateQuery,
+		existing.Name,
+		existing.Address,
+		existing.Latitude,
+		existing.Longitude,
+		existing.Phone,
+		existing.Rating,
+		existing.PriceLevel,
+		existing.CuisineID,
+		existing.ImageURL,
+		now,
+		existing.ID,
+	)
+	if err != nil {
+		logger.Error("更新匯入餐廳失敗", zap.Error(err), zap.Int("restaurant_id", existing.ID))
+		return "", err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", err
+	}
+
+	restaurant.UpdatedAt = now
+	return domain.ProviderUpsertUpdated, nil
+}
+
+// ListStaleProviderRestaurants 取得需要重新同步外部資料的餐廳
+// 從未同步或上次成功同步早於 cutoff 的餐廳，最久未嘗試同步的優先
+func (r *RestaurantRepository) ListStaleProviderRestaurants(ctx context.Context, cutoff time.Time, limit int) ([]domain.Restaurant, error) {
+	query := `
+		SELECT id, name, google_id, cuisine_id, provider_sync_status, provider_synced_at
+		FROM restaurants
+		WHERE google_id IS NOT NULL AND is_active = TRUE
+		  AND (provider_synced_at IS NULL OR provider_synced_at < $1)
+		ORDER BY provider_sync_attempted_at ASC NULLS FIRST, provider_synced_at ASC NULLS FIRST, id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, cutoff, limit)
+	if err != nil {
+		logger.Error("取得待同步餐廳失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var restaurants []domain.Restaurant
+	for rows.Next() {
+		restaurant := domain.Restaurant{IsActive: true}
+		var syncStatus sql.NullString
+		if err := rows.Scan(
+			&restaurant.ID,
+			&restaurant.Name,
+			&restaurant.GoogleID,
+			&restaurant.CuisineID,
+			&syncStatus,
+			&restaurant.ProviderSyncedAt,
+		); err != nil {
+			logger.Error("掃描待同步餐廳失敗", zap.Error(err))
+			return nil, err
+		}
+		restaurant.ProviderSyncStatus = domain.ProviderSyncStatus(syncStatus.String)
+		restaurants = append(restaurants, restaurant)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理待同步餐廳查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return restaurants, nil
+}
+
+// RecordProviderSync 記錄餐廳的外部資料同步結果
+// 取得外部回應（含查無地點、永久歇業）時更新成功同步時間；永久歇業的餐廳同時停用
+func (r *RestaurantRepository) RecordProviderSync(ctx context.Context, id int, status domain.ProviderSyncStatus, syncErr string, at time.Time) error {
+	query := `
+		UPDATE restaurants
+		SET provider_sync_attempted_at = $1,
+		    provider_sync_status = $2,
+		    provider_sync_error = NULLIF($3, ''),
+		    provider_synced_at = CASE WHEN $2 = 'error' THEN provider_synced_at ELSE $1 END,
+		    is_active = CASE WHEN $2 = 'closed' THEN FALSE ELSE is_active END,
+		    updated_at = CASE WHEN $2 = 'closed' THEN $1 ELSE updated_at END
+		WHERE id = $4`
+
+	result, err := r.db.ExecContext(ctx, query, at, string(status), syncErr, id)
+	if err != nil {
+		logger.Error("記錄餐廳同步結果失敗", zap.Error(err), zap.Int("restaurant_id", id))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("餐廳不存在")
+	}
+
+	return nil
+}
+
 // GetAll 取得所有餐廳（管理功能）
 func (r *RestaurantRepository) GetAll(ctx context.Context, limit, offset int) ([]domain.Restaurant, error) {
 	query := `
-		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine, is_active, google_id, image_url, description, created_at, updated_at
+		SELECT id, name, address, latitude, longitude, phone, rating, price_level, cuisine_id, is_active, google_id, image_url, description, review_count, COALESCE(review_rating, 0), blended_rating, locked_fields, source, provider_sync_status, provider_synced_at, created_at, updated_at
 		FROM restaurants
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2`
@@ -286,7 +746,7 @@ func (r *RestaurantRepository) GetAll(ctx context.Context, limit, offset int) ([
 	var restaurants []domain.Restaurant
 	for rows.Next() {
 		var restaurant domain.Restaurant
-		var phone, googleID, imageURL, description sql.NullString
+		var phone, googleID, imageURL, description, syncStatus sql.NullString
 
 		err := rows.Scan(
 			&restaurant.ID,
@@ -297,11 +757,18 @@ func (r *RestaurantRepository) GetAll(ctx context.Context, limit, offset int) ([
 			&phone,
 			&restaurant.Rating,
 			&restaurant.PriceLevel,
-			&restaurant.Cuisine,
+			&restaurant.CuisineID,
 			&restaurant.IsActive,
 			&googleID,
 			&imageURL,
 			&description,
+			&restaurant.ReviewCount,
+			&restaurant.ReviewRating,
+			&restaurant.BlendedRating,
+			(*pq.StringArray)(&restaurant.LockedFields),
+			&restaurant.Source,
+			&syncStatus,
+			&restaurant.ProviderSyncedAt,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
 		)
@@ -324,6 +791,7 @@ func (r *RestaurantRepository) GetAll(ctx context.Context, limit, offset int) ([
 		if description.Valid {
 			restaurant.Description = description.String
 		}
+		restaurant.ProviderSyncStatus = domain.ProviderSyncStatus(syncStatus.String)
 
 		restaurants = append(restaurants, restaurant)
 	}
@@ -337,6 +805,230 @@ func (r *RestaurantRepository) GetAll(ctx context.Context, limit, offset int) ([
 	return restaurants, nil
 }
 
+// SearchText 以名稱、別名與地址模糊比對搜尋餐廳
+// 有定位時依文字相關度與距離的加權分數排序，否則依相關度排序
+func (r *RestaurantRepository) SearchText(ctx context.Context, params *domain.TextSearchParams) ([]domain.TextSearchResult, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 僅在本交易內調整模糊比對門檻，讓 <% 運算子可使用三元組索引
+	threshold := strconv.FormatFloat(domain.TextSearchSimilarityThreshold, 'f', -1, 64)
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`, threshold); err != nil {
+		logger.Error("設定模糊比對門檻失敗", zap.Error(err))
+		return nil, err
+	}
+
+	query, args := buildTextSearchQuery(params)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("文字搜尋餐廳失敗", zap.Error(err), zap.String("query", params.Query))
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []domain.TextSearchResult{}
+	for rows.Next() {
+		var result domain.TextSearchResult
+		var phone, googleID, imageURL, description sql.NullString
+		var distance sql.NullFloat64
+
+		err := rows.Scan(
+			&result.ID,
+			&result.Name,
+			&result.Address,
+			&result.Latitude,
+			&result.Longitude,
+			&phone,
+			&result.Rating,
+			&result.PriceLevel,
+			&result.CuisineID,
+			&result.IsActive,
+			&googleID,
+			&imageURL,
+			&description,
+			&result.ReviewCount,
+			&result.ReviewRating,
+			&result.BlendedRating,
+			&result.CreatedAt,
+			&result.UpdatedAt,
+			&result.Relevance,
+			&distance,
+			&result.Score,
+		)
+		if err != nil {
+			logger.Error("掃描餐廳資料失敗", zap.Error(err))
+			return nil, err
+		}
+
+		// 處理可為空的欄位
+		if phone.Valid {
+			result.Phone = phone.String
+		}
+		if googleID.Valid {
+			result.GoogleID = googleID.String
+		}
+		if imageURL.Valid {
+			result.ImageURL = imageURL.String
+		}
+		if description.Valid {
+			result.Description = description.String
+		}
+		if distance.Valid {
+			value := distance.Float64
+			result.Distance = &value
+		}
+
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理文字搜尋結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// buildTextSearchQuery 建立餐廳文字搜尋查詢
+// $1 為查詢字串（模糊比對），$2 為 ILIKE 部分比對樣式，$3 為查詢字串對應的料理分類
+// 部分比對可處理中文短字串，模糊比對可容忍拼字錯誤與羅馬拼音別名
+func buildTextSearchQuery(params *domain.TextSearchParams) (string, []interface{}) {
+	queryText := strings.TrimSpace(params.Query)
+	cuisineIDs := params.CuisineIDs
+	if cuisineIDs == nil {
+		cuisineIDs = []int{}
+	}
+
+	args := []interface{}{queryText, "%" + likeEscaper.Replace(queryText) + "%", pq.Array(cuisineIDs)}
+
+	distanceExpr := "NULL::float8"
+	scoreExpr := "relevance"
+	locationCondition := ""
+	if params.HasLocation() {
+		args = append(args, *params.Latitude, *params.Longitude)
+		distanceExpr = "earth_distance(ll_to_earth($4, $5), ll_to_earth(r.latitude, r.longitude))"
+		scoreExpr = fmt.Sprintf("relevance * %g + (1 - %g) / (1 + distance / %d)",
+			domain.TextSearchRelevanceWeight, domain.TextSearchRelevanceWeight, domain.TextSearchDistanceScale)
+
+		if params.Radius > 0 {
+			args = append(args, float64(params.Radius))
+			locationCondition = `
+			  AND earth_box(ll_to_earth($4, $5), $6) @> ll_to_earth(r.latitude, r.longitude)
+			  AND ` + distanceExpr + ` <= $6`
+		}
+	}
+
+	query := `
+		SELECT id, name, address, latitude, longitude, phone, COALESCE(rating, 0), COALESCE(price_level, 1), cuisine_id,
+		       is_active, google_id, image_url, description, review_count, COALESCE(review_rating, 0), blended_rating,
+		       created_at, updated_at, relevance, distance, ` + scoreExpr + ` AS score
+		FROM (
+			SELECT r.*,
+			       GREATEST(
+			           CASE WHEN r.name ILIKE $2 THEN 0.8 + 0.2 * similarity($1, r.name) ELSE word_similarity($1, r.name) END,
+			           COALESCE(aliases.relevance, 0),
+			           word_similarity($1, COALESCE(r.address, '')) * 0.6,
+			           CASE WHEN r.cuisine_id = ANY($3) THEN 0.5 ELSE 0 END
+			       ) AS relevance,
+			       ` + distanceExpr + ` AS distance
+			FROM restaurants r
+			LEFT JOIN LATERAL (
+				SELECT MAX(CASE WHEN ra.alias ILIKE $2 THEN 0.8 + 0.2 * similarity($1, ra.alias) ELSE word_similarity($1, ra.alias) END) AS relevance
+				FROM restaurant_aliases ra
+				WHERE ra.restaurant_id = r.id
+			) aliases ON TRUE
+			WHERE r.is_active = TRUE
+			  AND (r.name ILIKE $2 OR $1 <% r.name OR $1 <% r.address OR r.cuisine_id = ANY($3)
+			       OR EXISTS (
+			           SELECT 1 FROM restaurant_aliases ra
+			           WHERE ra.restaurant_id = r.id AND (ra.alias ILIKE $2 OR $1 <% ra.alias)))` + locationCondition + `
+		) matched
+		ORDER BY score DESC, blended_rating DESC, id`
+
+	if params.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+		args = append(args, params.Limit)
+	}
+
+	return query, args
+}
+
+// SetAliases 取代餐廳的搜尋別名
+func (r *RestaurantRepository) SetAliases(ctx context.Context, restaurantID int, aliases []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM restaurant_aliases WHERE restaurant_id = $1`, restaurantID); err != nil {
+		logger.Error("清除餐廳別名失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+
+	for _, alias := range aliases {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO restaurant_aliases (restaurant_id, alias) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			restaurantID, alias,
+		)
+		if err != nil {
+			logger.Error("新增餐廳別名失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID), zap.String("alias", alias))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交餐廳別名失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetAliases 取得餐廳的搜尋別名
+func (r *RestaurantRepository) GetAliases(ctx context.Context, restaurantID int) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT alias FROM restaurant_aliases WHERE restaurant_id = $1 ORDER BY alias`,
+		restaurantID,
+	)
+	if err != nil {
+		logger.Error("取得餐廳別名失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// ImageURLExists 檢查是否有餐廳使用指定的圖片網址
+func (r *RestaurantRepository) ImageURLExists(ctx context.Context, imageURL string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM restaurants
+			WHERE image_url = $1
+		)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, imageURL).Scan(&exists); err != nil {
+		logger.Error("檢查餐廳圖片網址失敗", zap.Error(err))
+		return false, err
+	}
+
+	return exists, nil
+}
+
 // calculateDistance 計算兩點之間的距離（使用 Haversine 公式）
 func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	const R = 6371 // 地球半徑（公里）
@@ -0,0 +1,130 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq" // PostgreSQL 驅動程式
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// openTestDB 開啟測試資料庫，未設定 TEST_DATABASE_URL 時略過測試
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("未設定 TEST_DATABASE_URL，略過資料庫測試")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("開啟資料庫失敗: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := db.Ping(); err != nil {
+		t.Fatalf("連接資料庫失敗: %v", err)
+	}
+
+	return db
+}
+
+func TestSearchNearbyUsesLocationIndex(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	// 使用單一連線，讓 SET 設定套用到 EXPLAIN
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("取得資料庫連線失敗: %v", err)
+	}
+	defer conn.Close()
+
+	// 測試資料量小時規劃器會偏好循序掃描，關閉後確認查詢能使用索引
+	if _, err := conn.ExecContext(ctx, "SET enable_seqscan = off"); err != nil {
+		t.Fatalf("設定規劃器參數失敗: %v", err)
+	}
+	defer conn.ExecContext(ctx, "RESET enable_seqscan")
+
+	params := &domain.RestaurantSearchParams{
+		Latitude:  25.0330,
+		Longitude: 121.5654,
+		Radius:    1000,
+		Cuisine:   "拉麵",
+		MinRating: 3.5,
+		Limit:     20,
+	}
+	query, args := buildSearchNearbyQuery(params)
+
+	rows, err := conn.QueryContext(ctx, "EXPLAIN "+query, args...)
+	if err != nil {
+		t.Fatalf("EXPLAIN 失敗: %v", err)
+	}
+	defer rows.Close()
+
+	var plan strings.Builder
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			t.Fatalf("讀取查詢計畫失敗: %v", err)
+		}
+		plan.WriteString(line)
+		plan.WriteString("\n")
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("讀取查詢計畫失敗: %v", err)
+	}
+
+	if !strings.Contains(plan.String(), "idx_restaurants_location") {
+		t.Fatalf("查詢未使用 idx_restaurants_location 索引，查詢計畫:\n%s", plan.String())
+	}
+}
+
+func TestSearchNearbyReturnsMeters(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("開始交易失敗: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 台北 101 往北約 0.01 度（約 1112 公尺）
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO restaurants (name, address, latitude, longitude, is_active)
+		VALUES ('距離測試餐廳', '測試地址', 25.0430, 121.5654, TRUE)`)
+	if err != nil {
+		t.Fatalf("建立測試餐廳失敗: %v", err)
+	}
+
+	query, args := buildSearchNearbyQuery(&domain.RestaurantSearchParams{
+		Latitude:  25.0330,
+		Longitude: 121.5654,
+		Radius:    2000,
+	})
+
+	rows, err := tx.QueryContext(ctx, "SELECT name, distance FROM ("+query+") AS nearby WHERE name = '距離測試餐廳'", args...)
+	if err != nil {
+		t.Fatalf("搜尋附近餐廳失敗: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		t.Fatal("搜尋結果未包含測試餐廳")
+	}
+
+	var name string
+	var distance float64
+	if err := rows.Scan(&name, &distance); err != nil {
+		t.Fatalf("讀取搜尋結果失敗: %v", err)
+	}
+
+	if distance < 1050 || distance > 1170 {
+		t.Fatalf("距離應約為 1112 公尺，實際為 %.1f", distance)
+	}
+}
@@ -0,0 +1,409 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// reviewSelect 評論查詢欄位（含使用者名稱）
+const reviewSelect = `
+	SELECT rv.id, rv.restaurant_id, rv.user_id, u.username, rv.rating, COALESCE(rv.content, ''), rv.photo_urls,
+	       rv.visit_date, rv.status, rv.flag_count, COALESCE(rv.moderation_note, ''), rv.created_at, rv.updated_at
+	FROM reviews rv
+	JOIN users u ON u.id = rv.user_id`
+
+// ReviewRepository PostgreSQL 評論資料庫操作實作
+type ReviewRepository struct {
+	db *sql.DB
+}
+
+// NewReviewRepository 建立評論 Repository
+func NewReviewRepository(db *sql.DB) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+	}
+}
+
+// Upsert 新增或編輯使用者對餐廳的評論，並遞增更新餐廳評分彙總
+// 回傳值表示是否為新建立的評論
+func (r *ReviewRepository) Upsert(ctx context.Context, review *domain.Review) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var visitDate interface{}
+	if review.VisitDate != "" {
+		visitDate = review.VisitDate
+	}
+
+	// 首次評論以 ON CONFLICT DO NOTHING 建立，同時送出的第一則評論只有一筆會建立，其餘改為編輯
+	var existingRating int
+	var existingStatus domain.ReviewStatus
+	created := false
+	for attempt := 0; ; attempt++ {
+		err = tx.QueryRowContext(ctx, `
+			SELECT id, rating, status, created_at
+			FROM reviews
+			WHERE restaurant_id = $1 AND user_id = $2
+			FOR UPDATE`, review.RestaurantID, review.UserID,
+		).Scan(&review.ID, &existingRating, &existingStatus, &review.CreatedAt)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("取得既有評論失敗", zap.Error(err), zap.Int("restaurant_id", review.RestaurantID))
+			return false, err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO reviews (restaurant_id, user_id, rating, content, photo_urls, visit_date, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+			ON CONFLICT (restaurant_id, user_id) DO NOTHING
+			RETURNING id`,
+			review.RestaurantID, review.UserID, review.Rating, review.Content, pq.Array(review.PhotoURLs), visitDate, domain.ReviewStatusPublished, now,
+		).Scan(&review.ID)
+		if err == nil {
+			created = true
+			break
+		}
+		if !errors.Is(err, sql.ErrNoRows) || attempt > 0 {
+			logger.Error("建立評論失敗", zap.Error(err), zap.Int("restaurant_id", review.RestaurantID))
+			return false, err
+		}
+	}
+
+	if created {
+		review.Status = domain.ReviewStatusPublished
+		review.CreatedAt = now
+		if err := applyReviewAggregate(ctx, tx, review.RestaurantID, 1, review.Rating); err != nil {
+			return false, err
+		}
+	} else {
+		// 已隱藏的評論編輯後仍維持隱藏，不重新計入評分
+		review.Status = existingStatus
+		_, err = tx.ExecContext(ctx, `
+			UPDATE reviews
+			SET rating = $1, content = $2, photo_urls = $3, visit_date = $4, updated_at = $5
+			WHERE id = $6`,
+			review.Rating, review.Content, pq.Array(review.PhotoURLs), visitDate, now, review.ID,
+		)
+		if err != nil {
+			logger.Error("編輯評論失敗", zap.Error(err), zap.Int("review_id", review.ID))
+			return false, err
+		}
+
+		if review.IsCounted() && review.Rating != existingRating {
+			if err := applyReviewAggregate(ctx, tx, review.RestaurantID, 0, review.Rating-existingRating); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交評論失敗", zap.Error(err))
+		return false, err
+	}
+
+	review.UpdatedAt = now
+	return created, nil
+}
+
+// GetByID 根據 ID 取得評論
+func (r *ReviewRepository) GetByID(ctx context.Context, id int) (*domain.Review, error) {
+	reviews, err := r.queryReviews(ctx, reviewSelect+` WHERE rv.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, domain.ErrReviewNotFound
+	}
+
+	return &reviews[0], nil
+}
+
+// ListByRestaurant 取得餐廳的公開評論（不含已隱藏的評論），依建立時間由新到舊
+func (r *ReviewRepository) ListByRestaurant(ctx context.Context, restaurantID, limit, offset int) ([]domain.Review, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM reviews WHERE restaurant_id = $1 AND status <> 'hidden'`,
+		restaurantID,
+	).Scan(&total)
+	if err != nil {
+		logger.Error("計算餐廳評論數量失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, 0, err
+	}
+
+	reviews, err := r.queryReviews(ctx, reviewSelect+`
+		WHERE rv.restaurant_id = $1 AND rv.status <> 'hidden'
+		ORDER BY rv.created_at DESC, rv.id DESC
+		LIMIT $2 OFFSET $3`, restaurantID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// Delete 刪除使用者對餐廳的評論，並更新餐廳評分彙總
+func (r *ReviewRepository) Delete(ctx context.Context, restaurantID, userID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var rating int
+	var status domain.ReviewStatus
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM reviews
+		WHERE restaurant_id = $1 AND user_id = $2
+		RETURNING rating, status`, restaurantID, userID,
+	).Scan(&rating, &status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrReviewNotFound
+		}
+		logger.Error("刪除評論失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID), zap.Int("user_id", userID))
+		return err
+	}
+
+	if status != domain.ReviewStatusHidden {
+		if err := applyReviewAggregate(ctx, tx, restaurantID, -1, -rating); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交刪除評論失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Flag 檢舉評論，公開中的評論會進入版主審核佇列
+func (r *ReviewRepository) Flag(ctx context.Context, reviewID, userID int, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO review_flags (review_id, user_id, reason, created_at) VALUES ($1, $2, $3, $4)`,
+		reviewID, userID, reason, time.Now(),
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrReviewAlreadyFlagged
+		}
+		logger.Error("檢舉評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE reviews
+		SET flag_count = flag_count + 1,
+		    status = CASE WHEN status = 'published' THEN 'flagged' ELSE status END
+		WHERE id = $1`, reviewID)
+	if err != nil {
+		logger.Error("更新評論檢舉次數失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交評論檢舉失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// ListFlagged 取得待審核的評論（依檢舉次數由多到少）
+func (r *ReviewRepository) ListFlagged(ctx context.Context, limit, offset int) ([]domain.Review, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviews WHERE status = 'flagged'`).Scan(&total); err != nil {
+		logger.Error("計算待審核評論數量失敗", zap.Error(err))
+		return nil, 0, err
+	}
+
+	reviews, err := r.queryReviews(ctx, reviewSelect+`
+		WHERE rv.status = 'flagged'
+		ORDER BY rv.flag_count DESC, rv.updated_at
+		LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(reviews) == 0 {
+		return reviews, total, nil
+	}
+
+	reviewIDs := make([]int, 0, len(reviews))
+	index := make(map[int]int, len(reviews))
+	for i, review := range reviews {
+		reviewIDs = append(reviewIDs, review.ID)
+		index[review.ID] = i
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT review_id, reason
+		FROM review_flags
+		WHERE review_id = ANY($1)
+		ORDER BY created_at`, pq.Array(reviewIDs))
+	if err != nil {
+		logger.Error("取得評論檢舉原因失敗", zap.Error(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewID int
+		var reason string
+		if err := rows.Scan(&reviewID, &reason); err != nil {
+			logger.Error("掃描評論檢舉原因失敗", zap.Error(err))
+			return nil, 0, err
+		}
+		i := index[reviewID]
+		reviews[i].FlagReasons = append(reviews[i].FlagReasons, reason)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// Moderate 版主審核評論並依狀態變化更新餐廳評分彙總
+// 核准時清除檢舉記錄，之後可再次被檢舉
+func (r *ReviewRepository) Moderate(ctx context.Context, reviewID, moderatorID int, status domain.ReviewStatus, note string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current := domain.Review{ID: reviewID}
+	err = tx.QueryRowContext(ctx,
+		`SELECT restaurant_id, rating, status FROM reviews WHERE id = $1 FOR UPDATE`,
+		reviewID,
+	).Scan(&current.RestaurantID, &current.Rating, &current.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrReviewNotFound
+		}
+		logger.Error("取得評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return err
+	}
+
+	updated := current
+	updated.Status = status
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE reviews
+		SET status = $1, moderated_by = $2, moderated_at = $3, moderation_note = $4,
+		    flag_count = CASE WHEN $1 = 'published' THEN 0 ELSE flag_count END
+		WHERE id = $5`, status, moderatorID, time.Now(), note, reviewID)
+	if err != nil {
+		logger.Error("審核評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return err
+	}
+
+	if status == domain.ReviewStatusPublished {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM review_flags WHERE review_id = $1`, reviewID); err != nil {
+			logger.Error("清除評論檢舉失敗", zap.Error(err), zap.Int("review_id", reviewID))
+			return err
+		}
+	}
+
+	switch {
+	case current.IsCounted() && !updated.IsCounted():
+		err = applyReviewAggregate(ctx, tx, current.RestaurantID, -1, -current.Rating)
+	case !current.IsCounted() && updated.IsCounted():
+		err = applyReviewAggregate(ctx, tx, current.RestaurantID, 1, current.Rating)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交評論審核失敗", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// queryReviews 執行評論查詢
+func (r *ReviewRepository) queryReviews(ctx context.Context, query string, args ...interface{}) ([]domain.Review, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("取得評論失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []domain.Review{}
+	for rows.Next() {
+		var review domain.Review
+		var photoURLs pq.StringArray
+		var visitDate sql.NullTime
+
+		err := rows.Scan(
+			&review.ID,
+			&review.RestaurantID,
+			&review.UserID,
+			&review.Username,
+			&review.Rating,
+			&review.Content,
+			&photoURLs,
+			&visitDate,
+			&review.Status,
+			&review.FlagCount,
+			&review.ModerationNote,
+			&review.CreatedAt,
+			&review.UpdatedAt,
+		)
+		if err != nil {
+			logger.Error("掃描評論資料失敗", zap.Error(err))
+			return nil, err
+		}
+
+		review.PhotoURLs = []string(photoURLs)
+		if visitDate.Valid {
+			review.VisitDate = visitDate.Time.Format("2006-01-02")
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理評論查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// applyReviewAggregate 在交易中遞增更新餐廳評論數與評分總和
+func applyReviewAggregate(ctx context.Context, tx *sql.Tx, restaurantID, countDelta, ratingDelta int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE restaurants
+		SET review_count = review_count + $1, review_rating_sum = review_rating_sum + $2
+		WHERE id = $3`, countDelta, ratingDelta, restaurantID)
+	if err != nil {
+		logger.Error("更新餐廳評分彙總失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// SearchLogRepository PostgreSQL 搜尋記錄資料庫操作實作
+type SearchLogRepository struct {
+	db *sql.DB
+}
+
+// NewSearchLogRepository 建立搜尋記錄 Repository
+func NewSearchLogRepository(db *sql.DB) *SearchLogRepository {
+	return &SearchLogRepository{
+		db: db,
+	}
+}
+
+// Create 建立搜尋記錄與半徑擴大步驟
+func (r *SearchLogRepository) Create(ctx context.Context, log *domain.SearchLog) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO search_logs (source, geohash, latitude, longitude, requested_radius, final_radius, expansion_count, result_count, external_used, succeeded, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`
+
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query,
+		log.Source,
+		log.Geohash,
+		log.Latitude,
+		log.Longitude,
+		log.RequestedRadius,
+		log.FinalRadius,
+		log.ExpansionCount(),
+		log.ResultCount,
+		log.ExternalUsed,
+		log.Succeeded,
+		now,
+	).Scan(&log.ID)
+
+	if err != nil {
+		logger.Error("建立搜尋記錄失敗", zap.Error(err), zap.String("geohash", log.Geohash))
+		return err
+	}
+
+	stepQuery := `
+		INSERT INTO search_expansions (search_log_id, step, radius, local_count, external_count)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	for _, step := range log.Steps {
+		if _, err := tx.ExecContext(ctx, stepQuery, log.ID, step.Step, step.Radius, step.LocalCount, step.ExternalCount); err != nil {
+			logger.Error("建立搜尋擴大記錄失敗", zap.Error(err), zap.Int("search_log_id", log.ID))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交搜尋記錄失敗", zap.Error(err))
+		return err
+	}
+
+	log.CreatedAt = now
+	return nil
+}
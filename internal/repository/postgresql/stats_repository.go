@@ -0,0 +1,223 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// topRestaurantLimit 最常選中餐廳的回傳數量
+const topRestaurantLimit = 5
+
+// StatsRepository PostgreSQL 統計資料查詢實作
+type StatsRepository struct {
+	db *sql.DB
+}
+
+// NewStatsRepository 建立統計 Repository
+func NewStatsRepository(db *sql.DB) *StatsRepository {
+	return &StatsRepository{
+		db: db,
+	}
+}
+
+// GetUserStats 取得使用者在指定期間內的飲食統計
+func (r *StatsRepository) GetUserStats(ctx context.Context, userID int, params *domain.UserStatsParams) (*domain.UserStats, error) {
+	// game_sessions 的時間欄位為不含時區的 TIMESTAMP，以伺服器本地時間寫入
+	from := params.From.In(time.Local)
+	to := params.To.In(time.Local)
+
+	stats := &domain.UserStats{
+		From:                params.From,
+		To:                  params.To,
+		TimeZone:            params.Location.String(),
+		CuisineDistribution: []domain.CuisineCount{},
+		TopRestaurants:      []domain.RestaurantPickCount{},
+		GameTypeCounts:      []domain.GameTypeCount{},
+	}
+
+	// 遊戲總數與距離
+	summaryQuery := `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'completed'),
+		       COALESCE(SUM(result_distance), 0),
+		       COALESCE(AVG(result_distance), 0)
+		FROM game_sessions
+		WHERE user_id = $1 AND started_at >= $2 AND started_at < $3`
+
+	err := r.db.QueryRowContext(ctx, summaryQuery, userID, from, to).Scan(
+		&stats.TotalGames,
+		&stats.CompletedGames,
+		&stats.TotalDistance,
+		&stats.AverageDistance,
+	)
+	if err != nil {
+		logger.Error("取得遊戲統計失敗", zap.Error(err), zap.Int("user_id", userID))
+		return nil, err
+	}
+
+	if stats.TotalGames == 0 {
+		return stats, nil
+	}
+
+	if err := r.fillResultStats(ctx, userID, from, to, stats); err != nil {
+		return nil, err
+	}
+
+	if err := r.fillGameTypeStats(ctx, userID, from, to, stats); err != nil {
+		return nil, err
+	}
+
+	if err := r.fillTimeBuckets(ctx, userID, from, to, params.Location, stats); err != nil {
+		return nil, err
+	}
+
+	logger.Info("取得使用者統計成功", zap.Int("user_id", userID), zap.Int("total_games", stats.TotalGames))
+	return stats, nil
+}
+
+// fillResultStats 統計結果餐廳的料理類型、價位與最常選中餐廳
+func (r *StatsRepository) fillResultStats(ctx context.Context, userID int, from, to time.Time, stats *domain.UserStats) error {
+	cuisineQuery := `
+		SELECT r.cuisine_id, COUNT(*)
+		FROM game_sessions gs
+		JOIN restaurants r ON gs.result_restaurant_id = r.id
+		WHERE gs.user_id = $1 AND gs.started_at >= $2 AND gs.started_at < $3
+		GROUP BY 1
+		ORDER BY 2 DESC, 1 NULLS LAST`
+
+	rows, err := r.db.QueryContext(ctx, cuisineQuery, userID, from, to)
+	if err != nil {
+		logger.Error("取得料理類型統計失敗", zap.Error(err), zap.Int("user_id", userID))
+		return err
+	}
+	defer rows.Close()
+
+	total := 0
+	for rows.Next() {
+		var item domain.CuisineCount
+		if err := rows.Scan(&item.CuisineID, &item.Count); err != nil {
+			logger.Error("掃描料理類型統計失敗", zap.Error(err))
+			return err
+		}
+		total += item.Count
+		stats.CuisineDistribution = append(stats.CuisineDistribution, item)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("處理料理類型統計結果失敗", zap.Error(err))
+		return err
+	}
+
+	for i := range stats.CuisineDistribution {
+		stats.CuisineDistribution[i].Percentage = float64(stats.CuisineDistribution[i].Count) / float64(total) * 100
+	}
+
+	priceQuery := `
+		SELECT COALESCE(AVG(r.price_level), 0)
+		FROM game_sessions gs
+		JOIN restaurants r ON gs.result_restaurant_id = r.id
+		WHERE gs.user_id = $1 AND gs.started_at >= $2 AND gs.started_at < $3`
+
+	if err := r.db.QueryRowContext(ctx, priceQuery, userID, from, to).Scan(&stats.AveragePriceLevel); err != nil {
+		logger.Error("取得平均價位失敗", zap.Error(err), zap.Int("user_id", userID))
+		return err
+	}
+
+	topQuery := `
+		SELECT r.id, r.name, r.cuisine_id, COUNT(*)
+		FROM game_sessions gs
+		JOIN restaurants r ON gs.result_restaurant_id = r.id
+		WHERE gs.user_id = $1 AND gs.started_at >= $2 AND gs.started_at < $3
+		GROUP BY r.id, r.name, r.cuisine_id
+		ORDER BY COUNT(*) DESC, r.id
+		LIMIT $4`
+
+	topRows, err := r.db.QueryContext(ctx, topQuery, userID, from, to, topRestaurantLimit)
+	if err != nil {
+		logger.Error("取得最常選中餐廳失敗", zap.Error(err), zap.Int("user_id", userID))
+		return err
+	}
+	defer topRows.Close()
+
+	for topRows.Next() {
+		var item domain.RestaurantPickCount
+		if err := topRows.Scan(&item.RestaurantID, &item.Name, &item.CuisineID, &item.Count); err != nil {
+			logger.Error("掃描最常選中餐廳失敗", zap.Error(err))
+			return err
+		}
+		stats.TopRestaurants = append(stats.TopRestaurants, item)
+	}
+
+	return topRows.Err()
+}
+
+// fillGameTypeStats 統計各遊戲類型的次數
+func (r *StatsRepository) fillGameTypeStats(ctx context.Context, userID int, from, to time.Time, stats *domain.UserStats) error {
+	query := `
+		SELECT game_type, COUNT(*)
+		FROM game_sessions
+		WHERE user_id = $1 AND started_at >= $2 AND started_at < $3
+		GROUP BY game_type
+		ORDER BY 2 DESC, 1`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		logger.Error("取得遊戲類型統計失敗", zap.Error(err), zap.Int("user_id", userID))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.GameTypeCount
+		if err := rows.Scan(&item.GameType, &item.Count); err != nil {
+			logger.Error("掃描遊戲類型統計失敗", zap.Error(err))
+			return err
+		}
+		stats.GameTypeCounts = append(stats.GameTypeCounts, item)
+	}
+	if err = rows.Err(); err != nil {
+		logger.Error("處理遊戲類型統計結果失敗", zap.Error(err))
+		return err
+	}
+
+	if len(stats.GameTypeCounts) > 0 {
+		stats.FavoriteGameType = stats.GameTypeCounts[0].GameType
+	}
+
+	return nil
+}
+
+// fillTimeBuckets 依使用者時區統計星期與小時分布
+func (r *StatsRepository) fillTimeBuckets(ctx context.Context, userID int, from, to time.Time, loc *time.Location, stats *domain.UserStats) error {
+	query := `
+		SELECT started_at
+		FROM game_sessions
+		WHERE user_id = $1 AND started_at >= $2 AND started_at < $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, from, to)
+	if err != nil {
+		logger.Error("取得遊戲時間分布失敗", zap.Error(err), zap.Int("user_id", userID))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var startedAt time.Time
+		if err := rows.Scan(&startedAt); err != nil {
+			logger.Error("掃描遊戲時間失敗", zap.Error(err))
+			return err
+		}
+
+		// 將資料庫中的本地時間轉換為使用者時區
+		local := fromLocalTimestamp(startedAt).In(loc)
+
+		stats.GamesByWeekday[local.Weekday()]++
+		stats.GamesByHour[local.Hour()]++
+	}
+
+	return rows.Err()
+}
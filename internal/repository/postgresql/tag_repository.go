@@ -0,0 +1,188 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TagRepository PostgreSQL 標籤資料庫操作實作
+type TagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository 建立標籤 Repository
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
+
+// List 取得標籤清單，可依分類篩選
+func (r *TagRepository) List(ctx context.Context, category domain.TagCategory) ([]domain.Tag, error) {
+	query := `
+		SELECT id, slug, name, category, created_at, updated_at
+		FROM tags
+		WHERE $1 = '' OR category = $1
+		ORDER BY category, id`
+
+	return r.queryTags(ctx, query, string(category))
+}
+
+// GetByRestaurantID 取得餐廳的標籤
+func (r *TagRepository) GetByRestaurantID(ctx context.Context, restaurantID int) ([]domain.Tag, error) {
+	query := `
+		SELECT t.id, t.slug, t.name, t.category, t.created_at, t.updated_at
+		FROM tags t
+		JOIN restaurant_tags rt ON rt.tag_id = t.id
+		WHERE rt.restaurant_id = $1
+		ORDER BY t.category, t.id`
+
+	return r.queryTags(ctx, query, restaurantID)
+}
+
+// GetBySlugs 依識別碼取得標籤
+func (r *TagRepository) GetBySlugs(ctx context.Context, slugs []string) ([]domain.Tag, error) {
+	query := `
+		SELECT id, slug, name, category, created_at, updated_at
+		FROM tags
+		WHERE slug = ANY($1)
+		ORDER BY category, id`
+
+	return r.queryTags(ctx, query, pq.Array(slugs))
+}
+
+// Create 建立標籤
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	query := `
+		INSERT INTO tags (slug, name, category, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query, tag.Slug, tag.Name, tag.Category, now, now).Scan(&tag.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrTagExists
+		}
+		logger.Error("建立標籤失敗", zap.Error(err), zap.String("slug", tag.Slug))
+		return err
+	}
+
+	tag.CreatedAt = now
+	tag.UpdatedAt = now
+
+	logger.Info("標籤建立成功", zap.Int("tag_id", tag.ID), zap.String("slug", tag.Slug))
+	return nil
+}
+
+// Update 更新標籤名稱與分類
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	query := `
+		UPDATE tags
+		SET name = $1, category = $2, updated_at = $3
+		WHERE id = $4
+		RETURNING slug, created_at`
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query, tag.Name, tag.Category, now, tag.ID).Scan(&tag.Slug, &tag.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrTagNotFound
+		}
+		logger.Error("更新標籤失敗", zap.Error(err), zap.Int("tag_id", tag.ID))
+		return err
+	}
+
+	tag.UpdatedAt = now
+	logger.Info("標籤更新成功", zap.Int("tag_id", tag.ID))
+	return nil
+}
+
+// Delete 刪除標籤（一併移除餐廳上的該標籤）
+func (r *TagRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		logger.Error("刪除標籤失敗", zap.Error(err), zap.Int("tag_id", id))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrTagNotFound
+	}
+
+	logger.Info("標籤刪除成功", zap.Int("tag_id", id))
+	return nil
+}
+
+// SetRestaurantTags 以指定標籤取代餐廳原有的標籤
+func (r *TagRepository) SetRestaurantTags(ctx context.Context, restaurantID int, tagIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM restaurant_tags WHERE restaurant_id = $1`, restaurantID); err != nil {
+		logger.Error("清除餐廳標籤失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return err
+	}
+
+	query := `
+		INSERT INTO restaurant_tags (restaurant_id, tag_id, created_at)
+		VALUES ($1, $2, $3)`
+
+	now := time.Now()
+	for _, tagID := range tagIDs {
+		if _, err := tx.ExecContext(ctx, query, restaurantID, tagID, now); err != nil {
+			logger.Error("設定餐廳標籤失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID), zap.Int("tag_id", tagID))
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("提交餐廳標籤失敗", zap.Error(err))
+		return err
+	}
+
+	logger.Info("餐廳標籤更新成功", zap.Int("restaurant_id", restaurantID), zap.Int("tag_count", len(tagIDs)))
+	return nil
+}
+
+// queryTags 執行標籤查詢
+func (r *TagRepository) queryTags(ctx context.Context, query string, args ...interface{}) ([]domain.Tag, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Error("取得標籤失敗", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []domain.Tag{}
+	for rows.Next() {
+		var tag domain.Tag
+		if err := rows.Scan(&tag.ID, &tag.Slug, &tag.Name, &tag.Category, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+			logger.Error("掃描標籤失敗", zap.Error(err))
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error("處理標籤查詢結果失敗", zap.Error(err))
+		return nil, err
+	}
+
+	return tags, nil
+}
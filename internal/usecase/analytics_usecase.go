@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/geohash"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// coverageGeohashPrecision 覆蓋格網的 geohash 精度，與搜尋記錄一致
+	coverageGeohashPrecision = searchLogGeohashPrecision
+	// coverageBatchSize 重建格網時每批讀取的餐廳數量
+	coverageBatchSize = 1000
+	// activityGeohashPrecision 遊戲活動熱度的 geohash 精度，與遊戲開始位置一致
+	activityGeohashPrecision = searchLogGeohashPrecision
+	// defaultHeatmapDays 未指定日期範圍時查詢的天數
+	defaultHeatmapDays = 30
+	// maxHeatmapDays 單次查詢允許的最大天數
+	maxHeatmapDays = 366
+)
+
+// AnalyticsSettings 管理分析設定
+type AnalyticsSettings struct {
+	CoverageLookback      time.Duration // 覆蓋格網參考的搜尋記錄期間
+	ActivityRecomputeDays int           // 每次排程重新彙總的天數（含今日）
+	HeatmapMinCellCount   int           // 熱度格網最低顯示次數，低於此數量的格網不回傳
+}
+
+// AnalyticsUseCase 管理分析業務邏輯
+type AnalyticsUseCase struct {
+	coverageRepo CoverageRepository
+	activityRepo ActivityRepository
+	settings     AnalyticsSettings
+}
+
+// NewAnalyticsUseCase 建立管理分析用例
+func NewAnalyticsUseCase(coverageRepo CoverageRepository, activityRepo ActivityRepository, settings AnalyticsSettings) *AnalyticsUseCase {
+	if settings.ActivityRecomputeDays <= 0 {
+		settings.ActivityRecomputeDays = 1
+	}
+	if settings.HeatmapMinCellCount <= 0 {
+		settings.HeatmapMinCellCount = 1
+	}
+
+	return &AnalyticsUseCase{
+		coverageRepo: coverageRepo,
+		activityRepo: activityRepo,
+		settings:     settings,
+	}
+}
+
+// RefreshCoverage 從餐廳資料與搜尋記錄重建美食沙漠覆蓋格網
+func (uc *AnalyticsUseCase) RefreshCoverage(ctx context.Context) (int, error) {
+	now := time.Now()
+	cells := make(map[string]*domain.CoverageCell)
+
+	cellFor := func(hash string) *domain.CoverageCell {
+		cell, exists := cells[hash]
+		if !exists {
+			lat, lng, _ := geohash.Center(hash)
+			cell = &domain.CoverageCell{
+				Geohash:         hash,
+				CenterLatitude:  lat,
+				CenterLongitude: lng,
+				CuisineCounts:   make(map[string]int),
+				RefreshedAt:     now,
+			}
+			cells[hash] = cell
+		}
+		return cell
+	}
+
+	// 統計各格網的營業中餐廳
+	afterID := 0
+	for {
+		locations, err := uc.coverageRepo.ListActiveRestaurantLocations(ctx, afterID, coverageBatchSize)
+		if err != nil {
+			logger.Error("讀取餐廳位置失敗", zap.Error(err))
+			return 0, errors.New("更新覆蓋格網失敗")
+		}
+
+		for _, location := range locations {
+			cell := cellFor(geohash.Encode(location.Latitude, location.Longitude, coverageGeohashPrecision))
+			cell.RestaurantCount++
+			cuisine := location.Cuisine
+			if cuisine == "" {
+				cuisine = domain.UncategorizedCuisineSlug
+			}
+			cell.CuisineCounts[cuisine]++
+			afterID = location.ID
+		}
+
+		if len(locations) < coverageBatchSize {
+			break
+		}
+	}
+
+	// 合併搜尋記錄統計
+	searchStats, err := uc.coverageRepo.GetSearchStatsByGeohash(ctx, now.Add(-uc.settings.CoverageLookback))
+	if err != nil {
+		logger.Error("讀取搜尋統計失敗", zap.Error(err))
+		return 0, errors.New("更新覆蓋格網失敗")
+	}
+
+	for hash, stats := range searchStats {
+		if len(hash) > coverageGeohashPrecision {
+			hash = hash[:coverageGeohashPrecision]
+		}
+		cell := cellFor(hash)
+		cell.SearchCount += stats.SearchCount
+		cell.FailedSearchCount += stats.FailedSearchCount
+		cell.ExpandedSearchCount += stats.ExpandedSearchCount
+	}
+
+	result := make([]domain.CoverageCell, 0, len(cells))
+	for _, cell := range cells {
+		result = append(result, *cell)
+	}
+
+	if err := uc.coverageRepo.ReplaceCells(ctx, result); err != nil {
+		logger.Error("寫入覆蓋格網失敗", zap.Error(err))
+		return 0, errors.New("更新覆蓋格網失敗")
+	}
+
+	logger.Info("覆蓋格網重建完成", zap.Int("cell_count", len(result)), zap.Duration("elapsed", time.Since(now)))
+	return len(result), nil
+}
+
+// GetCoverageMap 取得美食沙漠覆蓋地圖（GeoJSON）
+func (uc *AnalyticsUseCase) GetCoverageMap(ctx context.Context, query *domain.CoverageQuery) (*domain.GeoJSONFeatureCollection, error) {
+	precision := query.Precision
+	if precision <= 0 || precision > coverageGeohashPrecision {
+		precision = coverageGeohashPrecision
+	}
+
+	cells, err := uc.coverageRepo.GetCells(ctx, query)
+	if err != nil {
+		logger.Error("取得覆蓋格網失敗", zap.Error(err))
+		return nil, errors.New("取得覆蓋地圖失敗")
+	}
+
+	// 依要求的精度合併格網
+	merged := make(map[string]*domain.CoverageCell)
+	for _, cell := range cells {
+		hash := cell.Geohash
+		if len(hash) > precision {
+			hash = hash[:precision]
+		}
+
+		target, exists := merged[hash]
+		if !exists {
+			target = &domain.CoverageCell{
+				Geohash:       hash,
+				CuisineCounts: make(map[string]int),
+				RefreshedAt:   cell.RefreshedAt,
+			}
+			merged[hash] = target
+		}
+
+		target.RestaurantCount += cell.RestaurantCount
+		target.SearchCount += cell.SearchCount
+		target.FailedSearchCount += cell.FailedSearchCount
+		target.ExpandedSearchCount += cell.ExpandedSearchCount
+		for cuisine, count := range cell.CuisineCounts {
+			target.CuisineCounts[cuisine] += count
+		}
+	}
+
+	hashes := make([]string, 0, len(merged))
+	for hash := range merged {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	features := make([]domain.GeoJSONFeature, 0, len(hashes))
+	for _, hash := range hashes {
+		cell := merged[hash]
+		box, ok := geohash.Decode(hash)
+		if !ok {
+			continue
+		}
+
+		features = append(features, domain.NewBoxFeature(box.MinLat, box.MinLng, box.MaxLat, box.MaxLng, map[string]interface{}{
+			"geohash":               cell.Geohash,
+			"restaurant_count":      cell.RestaurantCount,
+			"cuisine_counts":        cell.CuisineCounts,
+			"search_count":          cell.SearchCount,
+			"failed_search_count":   cell.FailedSearchCount,
+			"expanded_search_count": cell.ExpandedSearchCount,
+			"failed_rate":           cell.FailedRate(),
+			"expanded_rate":         cell.ExpandedRate(),
+			"refreshed_at":          cell.RefreshedAt,
+		}))
+	}
+
+	return domain.NewFeatureCollection(features), nil
+}
+
+// RefreshActivity 重新彙總最近幾天（含今日）的遊戲活動熱度
+func (uc *AnalyticsUseCase) RefreshActivity(ctx context.Context) (int, error) {
+	today := startOfDay(time.Now())
+
+	total := 0
+	for i := uc.settings.ActivityRecomputeDays - 1; i >= 0; i-- {
+		count, err := uc.AggregateActivityDay(ctx, today.AddDate(0, 0, -i))
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+
+	return total, nil
+}
+
+// AggregateActivityDay 彙總指定日期的遊戲活動熱度
+func (uc *AnalyticsUseCase) AggregateActivityDay(ctx context.Context, day time.Time) (int, error) {
+	from := startOfDay(day)
+	to := from.AddDate(0, 0, 1)
+
+	activities, err := uc.activityRepo.ListGameActivity(ctx, from, to)
+	if err != nil {
+		logger.Error("讀取遊戲活動失敗", zap.Error(err), zap.Time("day", from))
+		return 0, errors.New("更新遊戲活動熱度失敗")
+	}
+
+	type cellKey struct {
+		kind         domain.ActivityKind
+		restaurantID int
+		geohash      string
+	}
+	counts := make(map[cellKey]int)
+
+	for _, activity := range activities {
+		startHash := activity.StartGeohash
+		if len(startHash) > activityGeohashPrecision {
+			startHash = startHash[:activityGeohashPrecision]
+		}
+
+		if startHash != "" {
+			counts[cellKey{kind: domain.ActivityKindStart, geohash: startHash}]++
+		}
+
+		if activity.ResultRestaurantID == nil {
+			continue
+		}
+
+		winHash := geohash.Encode(activity.ResultLatitude, activity.ResultLongitude, activityGeohashPrecision)
+		counts[cellKey{kind: domain.ActivityKindWin, geohash: winHash}]++
+
+		if startHash != "" {
+			counts[cellKey{kind: domain.ActivityKindOrigin, restaurantID: *activity.ResultRestaurantID, geohash: startHash}]++
+		}
+	}
+
+	cells := make([]domain.ActivityCell, 0, len(counts))
+	for key, count := range counts {
+		cells = append(cells, domain.ActivityCell{
+			ActivityDate: from,
+			Kind:         key.kind,
+			RestaurantID: key.restaurantID,
+			Geohash:      key.geohash,
+			Count:        count,
+		})
+	}
+
+	if err := uc.activityRepo.ReplaceDay(ctx, from, cells); err != nil {
+		logger.Error("寫入遊戲活動熱度失敗", zap.Error(err), zap.Time("day", from))
+		return 0, errors.New("更新遊戲活動熱度失敗")
+	}
+
+	logger.Info("遊戲活動熱度彙總完成",
+		zap.String("date", from.Format("2006-01-02")),
+		zap.Int("game_count", len(activities)),
+		zap.Int("cell_count", len(cells)),
+	)
+	return len(cells), nil
+}
+
+// GetActivityHeatmap 取得遊戲開始位置或獲選餐廳位置的熱度地圖（GeoJSON）
+func (uc *AnalyticsUseCase) GetActivityHeatmap(ctx context.Context, req *domain.HeatmapRequest) (*domain.GeoJSONFeatureCollection, error) {
+	kind := domain.ActivityKind(req.Kind)
+	if kind == "" {
+		kind = domain.ActivityKindStart
+	}
+	if kind != domain.ActivityKindStart && kind != domain.ActivityKindWin {
+		return nil, domain.ErrInvalidActivityKind
+	}
+
+	return uc.activityHeatmap(ctx, req, kind, 0)
+}
+
+// GetRestaurantHeatmap 取得選中指定餐廳的玩家來源熱度地圖（GeoJSON）
+func (uc *AnalyticsUseCase) GetRestaurantHeatmap(ctx context.Context, restaurantID int, req *domain.HeatmapRequest) (*domain.GeoJSONFeatureCollection, error) {
+	return uc.activityHeatmap(ctx, req, domain.ActivityKindOrigin, restaurantID)
+}
+
+// activityHeatmap 查詢熱度格網，依精度合併並隱藏數量過低的格網
+func (uc *AnalyticsUseCase) activityHeatmap(ctx context.Context, req *domain.HeatmapRequest, kind domain.ActivityKind, restaurantID int) (*domain.GeoJSONFeatureCollection, error) {
+	from, to, err := heatmapDateRange(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	precision := req.Precision
+	if precision <= 0 || precision > activityGeohashPrecision {
+		precision = activityGeohashPrecision
+	}
+
+	cells, err := uc.activityRepo.GetCells(ctx, &domain.ActivityQuery{
+		From:         from,
+		To:           to,
+		Kind:         kind,
+		RestaurantID: restaurantID,
+	})
+	if err != nil {
+		logger.Error("取得遊戲活動熱度失敗", zap.Error(err), zap.String("kind", string(kind)))
+		return nil, errors.New("取得熱度地圖失敗")
+	}
+
+	// 依要求的精度合併格網
+	merged := make(map[string]int)
+	for _, cell := range cells {
+		hash := cell.Geohash
+		if len(hash) > precision {
+			hash = hash[:precision]
+		}
+		merged[hash] += cell.Count
+	}
+
+	hashes := make([]string, 0, len(merged))
+	for hash, count := range merged {
+		// 數量過低的格網可能識別出個別使用者，不予回傳
+		if count < uc.settings.HeatmapMinCellCount {
+			continue
+		}
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+
+	features := make([]domain.GeoJSONFeature, 0, len(hashes))
+	for _, hash := range hashes {
+		box, ok := geohash.Decode(hash)
+		if !ok {
+			continue
+		}
+
+		if req.HasBounds() {
+			lat, lng := box.Center()
+			if lat < req.MinLat || lat > req.MaxLat || lng < req.MinLng || lng > req.MaxLng {
+				continue
+			}
+		}
+
+		features = append(features, domain.NewBoxFeature(box.MinLat, box.MinLng, box.MaxLat, box.MaxLng, map[string]interface{}{
+			"geohash": hash,
+			"kind":    kind,
+			"count":   merged[hash],
+		}))
+	}
+
+	return domain.NewFeatureCollection(features), nil
+}
+
+// heatmapDateRange 將請求的日期轉換為查詢區間（伺服器時區，結束日期不含）
+func heatmapDateRange(req *domain.HeatmapRequest, now time.Time) (time.Time, time.Time, error) {
+	to := startOfDay(now).AddDate(0, 0, 1)
+	if req.To != "" {
+		date, err := time.ParseInLocation("2006-01-02", req.To, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, domain.ErrInvalidDateRange
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -defaultHeatmapDays)
+	if req.From != "" {
+		date, err := time.ParseInLocation("2006-01-02", req.From, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, domain.ErrInvalidDateRange
+		}
+		from = date
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxHeatmapDays*24*time.Hour {
+		return time.Time{}, time.Time{}, domain.ErrInvalidDateRange
+	}
+
+	return from, to, nil
+}
+
+// startOfDay 取得伺服器時區當日的零時
+func startOfDay(t time.Time) time.Time {
+	local := t.In(time.Local)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// APIBudget 外部 API 每日與每月請求額度，0 表示不限
+type APIBudget struct {
+	Daily   int
+	Monthly int
+}
+
+// CircuitStateReporter 回報外部 API 斷路器狀態
+type CircuitStateReporter interface {
+	CircuitState() string
+}
+
+// APIUsageUseCase 外部 API 用量與額度控管業務邏輯
+type APIUsageUseCase struct {
+	usageRepo APIUsageRepository
+	budgets   map[string]APIBudget
+
+	mu             sync.Mutex
+	exhaustedUntil map[string]time.Time // 額度用盡的資料來源與下一個統計週期的開始時間
+	circuits       map[string]CircuitStateReporter
+	now            func() time.Time
+}
+
+// NewAPIUsageUseCase 建立外部 API 用量用例，budgets 以資料來源名稱為鍵
+func NewAPIUsageUseCase(usageRepo APIUsageRepository, budgets map[string]APIBudget) *APIUsageUseCase {
+	return &APIUsageUseCase{
+		usageRepo:      usageRepo,
+		budgets:        budgets,
+		exhaustedUntil: make(map[string]time.Time),
+		circuits:       make(map[string]CircuitStateReporter),
+		now:            time.Now,
+	}
+}
+
+// Reserve 記錄一次外部 API 請求，超過每日或每月額度時回傳 domain.ErrAPIQuotaExceeded
+// 額度用盡後直到下一個統計週期前不再查詢資料庫；用量無法寫入時不阻擋請求
+func (uc *APIUsageUseCase) Reserve(ctx context.Context, provider string) error {
+	now := uc.now().UTC()
+
+	uc.mu.Lock()
+	until, exhausted := uc.exhaustedUntil[provider]
+	if exhausted && now.Before(until) {
+		uc.mu.Unlock()
+		return domain.ErrAPIQuotaExceeded
+	}
+	delete(uc.exhaustedUntil, provider)
+	uc.mu.Unlock()
+
+	day, month := usagePeriods(now)
+	budget := uc.budgets[provider]
+	ok, err := uc.usageRepo.Reserve(ctx, provider, day, month, budget.Daily, budget.Monthly)
+	if err != nil {
+		logger.Warn("記錄外部 API 用量失敗，略過額度檢查", zap.Error(err), zap.String("provider", provider))
+		return nil
+	}
+	if ok {
+		return nil
+	}
+
+	// 無法得知是每日或每月額度用盡，先暫停至隔天，屆時仍超過每月額度會再次暫停
+	uc.mu.Lock()
+	uc.exhaustedUntil[provider] = day.AddDate(0, 0, 1)
+	uc.mu.Unlock()
+
+	logger.Warn("外部 API 請求額度已用盡",
+		zap.String("provider", provider),
+		zap.Int("daily_budget", budget.Daily),
+		zap.Int("monthly_budget", budget.Monthly),
+	)
+	return domain.ErrAPIQuotaExceeded
+}
+
+// WatchCircuit 登記資料來源的斷路器，用量查詢時一併回報狀態
+func (uc *APIUsageUseCase) WatchCircuit(provider string, reporter CircuitStateReporter) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.circuits[provider] = reporter
+}
+
+// GetUsage 取得設定額度或登記斷路器的資料來源目前用量
+func (uc *APIUsageUseCase) GetUsage(ctx context.Context) ([]domain.APIUsage, error) {
+	now := uc.now().UTC()
+	day, month := usagePeriods(now)
+
+	uc.mu.Lock()
+	providerSet := make(map[string]bool)
+	for provider := range uc.budgets {
+		providerSet[provider] = true
+	}
+	for provider := range uc.circuits {
+		providerSet[provider] = true
+	}
+	uc.mu.Unlock()
+
+	providers := make([]string, 0, len(providerSet))
+	for provider := range providerSet {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	usages := make([]domain.APIUsage, 0, len(providers))
+	for _, provider := range providers {
+		daily, monthly, err := uc.usageRepo.GetCounts(ctx, provider, day, month)
+		if err != nil {
+			return nil, err
+		}
+
+		budget := uc.budgets[provider]
+		usage := domain.APIUsage{
+			Provider:      provider,
+			Day:           day.Format("2006-01-02"),
+			DailyCount:    daily,
+			DailyBudget:   budget.Daily,
+			Month:         month.Format("2006-01"),
+			MonthlyCount:  monthly,
+			MonthlyBudget: budget.Monthly,
+			Exhausted:     (budget.Daily > 0 && daily >= budget.Daily) || (budget.Monthly > 0 && monthly >= budget.Monthly),
+		}
+
+		uc.mu.Lock()
+		if reporter, exists := uc.circuits[provider]; exists {
+			usage.CircuitState = reporter.CircuitState()
+		}
+		uc.mu.Unlock()
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// usagePeriods 取得時間所屬的統計日與統計月（UTC）
+func usagePeriods(now time.Time) (time.Time, time.Time) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return day, month
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxClosureDays 單筆休業記錄允許的最大天數
+	maxClosureDays = 366
+	// closureReportReason 使用者回報自動建立的休業原因
+	closureReportReason = "使用者回報今日休業"
+)
+
+// ClosureUseCase 餐廳休業業務邏輯
+type ClosureUseCase struct {
+	closureRepo     ClosureRepository
+	restaurantRepo  RestaurantRepository
+	reportThreshold int
+}
+
+// NewClosureUseCase 建立餐廳休業用例
+func NewClosureUseCase(closureRepo ClosureRepository, restaurantRepo RestaurantRepository, reportThreshold int) *ClosureUseCase {
+	if reportThreshold <= 0 {
+		reportThreshold = 1
+	}
+
+	return &ClosureUseCase{
+		closureRepo:     closureRepo,
+		restaurantRepo:  restaurantRepo,
+		reportThreshold: reportThreshold,
+	}
+}
+
+// ListClosures 取得餐廳今天起仍有效的休業記錄
+func (uc *ClosureUseCase) ListClosures(ctx context.Context, restaurantID int) ([]domain.RestaurantClosure, error) {
+	// 以前一天為起點，避免不同時區的餐廳漏掉當日休業
+	fromDate := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	closures, err := uc.closureRepo.ListByRestaurant(ctx, restaurantID, fromDate)
+	if err != nil {
+		logger.Error("取得休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("取得休業記錄失敗")
+	}
+
+	return closures, nil
+}
+
+// CreateClosure 建立休業記錄（管理功能）
+func (uc *ClosureUseCase) CreateClosure(ctx context.Context, userID, restaurantID int, req *domain.CreateClosureRequest) (*domain.RestaurantClosure, error) {
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return nil, domain.ErrInvalidClosureDate
+	}
+
+	endDate := startDate
+	if req.EndDate != "" {
+		endDate, err = time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, domain.ErrInvalidClosureDate
+		}
+	}
+
+	if endDate.Before(startDate) || endDate.Sub(startDate) >= maxClosureDays*24*time.Hour {
+		return nil, domain.ErrInvalidClosureDate
+	}
+
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	closure := &domain.RestaurantClosure{
+		RestaurantID: restaurantID,
+		StartDate:    startDate.Format("2006-01-02"),
+		EndDate:      endDate.Format("2006-01-02"),
+		Reason:       req.Reason,
+		Source:       domain.ClosureSourceAdmin,
+		CreatedBy:    &userID,
+	}
+
+	if err := uc.closureRepo.Create(ctx, closure); err != nil {
+		logger.Error("建立休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("建立休業記錄失敗")
+	}
+
+	return closure, nil
+}
+
+// DeleteClosure 刪除休業記錄（管理功能）
+func (uc *ClosureUseCase) DeleteClosure(ctx context.Context, restaurantID, closureID int) error {
+	if err := uc.closureRepo.Delete(ctx, restaurantID, closureID); err != nil {
+		if errors.Is(err, domain.ErrClosureNotFound) {
+			return err
+		}
+		logger.Error("刪除休業記錄失敗", zap.Error(err), zap.Int("closure_id", closureID))
+		return errors.New("刪除休業記錄失敗")
+	}
+
+	return nil
+}
+
+// ReportClosed 使用者回報餐廳今日休業，回報人數達門檻時自動建立當日休業記錄
+func (uc *ClosureUseCase) ReportClosed(ctx context.Context, userID, restaurantID int) (*domain.ClosureReportResult, error) {
+	reportDate, count, err := uc.closureRepo.AddReport(ctx, restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			return nil, err
+		}
+		logger.Error("記錄休業回報失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID), zap.Int("user_id", userID))
+		return nil, errors.New("回報休業失敗")
+	}
+
+	result := &domain.ClosureReportResult{
+		RestaurantID: restaurantID,
+		ReportDate:   reportDate,
+		ReportCount:  count,
+	}
+
+	if count < uc.reportThreshold {
+		return result, nil
+	}
+
+	closure := &domain.RestaurantClosure{
+		RestaurantID: restaurantID,
+		StartDate:    reportDate,
+		EndDate:      reportDate,
+		Reason:       closureReportReason,
+		Source:       domain.ClosureSourceUserReport,
+	}
+	created, err := uc.closureRepo.CreateIfUncovered(ctx, closure)
+	if err != nil {
+		logger.Warn("建立回報休業記錄失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return result, nil
+	}
+	if !created {
+		// 當天已有休業記錄，或同時達門檻的其他回報已建立
+		return result, nil
+	}
+
+	logger.Info("使用者回報達門檻，已標記餐廳休業",
+		zap.Int("restaurant_id", restaurantID),
+		zap.String("date", reportDate),
+		zap.Int("report_count", count),
+	)
+
+	result.Closure = closure
+	return result, nil
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// cuisineTaxonomyTTL 料理分類樹快取時間，管理端修改時會立即失效
+const cuisineTaxonomyTTL = 5 * time.Minute
+
+// cuisineSlugPattern 料理分類識別碼格式：小寫英數字與底線
+var cuisineSlugPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// CuisineUseCase 料理分類業務邏輯，分類樹載入後快取於記憶體
+type CuisineUseCase struct {
+	cuisineRepo CuisineRepository
+
+	mu       sync.RWMutex
+	taxonomy *domain.CuisineTaxonomy
+	loadedAt time.Time
+}
+
+// NewCuisineUseCase 建立料理分類用例
+func NewCuisineUseCase(cuisineRepo CuisineRepository) *CuisineUseCase {
+	return &CuisineUseCase{
+		cuisineRepo: cuisineRepo,
+	}
+}
+
+// Taxonomy 取得料理分類樹，快取過期時重新載入
+func (uc *CuisineUseCase) Taxonomy(ctx context.Context) (*domain.CuisineTaxonomy, error) {
+	uc.mu.RLock()
+	taxonomy, loadedAt := uc.taxonomy, uc.loadedAt
+	uc.mu.RUnlock()
+
+	if taxonomy != nil && time.Since(loadedAt) < cuisineTaxonomyTTL {
+		return taxonomy, nil
+	}
+
+	cuisines, err := uc.cuisineRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	providerTypes, err := uc.cuisineRepo.ListProviderTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	taxonomy = domain.NewCuisineTaxonomy(cuisines, providerTypes)
+
+	uc.mu.Lock()
+	uc.taxonomy = taxonomy
+	uc.loadedAt = time.Now()
+	uc.mu.Unlock()
+
+	return taxonomy, nil
+}
+
+// ListCuisines 取得料理分類樹（依請求語系顯示名稱）
+func (uc *CuisineUseCase) ListCuisines(ctx context.Context) ([]domain.Cuisine, error) {
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Error("取得料理分類失敗", zap.Error(err))
+		return nil, errors.New("取得料理分類失敗")
+	}
+
+	return taxonomy.Tree(domain.LocaleFromContext(ctx)), nil
+}
+
+// CreateCuisine 建立料理分類（管理功能）
+func (uc *CuisineUseCase) CreateCuisine(ctx context.Context, req *domain.CreateCuisineRequest) (*domain.Cuisine, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !cuisineSlugPattern.MatchString(slug) {
+		return nil, domain.ErrInvalidCuisineSlug
+	}
+
+	if err := uc.validateCuisine(ctx, 0, req.ParentID, req.Names); err != nil {
+		return nil, err
+	}
+
+	cuisine := &domain.Cuisine{
+		Slug:     slug,
+		ParentID: req.ParentID,
+		Names:    req.Names,
+	}
+
+	if err := uc.cuisineRepo.Create(ctx, cuisine); err != nil {
+		if errors.Is(err, domain.ErrCuisineExists) {
+			return nil, err
+		}
+		logger.Error("建立料理分類失敗", zap.Error(err), zap.String("slug", slug))
+		return nil, errors.New("建立料理分類失敗")
+	}
+
+	uc.invalidate()
+	cuisine.Name = uc.localName(ctx, cuisine)
+	return cuisine, nil
+}
+
+// UpdateCuisine 更新料理分類的上層分類與名稱（管理功能）
+func (uc *CuisineUseCase) UpdateCuisine(ctx context.Context, id int, req *domain.UpdateCuisineRequest) (*domain.Cuisine, error) {
+	if err := uc.validateCuisine(ctx, id, req.ParentID, req.Names); err != nil {
+		return nil, err
+	}
+
+	cuisine := &domain.Cuisine{
+		ID:       id,
+		ParentID: req.ParentID,
+		Names:    req.Names,
+	}
+
+	if err := uc.cuisineRepo.Update(ctx, cuisine); err != nil {
+		if errors.Is(err, domain.ErrCuisineNotFound) {
+			return nil, err
+		}
+		logger.Error("更新料理分類失敗", zap.Error(err), zap.Int("cuisine_id", id))
+		return nil, errors.New("更新料理分類失敗")
+	}
+
+	uc.invalidate()
+	cuisine.Name = uc.localName(ctx, cuisine)
+	return cuisine, nil
+}
+
+// SetProviderType 設定外部地點類型對應的料理分類（管理功能）
+func (uc *CuisineUseCase) SetProviderType(ctx context.Context, mapping *domain.CuisineProviderType) error {
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Error("取得料理分類失敗", zap.Error(err))
+		return errors.New("設定地點類型對應失敗")
+	}
+	if _, ok := taxonomy.Get(mapping.CuisineID); !ok {
+		return domain.ErrCuisineNotFound
+	}
+
+	if err := uc.cuisineRepo.SetProviderType(ctx, mapping); err != nil {
+		logger.Error("設定地點類型對應失敗", zap.Error(err))
+		return errors.New("設定地點類型對應失敗")
+	}
+
+	uc.invalidate()
+	return nil
+}
+
+// DeleteProviderType 刪除外部地點類型對應（管理功能）
+func (uc *CuisineUseCase) DeleteProviderType(ctx context.Context, provider, providerType string) error {
+	if err := uc.cuisineRepo.DeleteProviderType(ctx, provider, providerType); err != nil {
+		if errors.Is(err, domain.ErrCuisineNotFound) {
+			return err
+		}
+		logger.Error("刪除地點類型對應失敗", zap.Error(err))
+		return errors.New("刪除地點類型對應失敗")
+	}
+
+	uc.invalidate()
+	return nil
+}
+
+// ResolveFilter 將料理類型篩選條件（ID、識別碼或名稱）展開為包含子分類的 ID
+func (uc *CuisineUseCase) ResolveFilter(ctx context.Context, value string) ([]int, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Error("取得料理分類失敗", zap.Error(err))
+		return nil, errors.New("取得料理分類失敗")
+	}
+
+	cuisine, ok := taxonomy.Resolve(value)
+	if !ok {
+		return nil, domain.ErrInvalidCuisine
+	}
+
+	return taxonomy.DescendantIDs(cuisine.ID), nil
+}
+
+// MatchQuery 查詢字串恰好為料理分類名稱或識別碼時，回傳包含子分類的 ID（供文字搜尋提高相關度）
+func (uc *CuisineUseCase) MatchQuery(ctx context.Context, query string) []int {
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過料理分類比對", zap.Error(err))
+		return nil
+	}
+
+	// 純數字視為餐廳名稱的一部分，不當作料理分類 ID
+	if _, err := strconv.Atoi(strings.TrimSpace(query)); err == nil {
+		return nil
+	}
+
+	cuisine, ok := taxonomy.Resolve(query)
+	if !ok {
+		return nil
+	}
+	return taxonomy.DescendantIDs(cuisine.ID)
+}
+
+// SuggestCuisines 依輸入文字建議料理分類（名稱依請求語系顯示）
+func (uc *CuisineUseCase) SuggestCuisines(ctx context.Context, query string, limit int) []domain.CuisineSuggestion {
+	suggestions := []domain.CuisineSuggestion{}
+
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過料理分類建議", zap.Error(err))
+		return suggestions
+	}
+
+	for _, cuisine := range taxonomy.Suggest(query, domain.LocaleFromContext(ctx), limit) {
+		suggestions = append(suggestions, domain.CuisineSuggestion{
+			ID:   cuisine.ID,
+			Slug: cuisine.Slug,
+			Name: cuisine.Name,
+		})
+	}
+	return suggestions
+}
+
+// AssignCuisine 為尚未分類的餐廳指定料理分類：優先使用外部地點類型對應，其次以名稱比對
+func (uc *CuisineUseCase) AssignCuisine(ctx context.Context, restaurant *domain.Restaurant, provider string) {
+	if restaurant.CuisineID != nil {
+		return
+	}
+
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過料理分類對應", zap.Error(err))
+		return
+	}
+
+	if len(restaurant.ProviderTypes) > 0 {
+		restaurant.CuisineID = taxonomy.MatchProviderTypes(provider, restaurant.ProviderTypes)
+	}
+	if restaurant.CuisineID == nil && restaurant.Cuisine != "" {
+		if cuisine, ok := taxonomy.Resolve(restaurant.Cuisine); ok {
+			id := cuisine.ID
+			restaurant.CuisineID = &id
+		}
+	}
+}
+
+// LocalizeRestaurants 依請求語系填入餐廳的料理分類名稱
+func (uc *CuisineUseCase) LocalizeRestaurants(ctx context.Context, restaurants ...*domain.Restaurant) {
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過名稱翻譯", zap.Error(err))
+		return
+	}
+
+	locale := domain.LocaleFromContext(ctx)
+	for _, restaurant := range restaurants {
+		if restaurant != nil {
+			restaurant.Cuisine = taxonomy.Name(restaurant.CuisineID, locale)
+		}
+	}
+}
+
+// LocalizeFavorites 依請求語系填入最愛餐廳的料理分類名稱
+func (uc *CuisineUseCase) LocalizeFavorites(ctx context.Context, favorites []domain.FavoriteRestaurantWithDetails) {
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過名稱翻譯", zap.Error(err))
+		return
+	}
+
+	locale := domain.LocaleFromContext(ctx)
+	for i := range favorites {
+		favorites[i].RestaurantCuisine = taxonomy.Name(favorites[i].RestaurantCuisineID, locale)
+	}
+}
+
+// LocalizeNearby 依請求語系填入附近餐廳的料理分類名稱
+func (uc *CuisineUseCase) LocalizeNearby(ctx context.Context, restaurants []domain.RestaurantWithDistance) {
+	targets := make([]*domain.Restaurant, len(restaurants))
+	for i := range restaurants {
+		targets[i] = &restaurants[i].Restaurant
+	}
+	uc.LocalizeRestaurants(ctx, targets...)
+}
+
+// CuisineName 取得料理分類在請求語系的名稱，未分類時回傳「餐廳」
+func (uc *CuisineUseCase) CuisineName(ctx context.Context, id *int) string {
+	locale := domain.LocaleFromContext(ctx)
+	if id == nil {
+		return domain.UncategorizedCuisineName(locale)
+	}
+
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Warn("取得料理分類失敗，略過名稱翻譯", zap.Error(err))
+		return ""
+	}
+
+	return taxonomy.Name(id, locale)
+}
+
+// validateCuisine 檢查名稱須包含預設語系，且上層分類存在並不會形成循環
+func (uc *CuisineUseCase) validateCuisine(ctx context.Context, id int, parentID *int, names map[string]string) error {
+	if strings.TrimSpace(names[domain.DefaultLocale]) == "" {
+		return domain.ErrMissingCuisineName
+	}
+
+	if parentID == nil {
+		return nil
+	}
+
+	taxonomy, err := uc.Taxonomy(ctx)
+	if err != nil {
+		logger.Error("取得料理分類失敗", zap.Error(err))
+		return errors.New("取得料理分類失敗")
+	}
+
+	if _, ok := taxonomy.Get(*parentID); !ok {
+		return domain.ErrInvalidCuisineParent
+	}
+	if id != 0 && taxonomy.IsDescendant(*parentID, id) {
+		return domain.ErrInvalidCuisineParent
+	}
+
+	return nil
+}
+
+// localName 取得料理分類在請求語系的名稱
+func (uc *CuisineUseCase) localName(ctx context.Context, cuisine *domain.Cuisine) string {
+	locale := domain.LocaleFromContext(ctx)
+	if name := cuisine.Names[locale]; name != "" {
+		return name
+	}
+	return cuisine.Names[domain.DefaultLocale]
+}
+
+// invalidate 清除料理分類樹快取
+func (uc *CuisineUseCase) invalidate() {
+	uc.mu.Lock()
+	uc.taxonomy = nil
+	uc.mu.Unlock()
+}
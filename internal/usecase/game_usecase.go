@@ -3,7 +3,6 @@ package usecase
 import (
 	"context"
 	"errors"
-	"math/rand"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,12 +11,34 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// cuisinePreferenceWindow 計算料理偏好時參考的歷史期間
+	cuisinePreferenceWindow = 90 * 24 * time.Hour
+	// gamePoolSize 每次搜尋取得的候選餐廳數量
+	gamePoolSize = 20
+	// maxSearchExpansionSteps 擴大搜尋半徑的次數上限，每一步都會查詢資料庫並可能呼叫外部 API
+	maxSearchExpansionSteps = 10
+)
+
+// GameSettings 遊戲用例設定
+type GameSettings struct {
+	MaxRestaurantsPerRound int                                   // 每場遊戲的候選餐廳上限，0 表示不限制
+	DefaultWeighting       WeightingStrategy                     // 預設抽選權重策略
+	Weighting              map[domain.GameType]WeightingStrategy // 依遊戲類型覆寫的權重策略
+	MaxSearchRadius        int                                   // 找不到餐廳時擴大搜尋的半徑上限（公尺）
+	RadiusExpansionFactor  float64                               // 每次擴大搜尋半徑的倍數
+}
+
 // GameUseCase 遊戲業務邏輯
 type GameUseCase struct {
 	gameRepo       GameRepository
 	restaurantRepo RestaurantRepository
 	favoriteRepo   FavoriteRepository
 	adRepo         AdvertisementRepository
+	searchLogRepo  SearchLogRepository
+	externalAPI    ExternalAPIService
+	cuisineUseCase *CuisineUseCase
+	settings       GameSettings
 }
 
 // NewGameUseCase 建立遊戲用例
@@ -26,12 +47,26 @@ func NewGameUseCase(
 	restaurantRepo RestaurantRepository,
 	favoriteRepo FavoriteRepository,
 	adRepo AdvertisementRepository,
+	searchLogRepo SearchLogRepository,
+	externalAPI ExternalAPIService,
+	cuisineUseCase *CuisineUseCase,
+	settings GameSettings,
 ) *GameUseCase {
+	if settings.DefaultWeighting == nil {
+		settings.DefaultWeighting = UniformWeighting{}
+	}
+	if settings.RadiusExpansionFactor <= 1 {
+		settings.RadiusExpansionFactor = 2
+	}
 	return &GameUseCase{
 		gameRepo:       gameRepo,
 		restaurantRepo: restaurantRepo,
 		favoriteRepo:   favoriteRepo,
 		adRepo:         adRepo,
+		searchLogRepo:  searchLogRepo,
+		externalAPI:    externalAPI,
+		cuisineUseCase: cuisineUseCase,
+		settings:       settings,
 	}
 }
 
@@ -40,15 +75,15 @@ func (uc *GameUseCase) StartGame(ctx context.Context, userID int, req *domain.St
 	// 產生遊戲會話 ID
 	sessionID := uuid.New().String()
 
-	// 取得附近餐廳
-	searchParams := &domain.RestaurantSearchParams{
-		Latitude:  req.Latitude,
-		Longitude: req.Longitude,
-		Radius:    req.Radius,
-		Limit:     20, // 最多 20 間餐廳參與遊戲
+	// 解析營業時間篩選條件
+	openTime, err := domain.ResolveOpenTime(req.OpenNow, req.OpenAt, time.Now())
+	if err != nil {
+		return nil, err
 	}
 
-	nearbyRestaurants, err := uc.restaurantRepo.SearchNearby(ctx, searchParams)
+	// 取得附近餐廳，找不到時逐步擴大搜尋半徑
+	nearbyRestaurants, searchLog, err := uc.searchWithExpansion(ctx, req, openTime)
+	recordSearchLog(ctx, uc.searchLogRepo, searchLog)
 	if err != nil {
 		logger.Error("搜尋附近餐廳失敗", zap.Error(err))
 		return nil, errors.New("搜尋餐廳失敗")
@@ -68,6 +103,11 @@ func (uc *GameUseCase) StartGame(ctx context.Context, userID int, req *domain.St
 		return nil, errors.New("附近沒有找到餐廳")
 	}
 
+	// 依權重策略抽選參與遊戲的餐廳
+	strategy := uc.weightingFor(req.GameType)
+	weights := strategy.Weigh(restaurants, uc.weightingInput(ctx, userID, restaurants))
+	restaurants = weightedDraw(restaurants, weights, uc.settings.MaxRestaurantsPerRound)
+
 	// 取得活躍廣告
 	advertisements, err := uc.adRepo.GetActiveAds(ctx, 3) // 最多 3 個廣告
 	if err != nil {
@@ -83,8 +123,22 @@ func (uc *GameUseCase) StartGame(ctx context.Context, userID int, req *domain.St
 		Status:         "playing",
 		Restaurants:    restaurants,
 		Advertisements: advertisements,
-		StartedAt:      time.Now(),
-		CreatedAt:      time.Now(),
+		Search: &domain.SearchSummary{
+			RequestedRadius: searchLog.RequestedRadius,
+			FinalRadius:     searchLog.FinalRadius,
+			Expansions:      searchLog.ExpansionCount(),
+			ExternalUsed:    searchLog.ExternalUsed,
+		},
+		StartGeohash: searchLog.Geohash,
+		StartedAt:    time.Now(),
+		CreatedAt:    time.Now(),
+	}
+
+	if req.Debug {
+		session.Debug = &domain.GameDebugInfo{
+			Strategy: strategy.Name(),
+			Weights:  weights,
+		}
 	}
 
 	if err := uc.gameRepo.CreateSession(ctx, session); err != nil {
@@ -95,6 +149,8 @@ func (uc *GameUseCase) StartGame(ctx context.Context, userID int, req *domain.St
 	// 記錄廣告瀏覽
 	uc.recordAdViews(ctx, userID, sessionID, advertisements)
 
+	uc.cuisineUseCase.LocalizeNearby(ctx, session.Restaurants)
+
 	logger.Info("遊戲開始", 
 		zap.String("session_id", sessionID),
 		zap.Int("user_id", userID),
@@ -158,10 +214,24 @@ func (uc *GameUseCase) CompleteGame(ctx context.Context, userID int, req *domain
 		}
 	}
 
+	// 記錄被否決的餐廳（僅限遊戲列表中且非最終選擇）
+	for _, vetoedID := range req.VetoedRestaurantIDs {
+		if vetoedID == req.SelectedRestaurantID {
+			continue
+		}
+		for _, restaurant := range session.Restaurants {
+			if restaurant.ID == vetoedID {
+				session.VetoedIDs = append(session.VetoedIDs, vetoedID)
+				break
+			}
+		}
+	}
+
 	// 更新遊戲會話
 	completedAt := time.Now()
 	session.Status = "completed"
 	session.Result = selectedRestaurant
+	session.ResultRestaurantID = &selectedRestaurant.ID
 	session.CompletedAt = &completedAt
 
 	if err := uc.gameRepo.UpdateSession(ctx, session); err != nil {
@@ -175,6 +245,7 @@ func (uc *GameUseCase) CompleteGame(ctx context.Context, userID int, req *domain
 		ClickedAd:          clickedAd,
 		CompletedAt:        completedAt,
 	}
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, &selectedRestaurant.Restaurant)
 
 	logger.Info("遊戲完成",
 		zap.String("session_id", req.SessionID),
@@ -194,6 +265,13 @@ func (uc *GameUseCase) GetGameHistory(ctx context.Context, userID int, limit, of
 		return nil, errors.New("取得遊戲歷史失敗")
 	}
 
+	for i := range sessions {
+		uc.cuisineUseCase.LocalizeNearby(ctx, sessions[i].Restaurants)
+		if sessions[i].Result != nil {
+			uc.cuisineUseCase.LocalizeRestaurants(ctx, &sessions[i].Result.Restaurant)
+		}
+	}
+
 	return sessions, nil
 }
 
@@ -214,20 +292,142 @@ func (uc *GameUseCase) mergeRestaurants(nearby []domain.RestaurantWithDistance,
 		}
 	}
 
-	// 轉換為切片並隨機排序
+	// 轉換為切片，順序由權重抽選決定
 	restaurants := make([]domain.RestaurantWithDistance, 0, len(restaurantMap))
 	for _, restaurant := range restaurantMap {
 		restaurants = append(restaurants, restaurant)
 	}
 
-	// 隨機打亂順序
-	rand.Shuffle(len(restaurants), func(i, j int) {
-		restaurants[i], restaurants[j] = restaurants[j], restaurants[i]
-	})
-
 	return restaurants
 }
 
+// searchWithExpansion 搜尋附近餐廳，找不到時以倍數擴大半徑並嘗試外部 API，直到半徑上限
+func (uc *GameUseCase) searchWithExpansion(ctx context.Context, req *domain.StartGameRequest, openTime *time.Time) ([]domain.RestaurantWithDistance, *domain.SearchLog, error) {
+	searchLog := newSearchLog(domain.SearchSourceGame, req.Latitude, req.Longitude, req.Radius)
+	hash := searchLog.Geohash
+
+	radius := req.Radius
+	if radius < 1 {
+		radius = 1
+	}
+	maxRadius := uc.settings.MaxSearchRadius
+	if maxRadius < radius {
+		maxRadius = radius
+	}
+
+	for step := 0; ; step++ {
+		params := &domain.RestaurantSearchParams{
+			Latitude:      req.Latitude,
+			Longitude:     req.Longitude,
+			Radius:        radius,
+			Limit:         gamePoolSize,
+			OpenTime:      openTime,
+			ExcludeClosed: true,
+			TagsAll:       domain.NormalizeTagSlugs(req.TagsAll),
+			TagsAny:       domain.NormalizeTagSlugs(req.TagsAny),
+		}
+
+		restaurants, err := uc.restaurantRepo.SearchNearby(ctx, params)
+		if err != nil {
+			return nil, searchLog, err
+		}
+
+		expansionStep := domain.SearchExpansionStep{
+			Step:       step,
+			Radius:     radius,
+			LocalCount: len(restaurants),
+		}
+
+		// 本地沒有餐廳時從外部 API 補充
+		if len(restaurants) == 0 && uc.externalAPI != nil {
+			expansionStep.ExternalCount = uc.importExternalRestaurants(ctx, params)
+			if expansionStep.ExternalCount > 0 {
+				searchLog.ExternalUsed = true
+				restaurants, err = uc.restaurantRepo.SearchNearby(ctx, params)
+				if err != nil {
+					return nil, searchLog, err
+				}
+			}
+		}
+
+		searchLog.Steps = append(searchLog.Steps, expansionStep)
+		searchLog.FinalRadius = radius
+		searchLog.ResultCount = len(restaurants)
+
+		if len(restaurants) > 0 || radius >= maxRadius || step >= maxSearchExpansionSteps {
+			searchLog.Succeeded = len(restaurants) > 0
+			return restaurants, searchLog, nil
+		}
+
+		// 每次至少擴大 1 公尺，避免半徑過小或倍數接近 1 時無法到達上限
+		next := int(float64(radius) * uc.settings.RadiusExpansionFactor)
+		if next <= radius {
+			next = radius + 1
+		}
+		radius = next
+		if radius > maxRadius {
+			radius = maxRadius
+		}
+
+		logger.Info("附近沒有餐廳，擴大搜尋半徑",
+			zap.String("geohash", hash),
+			zap.Int("step", step+1),
+			zap.Int("radius", radius),
+		)
+	}
+}
+
+// importExternalRestaurants 從外部 API 匯入餐廳，回傳新增或更新的數量
+func (uc *GameUseCase) importExternalRestaurants(ctx context.Context, params *domain.RestaurantSearchParams) int {
+	externalRestaurants, err := uc.externalAPI.SearchNearbyRestaurants(ctx, params.Latitude, params.Longitude, params.Radius)
+	if err != nil {
+		logger.Warn("從外部 API 搜尋餐廳失敗", zap.Error(err), zap.Int("radius", params.Radius))
+		return 0
+	}
+
+	summary := importProviderRestaurants(ctx, uc.restaurantRepo, uc.cuisineUseCase, externalRestaurants, domain.CuisineProviderGoogle)
+	return summary.Imported()
+}
+
+// weightingFor 取得遊戲類型對應的權重策略
+func (uc *GameUseCase) weightingFor(gameType domain.GameType) WeightingStrategy {
+	if strategy, exists := uc.settings.Weighting[gameType]; exists && strategy != nil {
+		return strategy
+	}
+	return uc.settings.DefaultWeighting
+}
+
+// weightingInput 取得計算權重所需的使用者歷史，失敗時以空白歷史繼續遊戲
+func (uc *GameUseCase) weightingInput(ctx context.Context, userID int, restaurants []domain.RestaurantWithDistance) *WeightingInput {
+	now := time.Now()
+	input := &WeightingInput{
+		History:           map[int]domain.RestaurantHistory{},
+		CuisinePreference: map[int]float64{},
+		Now:               now,
+	}
+
+	restaurantIDs := make([]int, len(restaurants))
+	for i, restaurant := range restaurants {
+		restaurantIDs[i] = restaurant.ID
+	}
+
+	history, err := uc.gameRepo.GetRestaurantHistory(ctx, userID, restaurantIDs)
+	if err != nil {
+		logger.Warn("取得餐廳遊戲歷史失敗", zap.Error(err), zap.Int("user_id", userID))
+	} else {
+		input.History = history
+	}
+
+	preferences, err := uc.gameRepo.GetCuisinePreferences(ctx, userID, now.Add(-cuisinePreferenceWindow))
+	if err != nil {
+		logger.Warn("取得料理偏好失敗", zap.Error(err), zap.Int("user_id", userID))
+	} else {
+		input.CuisinePreference = preferences
+	}
+
+	return input
+}
+
 // recordAdViews 記錄廣告瀏覽
 func (uc *GameUseCase) recordAdViews(ctx context.Context, userID int, sessionID string, ads []domain.Advertisement) {
 	for _, ad := range ads {
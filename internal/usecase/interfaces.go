@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 )
@@ -21,8 +22,94 @@ type RestaurantRepository interface {
 	Create(ctx context.Context, restaurant *domain.Restaurant) error
 	GetByID(ctx context.Context, id int) (*domain.Restaurant, error)
 	SearchNearby(ctx context.Context, params *domain.RestaurantSearchParams) ([]domain.RestaurantWithDistance, error)
+	CountInViewport(ctx context.Context, params *domain.ViewportSearchParams) (int, error)
+	SearchInViewport(ctx context.Context, params *domain.ViewportSearchParams) ([]domain.Restaurant, error)
+	ClusterInViewport(ctx context.Context, params *domain.ViewportSearchParams, cellSize float64) ([]domain.RestaurantCluster, error)
 	Update(ctx context.Context, restaurant *domain.Restaurant) error
 	GetAll(ctx context.Context, limit, offset int) ([]domain.Restaurant, error)
+	UpsertByProviderID(ctx context.Context, restaurant *domain.Restaurant) (domain.ProviderUpsertOutcome, error)
+	ListStaleProviderRestaurants(ctx context.Context, cutoff time.Time, limit int) ([]domain.Restaurant, error)
+	RecordProviderSync(ctx context.Context, id int, status domain.ProviderSyncStatus, syncErr string, at time.Time) error
+	SearchText(ctx context.Context, params *domain.TextSearchParams) ([]domain.TextSearchResult, error)
+	SetAliases(ctx context.Context, restaurantID int, aliases []string) error
+	GetAliases(ctx context.Context, restaurantID int) ([]string, error)
+	ImageURLExists(ctx context.Context, imageURL string) (bool, error)
+}
+
+// OpeningHoursRepository 餐廳營業時間資料庫操作介面
+type OpeningHoursRepository interface {
+	GetByRestaurantID(ctx context.Context, restaurantID int) (*domain.OpeningHours, error)
+	Replace(ctx context.Context, hours *domain.OpeningHours) error
+}
+
+// CuisineRepository 料理分類資料庫操作介面
+type CuisineRepository interface {
+	List(ctx context.Context) ([]domain.Cuisine, error)
+	ListProviderTypes(ctx context.Context) ([]domain.CuisineProviderType, error)
+	Create(ctx context.Context, cuisine *domain.Cuisine) error
+	Update(ctx context.Context, cuisine *domain.Cuisine) error
+	SetProviderType(ctx context.Context, mapping *domain.CuisineProviderType) error
+	DeleteProviderType(ctx context.Context, provider, providerType string) error
+}
+
+// TagRepository 標籤資料庫操作介面
+type TagRepository interface {
+	List(ctx context.Context, category domain.TagCategory) ([]domain.Tag, error)
+	GetByRestaurantID(ctx context.Context, restaurantID int) ([]domain.Tag, error)
+	GetBySlugs(ctx context.Context, slugs []string) ([]domain.Tag, error)
+	Create(ctx context.Context, tag *domain.Tag) error
+	Update(ctx context.Context, tag *domain.Tag) error
+	Delete(ctx context.Context, id int) error
+	SetRestaurantTags(ctx context.Context, restaurantID int, tagIDs []int) error
+}
+
+// RestaurantMergeRepository 重複餐廳偵測與合併資料庫操作介面
+type RestaurantMergeRepository interface {
+	DetectDuplicates(ctx context.Context, settings domain.DuplicateDetectionSettings) (int, error)
+	ListDuplicateCandidates(ctx context.Context, status domain.DuplicateCandidateStatus, limit, offset int) ([]domain.DuplicateCandidate, int, error)
+	DismissDuplicate(ctx context.Context, restaurantID, duplicateID, userID int) error
+	PreviewMerge(ctx context.Context, survivorID, duplicateID int) (*domain.RestaurantMergePreview, error)
+	Merge(ctx context.Context, survivorID, duplicateID, userID int) (*domain.RestaurantMerge, error)
+	UndoMerge(ctx context.Context, mergeID, userID int) (*domain.RestaurantMerge, error)
+	ListMerges(ctx context.Context, limit, offset int) ([]domain.RestaurantMerge, error)
+}
+
+// ReviewRepository 評論資料庫操作介面
+type ReviewRepository interface {
+	Upsert(ctx context.Context, review *domain.Review) (bool, error)
+	GetByID(ctx context.Context, id int) (*domain.Review, error)
+	ListByRestaurant(ctx context.Context, restaurantID, limit, offset int) ([]domain.Review, int, error)
+	Delete(ctx context.Context, restaurantID, userID int) error
+	Flag(ctx context.Context, reviewID, userID int, reason string) error
+	ListFlagged(ctx context.Context, limit, offset int) ([]domain.Review, int, error)
+	Moderate(ctx context.Context, reviewID, moderatorID int, status domain.ReviewStatus, note string) error
+}
+
+// MenuRepository 餐廳菜單資料庫操作介面
+type MenuRepository interface {
+	GetMenu(ctx context.Context, restaurantID int) (*domain.Menu, error)
+	GetSection(ctx context.Context, id int) (*domain.MenuSection, error)
+	CreateSection(ctx context.Context, section *domain.MenuSection) error
+	UpdateSection(ctx context.Context, section *domain.MenuSection) error
+	DeleteSection(ctx context.Context, id int) (int, error)
+	GetItem(ctx context.Context, id int) (*domain.MenuItem, error)
+	CreateItem(ctx context.Context, item *domain.MenuItem, tagIDs []int) error
+	UpdateItem(ctx context.Context, item *domain.MenuItem, tagIDs []int) error
+	DeleteItem(ctx context.Context, id int) (int, error)
+	ReplaceMenu(ctx context.Context, restaurantID int, sections []domain.MenuSection, items []domain.MenuItem, tagIDs map[string]int) error
+	FindMatchingItems(ctx context.Context, restaurantIDs []int, name string, maxPrice float64, perRestaurant int) (map[int][]domain.MenuItem, error)
+	SetDerivePriceLevel(ctx context.Context, restaurantID int, derive bool) error
+	GetPriceLevelInputs(ctx context.Context, restaurantID int) (bool, []float64, error)
+	UpdatePriceLevel(ctx context.Context, restaurantID, priceLevel int) error
+}
+
+// ClosureRepository 餐廳休業資料庫操作介面
+type ClosureRepository interface {
+	Create(ctx context.Context, closure *domain.RestaurantClosure) error
+	Delete(ctx context.Context, restaurantID, closureID int) error
+	ListByRestaurant(ctx context.Context, restaurantID int, fromDate string) ([]domain.RestaurantClosure, error)
+	CreateIfUncovered(ctx context.Context, closure *domain.RestaurantClosure) (bool, error)
+	AddReport(ctx context.Context, restaurantID, userID int) (string, int, error)
 }
 
 // FavoriteRepository 最愛餐廳資料庫操作介面
@@ -39,6 +126,8 @@ type GameRepository interface {
 	GetSessionByID(ctx context.Context, sessionID string) (*domain.GameSession, error)
 	UpdateSession(ctx context.Context, session *domain.GameSession) error
 	GetUserSessions(ctx context.Context, userID int, limit, offset int) ([]domain.GameSession, error)
+	GetRestaurantHistory(ctx context.Context, userID int, restaurantIDs []int) (map[int]domain.RestaurantHistory, error)
+	GetCuisinePreferences(ctx context.Context, userID int, since time.Time) (map[int]float64, error)
 }
 
 // AdvertisementRepository 廣告資料庫操作介面
@@ -53,12 +142,61 @@ type AdvertisementRepository interface {
 	GetStatistics(ctx context.Context, adID int, period string) (*domain.AdStatistics, error)
 }
 
+// StatsRepository 統計資料查詢介面
+type StatsRepository interface {
+	GetUserStats(ctx context.Context, userID int, params *domain.UserStatsParams) (*domain.UserStats, error)
+}
+
+// SearchLogRepository 搜尋記錄資料庫操作介面
+type SearchLogRepository interface {
+	Create(ctx context.Context, log *domain.SearchLog) error
+}
+
+// CoverageRepository 美食沙漠覆蓋格網資料庫操作介面
+type CoverageRepository interface {
+	ListActiveRestaurantLocations(ctx context.Context, afterID, limit int) ([]domain.RestaurantLocation, error)
+	GetSearchStatsByGeohash(ctx context.Context, since time.Time) (map[string]domain.SearchCellStats, error)
+	ReplaceCells(ctx context.Context, cells []domain.CoverageCell) error
+	GetCells(ctx context.Context, query *domain.CoverageQuery) ([]domain.CoverageCell, error)
+}
+
+// ActivityRepository 遊戲活動熱度資料庫操作介面
+type ActivityRepository interface {
+	ListGameActivity(ctx context.Context, from, to time.Time) ([]domain.GameActivity, error)
+	ReplaceDay(ctx context.Context, day time.Time, cells []domain.ActivityCell) error
+	GetCells(ctx context.Context, query *domain.ActivityQuery) ([]domain.ActivityCell, error)
+}
+
 // ExternalAPIService 外部 API 服務介面
 type ExternalAPIService interface {
 	SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error)
 	GetRestaurantDetails(ctx context.Context, googleID string) (*domain.Restaurant, error)
 }
 
+// APIUsageRepository 外部 API 用量資料庫操作介面
+type APIUsageRepository interface {
+	Reserve(ctx context.Context, provider string, day, month time.Time, dailyBudget, monthlyBudget int) (bool, error)
+	GetCounts(ctx context.Context, provider string, day, month time.Time) (int, int, error)
+}
+
+// PlaceSearchCache 外部地點搜尋結果快取介面，查無資料時回傳 false
+type PlaceSearchCache interface {
+	Get(ctx context.Context, key string) ([]domain.Restaurant, bool, error)
+	Set(ctx context.Context, key string, restaurants []domain.Restaurant, ttl time.Duration) error
+}
+
+// PlacePhotoFetcher 外部資料來源照片下載介面，查無照片時回傳 domain.ErrProviderPlaceNotFound
+type PlacePhotoFetcher interface {
+	FetchPlacePhoto(ctx context.Context, ref string, maxWidth int) (*domain.PlacePhoto, error)
+}
+
+// PlacePhotoCache 餐廳照片快取介面，查無資料時回傳 false
+type PlacePhotoCache interface {
+	Get(ctx context.Context, key string) (*domain.PlacePhoto, bool, error)
+	Set(ctx context.Context, key string, photo *domain.PlacePhoto) error
+	Prune(ctx context.Context, olderThan time.Time) (int, error)
+}
+
 // AuthService 認證服務介面
 type AuthService interface {
 	HashPassword(password string) (string, error)
@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// menuMatchesPerRestaurant 搜尋結果中每間餐廳附帶的符合品項數量上限
+const menuMatchesPerRestaurant = 3
+
+// MenuUseCase 餐廳菜單業務邏輯
+type MenuUseCase struct {
+	menuRepo             MenuRepository
+	tagRepo              TagRepository
+	restaurantRepo       RestaurantRepository
+	priceLevelThresholds []float64
+}
+
+// NewMenuUseCase 建立菜單用例
+func NewMenuUseCase(menuRepo MenuRepository, tagRepo TagRepository, restaurantRepo RestaurantRepository, priceLevelThresholds []float64) *MenuUseCase {
+	return &MenuUseCase{
+		menuRepo:             menuRepo,
+		tagRepo:              tagRepo,
+		restaurantRepo:       restaurantRepo,
+		priceLevelThresholds: priceLevelThresholds,
+	}
+}
+
+// GetMenu 取得餐廳菜單
+func (uc *MenuUseCase) GetMenu(ctx context.Context, restaurantID int) (*domain.Menu, error) {
+	menu, err := uc.menuRepo.GetMenu(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			return nil, err
+		}
+		logger.Error("取得餐廳菜單失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("取得餐廳菜單失敗")
+	}
+
+	return menu, nil
+}
+
+// CreateSection 建立菜單分類（管理功能）
+func (uc *MenuUseCase) CreateSection(ctx context.Context, restaurantID int, req *domain.MenuSectionRequest) (*domain.MenuSection, error) {
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	section := &domain.MenuSection{
+		RestaurantID: restaurantID,
+		Name:         strings.TrimSpace(req.Name),
+		Position:     req.Position,
+		Items:        []domain.MenuItem{},
+	}
+
+	if err := uc.menuRepo.CreateSection(ctx, section); err != nil {
+		logger.Error("建立菜單分類失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("建立菜單分類失敗")
+	}
+
+	return section, nil
+}
+
+// UpdateSection 更新菜單分類（管理功能）
+func (uc *MenuUseCase) UpdateSection(ctx context.Context, id int, req *domain.MenuSectionRequest) (*domain.MenuSection, error) {
+	section := &domain.MenuSection{
+		ID:       id,
+		Name:     strings.TrimSpace(req.Name),
+		Position: req.Position,
+	}
+
+	if err := uc.menuRepo.UpdateSection(ctx, section); err != nil {
+		if errors.Is(err, domain.ErrMenuSectionNotFound) {
+			return nil, err
+		}
+		logger.Error("更新菜單分類失敗", zap.Error(err), zap.Int("section_id", id))
+		return nil, errors.New("更新菜單分類失敗")
+	}
+
+	return section, nil
+}
+
+// DeleteSection 刪除菜單分類，分類內的品項改為未分類（管理功能）
+func (uc *MenuUseCase) DeleteSection(ctx context.Context, id int) error {
+	if _, err := uc.menuRepo.DeleteSection(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrMenuSectionNotFound) {
+			return err
+		}
+		logger.Error("刪除菜單分類失敗", zap.Error(err), zap.Int("section_id", id))
+		return errors.New("刪除菜單分類失敗")
+	}
+
+	return nil
+}
+
+// CreateItem 建立菜單品項（管理功能）
+func (uc *MenuUseCase) CreateItem(ctx context.Context, restaurantID int, req *domain.MenuItemRequest) (*domain.MenuItem, error) {
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	item := newMenuItem(restaurantID, req)
+	if err := uc.checkSection(ctx, item); err != nil {
+		return nil, err
+	}
+
+	tagIDs, err := uc.resolveTags(ctx, item.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.menuRepo.CreateItem(ctx, item, tagIDs); err != nil {
+		logger.Error("建立菜單品項失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("建立菜單品項失敗")
+	}
+
+	uc.refreshPriceLevel(ctx, restaurantID)
+	return item, nil
+}
+
+// UpdateItem 更新菜單品項（管理功能）
+func (uc *MenuUseCase) UpdateItem(ctx context.Context, id int, req *domain.MenuItemRequest) (*domain.MenuItem, error) {
+	existing, err := uc.menuRepo.GetItem(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrMenuItemNotFound) {
+			return nil, err
+		}
+		logger.Error("取得菜單品項失敗", zap.Error(err), zap.Int("item_id", id))
+		return nil, errors.New("更新菜單品項失敗")
+	}
+
+	item := newMenuItem(existing.RestaurantID, req)
+	item.ID = id
+	if err := uc.checkSection(ctx, item); err != nil {
+		return nil, err
+	}
+
+	tagIDs, err := uc.resolveTags(ctx, item.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.menuRepo.UpdateItem(ctx, item, tagIDs); err != nil {
+		if errors.Is(err, domain.ErrMenuItemNotFound) {
+			return nil, err
+		}
+		logger.Error("更新菜單品項失敗", zap.Error(err), zap.Int("item_id", id))
+		return nil, errors.New("更新菜單品項失敗")
+	}
+
+	uc.refreshPriceLevel(ctx, item.RestaurantID)
+	return item, nil
+}
+
+// DeleteItem 刪除菜單品項（管理功能）
+func (uc *MenuUseCase) DeleteItem(ctx context.Context, id int) error {
+	restaurantID, err := uc.menuRepo.DeleteItem(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrMenuItemNotFound) {
+			return err
+		}
+		logger.Error("刪除菜單品項失敗", zap.Error(err), zap.Int("item_id", id))
+		return errors.New("刪除菜單品項失敗")
+	}
+
+	uc.refreshPriceLevel(ctx, restaurantID)
+	return nil
+}
+
+// ImportMenu 批次匯入菜單，取代餐廳原有菜單（管理功能）
+func (uc *MenuUseCase) ImportMenu(ctx context.Context, restaurantID int, menuImport *domain.MenuImport) (*domain.Menu, error) {
+	if menuImport.ItemCount() == 0 {
+		return nil, domain.ErrInvalidMenuImport
+	}
+	if menuImport.ItemCount() > domain.MaxMenuImportItems {
+		return nil, domain.ErrMenuImportTooLarge
+	}
+
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	tagIDs := make(map[string]int)
+	if slugs := menuImport.TagSlugs(); len(slugs) > 0 {
+		tags, err := uc.tagRepo.GetBySlugs(ctx, slugs)
+		if err != nil {
+			logger.Error("取得標籤失敗", zap.Error(err))
+			return nil, errors.New("匯入菜單失敗")
+		}
+		if len(tags) != len(slugs) {
+			return nil, domain.ErrUnknownTag
+		}
+		for _, tag := range tags {
+			tagIDs[tag.Slug] = tag.ID
+		}
+	}
+
+	sections := make([]domain.MenuSection, 0, len(menuImport.Sections))
+	for i, importSection := range menuImport.Sections {
+		section := domain.MenuSection{
+			RestaurantID: restaurantID,
+			Name:         strings.TrimSpace(importSection.Name),
+			Position:     i,
+		}
+		for j := range importSection.Items {
+			item := newMenuItem(restaurantID, &importSection.Items[j])
+			item.Position = j
+			section.Items = append(section.Items, *item)
+		}
+		sections = append(sections, section)
+	}
+
+	items := make([]domain.MenuItem, 0, len(menuImport.Items))
+	for i := range menuImport.Items {
+		item := newMenuItem(restaurantID, &menuImport.Items[i])
+		item.SectionID = nil
+		item.Position = i
+		items = append(items, *item)
+	}
+
+	if err := uc.menuRepo.ReplaceMenu(ctx, restaurantID, sections, items, tagIDs); err != nil {
+		logger.Error("匯入菜單失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("匯入菜單失敗")
+	}
+
+	uc.refreshPriceLevel(ctx, restaurantID)
+	return uc.GetMenu(ctx, restaurantID)
+}
+
+// UpdateSettings 更新菜單設定（管理功能）
+func (uc *MenuUseCase) UpdateSettings(ctx context.Context, restaurantID int, req *domain.MenuSettingsRequest) (*domain.Menu, error) {
+	if err := uc.menuRepo.SetDerivePriceLevel(ctx, restaurantID, req.DerivePriceLevel); err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			return nil, err
+		}
+		logger.Error("更新菜單設定失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("更新菜單設定失敗")
+	}
+
+	uc.refreshPriceLevel(ctx, restaurantID)
+	return uc.GetMenu(ctx, restaurantID)
+}
+
+// AttachMenuMatches 為搜尋結果附上符合菜單條件的品項
+func (uc *MenuUseCase) AttachMenuMatches(ctx context.Context, params *domain.RestaurantSearchParams, restaurants []domain.RestaurantWithDistance) {
+	if !params.HasMenuFilter() || len(restaurants) == 0 {
+		return
+	}
+
+	restaurantIDs := make([]int, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		restaurantIDs = append(restaurantIDs, restaurant.ID)
+	}
+
+	matches, err := uc.menuRepo.FindMatchingItems(ctx, restaurantIDs, params.MenuItem, params.MaxItemPrice, menuMatchesPerRestaurant)
+	if err != nil {
+		logger.Warn("取得符合條件的菜單品項失敗", zap.Error(err))
+		return
+	}
+
+	for i := range restaurants {
+		restaurants[i].MenuMatches = matches[restaurants[i].ID]
+	}
+}
+
+// checkSection 確認品項的分類屬於同一間餐廳
+func (uc *MenuUseCase) checkSection(ctx context.Context, item *domain.MenuItem) error {
+	if item.SectionID == nil {
+		return nil
+	}
+
+	section, err := uc.menuRepo.GetSection(ctx, *item.SectionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMenuSectionNotFound) {
+			return err
+		}
+		logger.Error("取得菜單分類失敗", zap.Error(err), zap.Int("section_id", *item.SectionID))
+		return errors.New("取得菜單分類失敗")
+	}
+	if section.RestaurantID != item.RestaurantID {
+		return domain.ErrMenuSectionNotFound
+	}
+
+	return nil
+}
+
+// resolveTags 將品項標籤識別碼轉換為標籤 ID
+func (uc *MenuUseCase) resolveTags(ctx context.Context, slugs []string) ([]int, error) {
+	if len(slugs) == 0 {
+		return nil, nil
+	}
+
+	tags, err := uc.tagRepo.GetBySlugs(ctx, slugs)
+	if err != nil {
+		logger.Error("取得標籤失敗", zap.Error(err))
+		return nil, errors.New("取得標籤失敗")
+	}
+	if len(tags) != len(slugs) {
+		return nil, domain.ErrUnknownTag
+	}
+
+	tagIDs := make([]int, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagIDs, nil
+}
+
+// refreshPriceLevel 餐廳啟用菜單計算價位時，依供應中品項價格重新計算價位等級
+func (uc *MenuUseCase) refreshPriceLevel(ctx context.Context, restaurantID int) {
+	derive, prices, err := uc.menuRepo.GetPriceLevelInputs(ctx, restaurantID)
+	if err != nil {
+		logger.Warn("取得菜單價格失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return
+	}
+	if !derive {
+		return
+	}
+
+	level := domain.DerivePriceLevel(prices, uc.priceLevelThresholds)
+	if level == 0 {
+		return
+	}
+
+	if err := uc.menuRepo.UpdatePriceLevel(ctx, restaurantID, level); err != nil {
+		logger.Warn("更新餐廳價位等級失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+	}
+}
+
+// newMenuItem 由請求建立菜單品項，套用幣別與供應狀態預設值
+func newMenuItem(restaurantID int, req *domain.MenuItemRequest) *domain.MenuItem {
+	item := &domain.MenuItem{
+		RestaurantID: restaurantID,
+		SectionID:    req.SectionID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  strings.TrimSpace(req.Description),
+		Price:        req.Price,
+		Currency:     strings.ToUpper(req.Currency),
+		ImageURL:     req.ImageURL,
+		IsAvailable:  true,
+		Position:     req.Position,
+		Tags:         domain.NormalizeTagSlugs(req.Tags),
+	}
+	if item.Currency == "" {
+		item.Currency = domain.DefaultMenuCurrency
+	}
+	if req.IsAvailable != nil {
+		item.IsAvailable = *req.IsAvailable
+	}
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
+	return item
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// queryVocabularyTTL 自然語言查詢詞彙表快取時間（飲食標籤異動的最長延遲）
+	queryVocabularyTTL = 5 * time.Minute
+	// defaultNaturalQueryRadius 查詢未指定距離時的預設搜尋半徑（公尺）
+	defaultNaturalQueryRadius = 1000
+	// defaultNaturalQueryLimit 自然語言搜尋的預設結果數量
+	defaultNaturalQueryLimit = 20
+)
+
+// NaturalQueryUseCase 自然語言餐廳搜尋業務邏輯：以規則解析查詢後轉為附近餐廳搜尋
+type NaturalQueryUseCase struct {
+	restaurantUseCase *RestaurantUseCase
+	cuisineUseCase    *CuisineUseCase
+	tagRepo           TagRepository
+
+	mu         sync.RWMutex
+	vocabulary *domain.QueryVocabulary
+	taxonomy   *domain.CuisineTaxonomy
+	loadedAt   time.Time
+}
+
+// NewNaturalQueryUseCase 建立自然語言搜尋用例
+func NewNaturalQueryUseCase(restaurantUseCase *RestaurantUseCase, cuisineUseCase *CuisineUseCase, tagRepo TagRepository) *NaturalQueryUseCase {
+	return &NaturalQueryUseCase{
+		restaurantUseCase: restaurantUseCase,
+		cuisineUseCase:    cuisineUseCase,
+		tagRepo:           tagRepo,
+	}
+}
+
+// Search 解析自然語言查詢並搜尋附近餐廳，回傳解析結果供前端顯示
+func (uc *NaturalQueryUseCase) Search(ctx context.Context, params *domain.NaturalQueryParams) (*domain.NaturalQueryResult, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, domain.ErrInvalidSearchQuery
+	}
+
+	vocabulary, err := uc.Vocabulary(ctx)
+	if err != nil {
+		logger.Error("建立查詢詞彙表失敗", zap.Error(err))
+		return nil, errors.New("搜尋餐廳失敗")
+	}
+
+	interpretation := domain.ParseNaturalQuery(params.Query, vocabulary)
+
+	searchParams := &domain.RestaurantSearchParams{
+		Latitude:  params.Latitude,
+		Longitude: params.Longitude,
+		Radius:    params.Radius,
+		Limit:     params.Limit,
+	}
+	if searchParams.Radius == 0 {
+		searchParams.Radius = defaultNaturalQueryRadius
+	}
+	if searchParams.Limit == 0 {
+		searchParams.Limit = defaultNaturalQueryLimit
+	}
+	interpretation.ApplyTo(searchParams)
+
+	restaurants, err := uc.restaurantUseCase.SearchNearby(ctx, searchParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.NaturalQueryResult{
+		Interpretation: interpretation,
+		Restaurants:    restaurants,
+		Count:          len(restaurants),
+	}, nil
+}
+
+// Vocabulary 取得查詢詞彙表，料理分類樹更新或快取過期時重新建立
+func (uc *NaturalQueryUseCase) Vocabulary(ctx context.Context) (*domain.QueryVocabulary, error) {
+	taxonomy, err := uc.cuisineUseCase.Taxonomy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.mu.RLock()
+	vocabulary, cachedTaxonomy, loadedAt := uc.vocabulary, uc.taxonomy, uc.loadedAt
+	uc.mu.RUnlock()
+
+	if vocabulary != nil && cachedTaxonomy == taxonomy && time.Since(loadedAt) < queryVocabularyTTL {
+		return vocabulary, nil
+	}
+
+	tags, err := uc.tagRepo.List(ctx, domain.TagCategoryDietary)
+	if err != nil {
+		return nil, err
+	}
+
+	vocabulary = domain.NewQueryVocabulary(taxonomy, tags)
+
+	uc.mu.Lock()
+	uc.vocabulary = vocabulary
+	uc.taxonomy = taxonomy
+	uc.loadedAt = time.Now()
+	uc.mu.Unlock()
+
+	return vocabulary, nil
+}
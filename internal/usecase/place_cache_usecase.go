@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/geohash"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// placeCacheRadiusBuckets 快取的搜尋半徑級距（公尺），最大值與 Google Places 的半徑上限相同
+var placeCacheRadiusBuckets = []int{500, 1000, 2000, 5000, 10000, 20000, 50000}
+
+// PlaceCacheSettings 外部地點搜尋快取設定
+type PlaceCacheSettings struct {
+	Backend   string        // 快取後端名稱（統計顯示用）
+	TTL       time.Duration // 搜尋結果保留時間
+	Precision int           // geohash 格網精度
+}
+
+// CachedExternalAPI 以 geohash 格網與半徑級距快取外部地點搜尋結果
+// 同一格網、同一級距的搜尋改以格網中心點查詢，讓附近使用者共用結果；同時進行的相同查詢合併為一次請求
+type CachedExternalAPI struct {
+	externalAPI ExternalAPIService
+	cache       PlaceSearchCache
+	settings    PlaceCacheSettings
+	group       singleflight.Group
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	shared atomic.Int64
+	errors atomic.Int64
+}
+
+// NewCachedExternalAPI 建立具快取的外部地點服務
+func NewCachedExternalAPI(externalAPI ExternalAPIService, cache PlaceSearchCache, settings PlaceCacheSettings) *CachedExternalAPI {
+	if settings.TTL <= 0 {
+		settings.TTL = time.Hour
+	}
+	if settings.Precision <= 0 {
+		settings.Precision = 6
+	}
+
+	return &CachedExternalAPI{
+		externalAPI: externalAPI,
+		cache:       cache,
+		settings:    settings,
+	}
+}
+
+// SearchNearbyRestaurants 搜尋附近餐廳，優先使用快取結果
+func (c *CachedExternalAPI) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	hash := geohash.Encode(lat, lng, c.settings.Precision)
+	box, _ := geohash.Decode(hash)
+	centerLat, centerLng := box.Center()
+
+	// 以格網中心點查詢時，半徑需加上中心點到格網角落的距離才能涵蓋原本的搜尋範圍
+	halfDiagonal := domain.DistanceMeters(centerLat, centerLng, box.MaxLat, box.MaxLng)
+	bucket := placeCacheRadiusBucket(radius + int(math.Ceil(halfDiagonal)))
+	key := fmt.Sprintf("%s:%d", hash, bucket)
+
+	restaurants, found, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.errors.Add(1)
+		logger.Warn("讀取地點搜尋快取失敗", zap.Error(err), zap.String("key", key))
+	} else if found {
+		c.hits.Add(1)
+		return restaurants, nil
+	}
+
+	// 查詢不受個別請求取消影響，避免一個請求中斷時合併的其他請求一起失敗
+	leader := false
+	resultCh := c.group.DoChan(key, func() (interface{}, error) {
+		leader = true
+		c.misses.Add(1)
+		restaurants, err := c.externalAPI.SearchNearbyRestaurants(context.WithoutCancel(ctx), centerLat, centerLng, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.cache.Set(context.WithoutCancel(ctx), key, restaurants, c.settings.TTL); err != nil {
+			c.errors.Add(1)
+			logger.Warn("寫入地點搜尋快取失敗", zap.Error(err), zap.String("key", key))
+		}
+		return restaurants, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultCh:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		if !leader {
+			c.shared.Add(1)
+		}
+		// 每個呼叫端取得獨立的切片，避免匯入時互相修改
+		return append([]domain.Restaurant(nil), result.Val.([]domain.Restaurant)...), nil
+	}
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊（不使用快取，排程同步需要最新資料）
+func (c *CachedExternalAPI) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	return c.externalAPI.GetRestaurantDetails(ctx, placeID)
+}
+
+// Stats 取得快取統計
+func (c *CachedExternalAPI) Stats() domain.PlaceCacheStats {
+	stats := domain.PlaceCacheStats{
+		Enabled: true,
+		Backend: c.settings.Backend,
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Shared:  c.shared.Load(),
+		Errors:  c.errors.Load(),
+	}
+	if total := stats.Hits + stats.Misses + stats.Shared; total > 0 {
+		stats.HitRate = float64(stats.Hits+stats.Shared) / float64(total)
+	}
+	return stats
+}
+
+// placeCacheRadiusBucket 將搜尋半徑無條件進位至快取級距
+func placeCacheRadiusBucket(radius int) int {
+	for _, bucket := range placeCacheRadiusBuckets {
+		if radius <= bucket {
+			return bucket
+		}
+	}
+	return placeCacheRadiusBuckets[len(placeCacheRadiusBuckets)-1]
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// placePhotoMissTTL 查無照片的參照在此期間內不再查詢
+	placePhotoMissTTL = time.Hour
+	// maxPlacePhotoMisses 記錄查無照片參照的數量上限
+	maxPlacePhotoMisses = 10000
+)
+
+// PlacePhotoSettings 餐廳照片代理設定
+type PlacePhotoSettings struct {
+	MaxWidth int           // 向外部資料來源要求的照片寬度（像素）
+	CacheTTL time.Duration // 照片快取保留時間
+}
+
+// PlacePhotoUseCase 餐廳照片代理業務邏輯：優先使用本機快取，未命中時由伺服器端下載照片
+// 只下載餐廳資料中實際使用的照片，避免任意參照消耗外部 API 額度
+type PlacePhotoUseCase struct {
+	fetcher        PlacePhotoFetcher
+	cache          PlacePhotoCache
+	restaurantRepo RestaurantRepository
+	settings       PlacePhotoSettings
+	group          singleflight.Group
+	now            func() time.Time
+
+	mu     sync.Mutex
+	misses map[string]time.Time // 查無照片的參照與到期時間
+}
+
+// NewPlacePhotoUseCase 建立餐廳照片用例，fetcher 為 nil 時（未設定 API 金鑰）只提供已快取的照片
+func NewPlacePhotoUseCase(fetcher PlacePhotoFetcher, cache PlacePhotoCache, restaurantRepo RestaurantRepository, settings PlacePhotoSettings) *PlacePhotoUseCase {
+	if settings.MaxWidth <= 0 {
+		settings.MaxWidth = 400
+	}
+	if settings.CacheTTL <= 0 {
+		settings.CacheTTL = 7 * 24 * time.Hour
+	}
+
+	return &PlacePhotoUseCase{
+		fetcher:        fetcher,
+		cache:          cache,
+		restaurantRepo: restaurantRepo,
+		settings:       settings,
+		now:            time.Now,
+		misses:         make(map[string]time.Time),
+	}
+}
+
+// GetPhoto 取得照片，快取過期且重新下載失敗時改用過期的快取
+func (uc *PlacePhotoUseCase) GetPhoto(ctx context.Context, ref string) (*domain.PlacePhoto, error) {
+	if !domain.ValidPlacePhotoRef(ref) {
+		return nil, domain.ErrInvalidPhotoReference
+	}
+
+	key := fmt.Sprintf("%s@%d", ref, uc.settings.MaxWidth)
+	cached, found, err := uc.cache.Get(ctx, key)
+	if err != nil {
+		logger.Warn("讀取照片快取失敗", zap.Error(err), zap.String("ref", ref))
+		found = false
+	}
+	if found {
+		cached.ExpiresAt = cached.FetchedAt.Add(uc.settings.CacheTTL)
+		if uc.now().Before(cached.ExpiresAt) {
+			return cached, nil
+		}
+	}
+
+	if uc.fetcher == nil || uc.isMiss(ref) {
+		if found {
+			return uc.stale(cached), nil
+		}
+		return nil, domain.ErrProviderPlaceNotFound
+	}
+
+	// 下載不受個別請求取消影響，同一張照片同時只下載一次
+	resultCh := uc.group.DoChan(key, func() (interface{}, error) {
+		fetchCtx := context.WithoutCancel(ctx)
+		exists, err := uc.restaurantRepo.ImageURLExists(fetchCtx, domain.PlacePhotoPath(ref))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			uc.recordMiss(ref)
+			return nil, domain.ErrProviderPlaceNotFound
+		}
+
+		photo, err := uc.fetcher.FetchPlacePhoto(fetchCtx, ref, uc.settings.MaxWidth)
+		if errors.Is(err, domain.ErrProviderPlaceNotFound) {
+			uc.recordMiss(ref)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.cache.Set(fetchCtx, key, photo); err != nil {
+			logger.Warn("寫入照片快取失敗", zap.Error(err), zap.String("ref", ref))
+		}
+		return photo, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultCh:
+		if result.Err != nil {
+			if found && !errors.Is(result.Err, domain.ErrProviderPlaceNotFound) {
+				logger.Warn("重新下載照片失敗，使用過期的快取", zap.Error(result.Err), zap.String("ref", ref))
+				return uc.stale(cached), nil
+			}
+			return nil, result.Err
+		}
+		photo := *result.Val.(*domain.PlacePhoto)
+		photo.ExpiresAt = photo.FetchedAt.Add(uc.settings.CacheTTL)
+		return &photo, nil
+	}
+}
+
+// PruneCache 清除超過保留時間的照片快取，回傳刪除的檔案數
+func (uc *PlacePhotoUseCase) PruneCache(ctx context.Context) (int, error) {
+	return uc.cache.Prune(ctx, uc.now().Add(-uc.settings.CacheTTL))
+}
+
+// isMiss 參照是否在近期查無照片
+func (uc *PlacePhotoUseCase) isMiss(ref string) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	until, exists := uc.misses[ref]
+	if !exists {
+		return false
+	}
+	if !uc.now().Before(until) {
+		delete(uc.misses, ref)
+		return false
+	}
+	return true
+}
+
+// recordMiss 記錄查無照片的參照，數量達上限時先移除過期項目，仍然過多則全部清除
+func (uc *PlacePhotoUseCase) recordMiss(ref string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	if len(uc.misses) >= maxPlacePhotoMisses {
+		for missRef, until := range uc.misses {
+			if !now.Before(until) {
+				delete(uc.misses, missRef)
+			}
+		}
+		if len(uc.misses) >= maxPlacePhotoMisses {
+			uc.misses = make(map[string]time.Time)
+		}
+	}
+	uc.misses[ref] = now.Add(placePhotoMissTTL)
+}
+
+// stale 過期的快取照片只讓瀏覽器短暫快取，以便外部資料來源恢復後盡快更新
+func (uc *PlacePhotoUseCase) stale(photo *domain.PlacePhoto) *domain.PlacePhoto {
+	photo.ExpiresAt = uc.now().Add(5 * time.Minute)
+	return photo
+}
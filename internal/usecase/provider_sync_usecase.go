@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ProviderSyncSettings 外部資料同步設定
+type ProviderSyncSettings struct {
+	MaxAge time.Duration // 超過此時間未同步的餐廳會重新向外部資料來源查詢
+	Budget int           // 每次排程最多發出的外部請求數
+}
+
+// ProviderSyncUseCase 外部資料同步業務邏輯
+type ProviderSyncUseCase struct {
+	restaurantRepo RestaurantRepository
+	externalAPI    ExternalAPIService
+	cuisineUseCase *CuisineUseCase
+	settings       ProviderSyncSettings
+}
+
+// NewProviderSyncUseCase 建立外部資料同步用例
+func NewProviderSyncUseCase(
+	restaurantRepo RestaurantRepository,
+	externalAPI ExternalAPIService,
+	cuisineUseCase *CuisineUseCase,
+	settings ProviderSyncSettings,
+) *ProviderSyncUseCase {
+	if settings.MaxAge <= 0 {
+		settings.MaxAge = 30 * 24 * time.Hour
+	}
+	if settings.Budget <= 0 {
+		settings.Budget = 100
+	}
+
+	return &ProviderSyncUseCase{
+		restaurantRepo: restaurantRepo,
+		externalAPI:    externalAPI,
+		cuisineUseCase: cuisineUseCase,
+		settings:       settings,
+	}
+}
+
+// RefreshStale 重新取得過久未同步餐廳的外部資料，回傳已查詢的餐廳數
+// 每次最多查詢 Budget 家餐廳；外部請求額度用盡時提前結束，剩餘餐廳留待下次排程
+func (uc *ProviderSyncUseCase) RefreshStale(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-uc.settings.MaxAge)
+	restaurants, err := uc.restaurantRepo.ListStaleProviderRestaurants(ctx, cutoff, uc.settings.Budget)
+	if err != nil {
+		return 0, err
+	}
+
+	var summary domain.ProviderSyncSummary
+	for i := range restaurants {
+		if ctx.Err() != nil {
+			break
+		}
+
+		err := uc.syncRestaurant(ctx, &restaurants[i], &summary)
+		if errors.Is(err, domain.ErrAPIQuotaExceeded) || errors.Is(err, domain.ErrProviderUnavailable) {
+			logger.Warn("外部資料來源請求額度已用盡或暫停請求，停止本次同步", zap.Int("checked", summary.Checked), zap.Error(err))
+			break
+		}
+	}
+
+	logger.Info("外部資料同步完成",
+		zap.Int("checked", summary.Checked),
+		zap.Int("updated", summary.Updated),
+		zap.Int("unchanged", summary.Unchanged),
+		zap.Int("closed", summary.Closed),
+		zap.Int("not_found", summary.NotFound),
+		zap.Int("failed", summary.Failed),
+	)
+	return summary.Checked, nil
+}
+
+// syncRestaurant 同步單一餐廳並記錄同步狀態，回傳外部資料來源的錯誤
+func (uc *ProviderSyncUseCase) syncRestaurant(ctx context.Context, restaurant *domain.Restaurant, summary *domain.ProviderSyncSummary) error {
+	summary.Checked++
+
+	detail, err := uc.externalAPI.GetRestaurantDetails(ctx, restaurant.GoogleID)
+	switch {
+	case errors.Is(err, domain.ErrProviderPlaceNotFound):
+		summary.NotFound++
+		uc.recordSync(ctx, restaurant.ID, domain.ProviderSyncNotFound, "")
+		return nil
+	case err != nil:
+		summary.Failed++
+		// 額度用盡或斷路器開啟不算同步失敗，不記錄嘗試時間以便下次排程優先處理
+		if !errors.Is(err, domain.ErrAPIQuotaExceeded) && !errors.Is(err, domain.ErrProviderUnavailable) && ctx.Err() == nil {
+			uc.recordSync(ctx, restaurant.ID, domain.ProviderSyncError, err.Error())
+		}
+		return err
+	}
+
+	status := domain.ProviderSyncStatusFor(detail.BusinessStatus)
+	if status == domain.ProviderSyncClosed {
+		summary.Closed++
+		uc.recordSync(ctx, restaurant.ID, status, "")
+		logger.Info("餐廳已永久歇業，停用餐廳", zap.Int("restaurant_id", restaurant.ID), zap.String("google_id", restaurant.GoogleID))
+		return nil
+	}
+
+	// 外部回傳的 ID 可能已更新，仍以資料庫記錄的 ID 比對既有餐廳
+	detail.GoogleID = restaurant.GoogleID
+	source, _ := domain.ParseProviderPlaceID(restaurant.GoogleID)
+	uc.cuisineUseCase.AssignCuisine(ctx, detail, source)
+
+	outcome, err := uc.restaurantRepo.UpsertByProviderID(ctx, detail)
+	if err != nil {
+		summary.Failed++
+		uc.recordSync(ctx, restaurant.ID, domain.ProviderSyncError, err.Error())
+		return nil
+	}
+	if outcome == domain.ProviderUpsertUpdated {
+		summary.Updated++
+	} else {
+		summary.Unchanged++
+	}
+
+	uc.recordSync(ctx, restaurant.ID, status, "")
+	return nil
+}
+
+// recordSync 記錄同步狀態，失敗時只記錄日誌
+func (uc *ProviderSyncUseCase) recordSync(ctx context.Context, restaurantID int, status domain.ProviderSyncStatus, syncErr string) {
+	if err := uc.restaurantRepo.RecordProviderSync(ctx, restaurantID, status, syncErr, time.Now()); err != nil {
+		logger.Warn("記錄餐廳同步狀態失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+	}
+}
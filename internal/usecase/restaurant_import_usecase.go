@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// restaurantImportBatchSize 批次匯入時每批的餐廳數
+const restaurantImportBatchSize = 500
+
+// RestaurantImportUseCase 批次匯入外部餐廳資料業務邏輯
+type RestaurantImportUseCase struct {
+	restaurantRepo RestaurantRepository
+	cuisineUseCase *CuisineUseCase
+}
+
+// NewRestaurantImportUseCase 建立批次匯入外部餐廳資料用例
+func NewRestaurantImportUseCase(restaurantRepo RestaurantRepository, cuisineUseCase *CuisineUseCase) *RestaurantImportUseCase {
+	return &RestaurantImportUseCase{
+		restaurantRepo: restaurantRepo,
+		cuisineUseCase: cuisineUseCase,
+	}
+}
+
+// Import 依外部 ID 新增或更新餐廳，並依資料來源的地點類型對應料理分類
+// 每批匯入後記錄進度，context 取消時停止並回傳已完成的統計
+func (uc *RestaurantImportUseCase) Import(ctx context.Context, restaurants []domain.Restaurant, source string) (domain.ImportSummary, error) {
+	var summary domain.ImportSummary
+	for start := 0; start < len(restaurants); start += restaurantImportBatchSize {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		end := start + restaurantImportBatchSize
+		if end > len(restaurants) {
+			end = len(restaurants)
+		}
+		batch := importProviderRestaurants(ctx, uc.restaurantRepo, uc.cuisineUseCase, restaurants[start:end], source)
+		summary.Created += batch.Created
+		summary.Updated += batch.Updated
+		summary.Unchanged += batch.Unchanged
+		summary.Failed += batch.Failed
+
+		logger.Info("批次匯入餐廳進度",
+			zap.String("source", source),
+			zap.Int("processed", end),
+			zap.Int("total", len(restaurants)),
+		)
+	}
+	return summary, nil
+}
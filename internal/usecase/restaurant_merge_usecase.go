@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultMergePageSize 疑似重複餐廳與合併記錄清單的預設筆數
+const defaultMergePageSize = 50
+
+// RestaurantMergeUseCase 重複餐廳偵測與合併業務邏輯（管理功能）
+type RestaurantMergeUseCase struct {
+	mergeRepo RestaurantMergeRepository
+	settings  domain.DuplicateDetectionSettings
+}
+
+// NewRestaurantMergeUseCase 建立餐廳合併用例
+func NewRestaurantMergeUseCase(mergeRepo RestaurantMergeRepository, settings domain.DuplicateDetectionSettings) *RestaurantMergeUseCase {
+	return &RestaurantMergeUseCase{
+		mergeRepo: mergeRepo,
+		settings:  settings,
+	}
+}
+
+// DetectDuplicates 偵測名稱相似且座標相近的餐廳（排程與管理端手動執行），回傳偵測到的組數
+func (uc *RestaurantMergeUseCase) DetectDuplicates(ctx context.Context) (int, error) {
+	count, err := uc.mergeRepo.DetectDuplicates(ctx, uc.settings)
+	if err != nil {
+		logger.Error("偵測重複餐廳失敗", zap.Error(err))
+		return 0, errors.New("偵測重複餐廳失敗")
+	}
+	return count, nil
+}
+
+// ListDuplicates 取得疑似重複餐廳清單，預設只列出待處理的組合
+func (uc *RestaurantMergeUseCase) ListDuplicates(ctx context.Context, params *domain.DuplicateCandidateParams) ([]domain.DuplicateCandidate, int, error) {
+	status := params.Status
+	if status == "" {
+		status = domain.DuplicateCandidatePending
+	}
+	if !status.IsValid() {
+		return nil, 0, domain.ErrInvalidDuplicateStatus
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultMergePageSize
+	}
+
+	candidates, total, err := uc.mergeRepo.ListDuplicateCandidates(ctx, status, limit, params.Offset)
+	if err != nil {
+		logger.Error("取得疑似重複餐廳失敗", zap.Error(err))
+		return nil, 0, errors.New("取得疑似重複餐廳失敗")
+	}
+	return candidates, total, nil
+}
+
+// DismissDuplicate 將疑似重複的組合標記為非重複，之後偵測不再列入
+func (uc *RestaurantMergeUseCase) DismissDuplicate(ctx context.Context, userID int, req *domain.DismissDuplicateRequest) error {
+	if err := uc.mergeRepo.DismissDuplicate(ctx, req.RestaurantID, req.DuplicateID, userID); err != nil {
+		if errors.Is(err, domain.ErrDuplicateCandidateNotFound) {
+			return err
+		}
+		logger.Error("略過疑似重複餐廳失敗", zap.Error(err))
+		return errors.New("略過疑似重複餐廳失敗")
+	}
+	return nil
+}
+
+// PreviewMerge 預覽合併將移動的資料
+func (uc *RestaurantMergeUseCase) PreviewMerge(ctx context.Context, req *domain.RestaurantMergeRequest) (*domain.RestaurantMergePreview, error) {
+	preview, err := uc.mergeRepo.PreviewMerge(ctx, req.SurvivorID, req.DuplicateID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) || errors.Is(err, domain.ErrInvalidRestaurantMerge) {
+			return nil, err
+		}
+		logger.Error("預覽餐廳合併失敗", zap.Error(err))
+		return nil, errors.New("預覽餐廳合併失敗")
+	}
+	return preview, nil
+}
+
+// Merge 合併重複餐廳
+func (uc *RestaurantMergeUseCase) Merge(ctx context.Context, userID int, req *domain.RestaurantMergeRequest) (*domain.RestaurantMerge, error) {
+	merge, err := uc.mergeRepo.Merge(ctx, req.SurvivorID, req.DuplicateID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) || errors.Is(err, domain.ErrInvalidRestaurantMerge) {
+			return nil, err
+		}
+		logger.Error("合併餐廳失敗", zap.Error(err), zap.Int("survivor_id", req.SurvivorID), zap.Int("duplicate_id", req.DuplicateID))
+		return nil, errors.New("合併餐廳失敗")
+	}
+
+	logger.Info("合併餐廳成功",
+		zap.Int("merge_id", merge.ID),
+		zap.Int("survivor_id", merge.SurvivorID),
+		zap.Int("duplicate_id", merge.DuplicateID),
+		zap.Int("user_id", userID),
+	)
+	return merge, nil
+}
+
+// UndoMerge 復原合併
+func (uc *RestaurantMergeUseCase) UndoMerge(ctx context.Context, userID, mergeID int) (*domain.RestaurantMerge, error) {
+	merge, err := uc.mergeRepo.UndoMerge(ctx, mergeID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantMergeNotFound) ||
+			errors.Is(err, domain.ErrMergeAlreadyUndone) ||
+			errors.Is(err, domain.ErrMergeUndoBlocked) {
+			return nil, err
+		}
+		logger.Error("復原餐廳合併失敗", zap.Error(err), zap.Int("merge_id", mergeID))
+		return nil, errors.New("復原餐廳合併失敗")
+	}
+
+	logger.Info("復原餐廳合併成功", zap.Int("merge_id", mergeID), zap.Int("user_id", userID))
+	return merge, nil
+}
+
+// ListMerges 取得合併記錄（稽核用）
+func (uc *RestaurantMergeUseCase) ListMerges(ctx context.Context, params *domain.RestaurantMergeListParams) ([]domain.RestaurantMerge, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultMergePageSize
+	}
+
+	merges, err := uc.mergeRepo.ListMerges(ctx, limit, params.Offset)
+	if err != nil {
+		logger.Error("取得合併記錄失敗", zap.Error(err))
+		return nil, errors.New("取得合併記錄失敗")
+	}
+	return merges, nil
+}
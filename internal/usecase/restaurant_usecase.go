@@ -3,34 +3,76 @@ package usecase
 import (
 	"context"
 	"errors"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
 	"go.uber.org/zap"
 )
 
+const (
+	// viewportClusterMaxZoom 小於等於此縮放等級時以群集回傳
+	viewportClusterMaxZoom = 14
+	// viewportClusterGridDivisions 每個地圖圖磚切分的群集網格數（每邊）
+	viewportClusterGridDivisions = 4
+	// defaultViewportLimit 個別餐廳模式的預設結果數量
+	defaultViewportLimit = 200
+	// defaultTextSearchLimit 文字搜尋的預設結果數量
+	defaultTextSearchLimit = 20
+	// defaultAutocompleteLimit 自動完成每種建議的預設數量
+	defaultAutocompleteLimit = 5
+)
+
 // RestaurantUseCase 餐廳業務邏輯
 type RestaurantUseCase struct {
-	restaurantRepo RestaurantRepository
-	favoriteRepo   FavoriteRepository
-	externalAPI    ExternalAPIService
+	restaurantRepo   RestaurantRepository
+	favoriteRepo     FavoriteRepository
+	openingHoursRepo OpeningHoursRepository
+	searchLogRepo    SearchLogRepository
+	externalAPI      ExternalAPIService
+	cuisineUseCase   *CuisineUseCase
+	menuUseCase      *MenuUseCase
 }
 
 // NewRestaurantUseCase 建立餐廳用例
 func NewRestaurantUseCase(
 	restaurantRepo RestaurantRepository,
 	favoriteRepo FavoriteRepository,
+	openingHoursRepo OpeningHoursRepository,
+	searchLogRepo SearchLogRepository,
 	externalAPI ExternalAPIService,
+	cuisineUseCase *CuisineUseCase,
+	menuUseCase *MenuUseCase,
 ) *RestaurantUseCase {
 	return &RestaurantUseCase{
-		restaurantRepo: restaurantRepo,
-		favoriteRepo:   favoriteRepo,
-		externalAPI:    externalAPI,
+		restaurantRepo:   restaurantRepo,
+		favoriteRepo:     favoriteRepo,
+		openingHoursRepo: openingHoursRepo,
+		searchLogRepo:    searchLogRepo,
+		externalAPI:      externalAPI,
+		cuisineUseCase:   cuisineUseCase,
+		menuUseCase:      menuUseCase,
 	}
 }
 
-// SearchNearby 搜尋附近餐廳
+// SearchNearby 搜尋附近餐廳，並記錄搜尋結果供美食沙漠分析
 func (uc *RestaurantUseCase) SearchNearby(ctx context.Context, params *domain.RestaurantSearchParams) ([]domain.RestaurantWithDistance, error) {
+	openTime, err := domain.ResolveOpenTime(params.OpenNow, params.OpenAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	params.OpenTime = openTime
+	params.TagsAll = domain.NormalizeTagSlugs(params.TagsAll)
+	params.TagsAny = domain.NormalizeTagSlugs(params.TagsAny)
+
+	cuisineIDs, err := uc.cuisineUseCase.ResolveFilter(ctx, params.Cuisine)
+	if err != nil {
+		return nil, err
+	}
+	params.CuisineIDs = cuisineIDs
+
 	// 先從本地資料庫搜尋
 	restaurants, err := uc.restaurantRepo.SearchNearby(ctx, params)
 	if err != nil {
@@ -38,18 +80,19 @@ func (uc *RestaurantUseCase) SearchNearby(ctx context.Context, params *domain.Re
 		return nil, errors.New("搜尋餐廳失敗")
 	}
 
+	searchLog := newSearchLog(domain.SearchSourceSearch, params.Latitude, params.Longitude, params.Radius)
+	step := domain.SearchExpansionStep{Radius: params.Radius, LocalCount: len(restaurants)}
+
 	// 如果本地餐廳數量不足，可以從外部 API 補充
 	if len(restaurants) < 5 && uc.externalAPI != nil {
 		externalRestaurants, err := uc.externalAPI.SearchNearbyRestaurants(ctx, params.Latitude, params.Longitude, params.Radius)
 		if err != nil {
 			logger.Warn("從外部 API 搜尋餐廳失敗", zap.Error(err))
 		} else {
-			// 將外部餐廳加入本地資料庫
-			for _, restaurant := range externalRestaurants {
-				if err := uc.restaurantRepo.Create(ctx, &restaurant); err != nil {
-					logger.Warn("儲存外部餐廳失敗", zap.Error(err), zap.String("name", restaurant.Name))
-				}
-			}
+			// 將外部餐廳匯入本地資料庫（依外部 ID 去重）
+			summary := importProviderRestaurants(ctx, uc.restaurantRepo, uc.cuisineUseCase, externalRestaurants, domain.CuisineProviderGoogle)
+			step.ExternalCount = summary.Imported()
+			searchLog.ExternalUsed = step.ExternalCount > 0
 
 			// 重新搜尋
 			restaurants, err = uc.restaurantRepo.SearchNearby(ctx, params)
@@ -59,6 +102,12 @@ func (uc *RestaurantUseCase) SearchNearby(ctx context.Context, params *domain.Re
 		}
 	}
 
+	searchLog.Steps = append(searchLog.Steps, step)
+	searchLog.FinalRadius = params.Radius
+	searchLog.ResultCount = len(restaurants)
+	searchLog.Succeeded = len(restaurants) > 0
+	recordSearchLog(ctx, uc.searchLogRepo, searchLog)
+
 	logger.Info("搜尋附近餐廳完成",
 		zap.Float64("latitude", params.Latitude),
 		zap.Float64("longitude", params.Longitude),
@@ -66,9 +115,202 @@ func (uc *RestaurantUseCase) SearchNearby(ctx context.Context, params *domain.Re
 		zap.Int("count", len(restaurants)),
 	)
 
+	uc.cuisineUseCase.LocalizeNearby(ctx, restaurants)
+	uc.menuUseCase.AttachMenuMatches(ctx, params, restaurants)
 	return restaurants, nil
 }
 
+// importProviderRestaurants 依外部 ID 匯入外部資料來源的餐廳，回傳新增、更新與未變動的數量
+// provider 為未標示資料來源的餐廳預設使用的來源
+func importProviderRestaurants(
+	ctx context.Context,
+	restaurantRepo RestaurantRepository,
+	cuisineUseCase *CuisineUseCase,
+	restaurants []domain.Restaurant,
+	provider string,
+) domain.ImportSummary {
+	var summary domain.ImportSummary
+	for i := range restaurants {
+		restaurant := &restaurants[i]
+		if restaurant.Source == "" {
+			restaurant.Source = provider
+		}
+		cuisineUseCase.AssignCuisine(ctx, restaurant, restaurant.Source)
+
+		outcome, err := restaurantRepo.UpsertByProviderID(ctx, restaurant)
+		if err != nil {
+			logger.Warn("儲存外部餐廳失敗", zap.Error(err), zap.String("name", restaurant.Name))
+			summary.Failed++
+			continue
+		}
+		summary.Add(outcome)
+	}
+
+	logger.Info("匯入外部餐廳完成",
+		zap.String("provider", provider),
+		zap.Int("created", summary.Created),
+		zap.Int("updated", summary.Updated),
+		zap.Int("unchanged", summary.Unchanged),
+		zap.Int("failed", summary.Failed),
+	)
+	return summary
+}
+
+// SearchInViewport 搜尋地圖可視範圍內的餐廳，低縮放等級時回傳群集
+func (uc *RestaurantUseCase) SearchInViewport(ctx context.Context, params *domain.ViewportSearchParams) (*domain.ViewportSearchResult, error) {
+	if params.MinLat > params.MaxLat {
+		return nil, domain.ErrInvalidViewport
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultViewportLimit
+	}
+
+	cuisineIDs, err := uc.cuisineUseCase.ResolveFilter(ctx, params.Cuisine)
+	if err != nil {
+		return nil, err
+	}
+	params.CuisineIDs = cuisineIDs
+
+	count, err := uc.restaurantRepo.CountInViewport(ctx, params)
+	if err != nil {
+		logger.Error("計算範圍內餐廳數量失敗", zap.Error(err))
+		return nil, errors.New("搜尋餐廳失敗")
+	}
+
+	result := &domain.ViewportSearchResult{
+		Count: count,
+	}
+
+	if params.Zoom <= viewportClusterMaxZoom {
+		clusters, err := uc.restaurantRepo.ClusterInViewport(ctx, params, viewportCellSize(params.Zoom))
+		if err != nil {
+			logger.Error("餐廳分群失敗", zap.Error(err))
+			return nil, errors.New("搜尋餐廳失敗")
+		}
+		result.Mode = domain.ViewportModeClusters
+		result.Clusters = clusters
+		if result.Clusters == nil {
+			result.Clusters = []domain.RestaurantCluster{}
+		}
+		return result, nil
+	}
+
+	restaurants, err := uc.restaurantRepo.SearchInViewport(ctx, params)
+	if err != nil {
+		logger.Error("搜尋範圍內餐廳失敗", zap.Error(err))
+		return nil, errors.New("搜尋餐廳失敗")
+	}
+	result.Mode = domain.ViewportModeRestaurants
+	result.Restaurants = restaurants
+	if result.Restaurants == nil {
+		result.Restaurants = []domain.Restaurant{}
+	}
+	result.Truncated = count > len(restaurants)
+	uc.localizeRestaurants(ctx, result.Restaurants)
+
+	return result, nil
+}
+
+// viewportCellSize 依縮放等級計算群集網格大小（度），與 Web Mercator 圖磚寬度對齊
+func viewportCellSize(zoom int) float64 {
+	return 360 / math.Pow(2, float64(zoom)) / viewportClusterGridDivisions
+}
+
+// SearchText 以名稱、別名或地址文字搜尋餐廳
+func (uc *RestaurantUseCase) SearchText(ctx context.Context, params *domain.TextSearchParams) ([]domain.TextSearchResult, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return nil, domain.ErrInvalidSearchQuery
+	}
+	if (params.Latitude == nil) != (params.Longitude == nil) {
+		return nil, domain.ErrInvalidSearchQuery
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultTextSearchLimit
+	}
+	params.CuisineIDs = uc.cuisineUseCase.MatchQuery(ctx, params.Query)
+
+	results, err := uc.restaurantRepo.SearchText(ctx, params)
+	if err != nil {
+		logger.Error("文字搜尋餐廳失敗", zap.Error(err), zap.String("query", params.Query))
+		return nil, errors.New("搜尋餐廳失敗")
+	}
+
+	targets := make([]*domain.Restaurant, len(results))
+	for i := range results {
+		targets[i] = &results[i].Restaurant
+	}
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, targets...)
+
+	return results, nil
+}
+
+// Autocomplete 依輸入文字建議餐廳與料理分類
+func (uc *RestaurantUseCase) Autocomplete(ctx context.Context, params *domain.AutocompleteParams) (*domain.AutocompleteResult, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	}
+
+	results, err := uc.SearchText(ctx, &domain.TextSearchParams{
+		Query:     params.Query,
+		Latitude:  params.Latitude,
+		Longitude: params.Longitude,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AutocompleteResult{
+		Query:       strings.TrimSpace(params.Query),
+		Restaurants: make([]domain.RestaurantSuggestion, 0, len(results)),
+		Cuisines:    uc.cuisineUseCase.SuggestCuisines(ctx, params.Query, limit),
+	}
+	for _, restaurant := range results {
+		result.Restaurants = append(result.Restaurants, domain.RestaurantSuggestion{
+			ID:       restaurant.ID,
+			Name:     restaurant.Name,
+			Address:  restaurant.Address,
+			Cuisine:  restaurant.Cuisine,
+			Distance: restaurant.Distance,
+		})
+	}
+
+	return result, nil
+}
+
+// SetAliases 設定餐廳搜尋別名（管理功能）
+func (uc *RestaurantUseCase) SetAliases(ctx context.Context, restaurantID int, req *domain.SetRestaurantAliasesRequest) ([]string, error) {
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	aliases := make([]string, 0, len(req.Aliases))
+	seen := make(map[string]bool, len(req.Aliases))
+	for _, alias := range req.Aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+
+	if err := uc.restaurantRepo.SetAliases(ctx, restaurantID, aliases); err != nil {
+		logger.Error("設定餐廳別名失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("設定餐廳別名失敗")
+	}
+
+	saved, err := uc.restaurantRepo.GetAliases(ctx, restaurantID)
+	if err != nil {
+		logger.Error("取得餐廳別名失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("設定餐廳別名失敗")
+	}
+	return saved, nil
+}
+
 // GetRestaurant 取得餐廳詳細資訊
 func (uc *RestaurantUseCase) GetRestaurant(ctx context.Context, id int) (*domain.Restaurant, error) {
 	restaurant, err := uc.restaurantRepo.GetByID(ctx, id)
@@ -77,6 +319,7 @@ func (uc *RestaurantUseCase) GetRestaurant(ctx context.Context, id int) (*domain
 		return nil, errors.New("餐廳不存在")
 	}
 
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, restaurant)
 	return restaurant, nil
 }
 
@@ -147,11 +390,33 @@ func (uc *RestaurantUseCase) GetFavorites(ctx context.Context, userID int) ([]do
 
 // CreateRestaurant 建立餐廳（管理功能）
 func (uc *RestaurantUseCase) CreateRestaurant(ctx context.Context, restaurant *domain.Restaurant) error {
+	if err := uc.resolveRestaurantCuisine(ctx, restaurant); err != nil {
+		return err
+	}
+
 	if err := uc.restaurantRepo.Create(ctx, restaurant); err != nil {
+		if errors.Is(err, domain.ErrRestaurantProviderIDExists) {
+			return err
+		}
 		logger.Error("建立餐廳失敗", zap.Error(err), zap.String("name", restaurant.Name))
 		return errors.New("建立餐廳失敗")
 	}
 
+	// 一併儲存營業時間
+	if len(restaurant.OpeningHours) > 0 {
+		hours := &domain.OpeningHours{
+			RestaurantID: restaurant.ID,
+			TimeZone:     domain.DefaultRestaurantTimeZone,
+			Periods:      restaurant.OpeningHours,
+		}
+		if err := hours.Validate(); err != nil {
+			logger.Warn("營業時間格式錯誤，略過儲存", zap.Error(err), zap.Int("restaurant_id", restaurant.ID))
+		} else if err := uc.openingHoursRepo.Replace(ctx, hours); err != nil {
+			logger.Warn("儲存營業時間失敗", zap.Error(err), zap.Int("restaurant_id", restaurant.ID))
+		}
+	}
+
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, restaurant)
 	logger.Info("建立餐廳成功", zap.String("name", restaurant.Name), zap.Int("id", restaurant.ID))
 	return nil
 }
@@ -164,24 +429,151 @@ func (uc *RestaurantUseCase) GetAllRestaurants(ctx context.Context, limit, offse
 		return nil, errors.New("取得餐廳清單失敗")
 	}
 
+	uc.localizeRestaurants(ctx, restaurants)
 	return restaurants, nil
 }
 
 // UpdateRestaurant 更新餐廳資訊（管理功能）
 func (uc *RestaurantUseCase) UpdateRestaurant(ctx context.Context, restaurant *domain.Restaurant) error {
 	// 檢查餐廳是否存在
-	_, err := uc.restaurantRepo.GetByID(ctx, restaurant.ID)
+	existing, err := uc.restaurantRepo.GetByID(ctx, restaurant.ID)
 	if err != nil {
 		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurant.ID))
 		return errors.New("餐廳不存在")
 	}
 
+	if err := uc.resolveRestaurantCuisine(ctx, restaurant); err != nil {
+		return err
+	}
+
+	// 管理員修改的欄位加入鎖定，外部資料同步時不再覆寫；未指定 locked_fields 時沿用既有的鎖定
+	if restaurant.LockedFields == nil {
+		restaurant.LockedFields = existing.LockedFields
+	}
+	restaurant.LockedFields = domain.NormalizeLockedFields(append(restaurant.LockedFields, existing.ChangedFields(restaurant)...))
+
 	// 更新餐廳資訊
 	if err := uc.restaurantRepo.Update(ctx, restaurant); err != nil {
+		if errors.Is(err, domain.ErrRestaurantProviderIDExists) {
+			return err
+		}
 		logger.Error("更新餐廳失敗", zap.Error(err), zap.Int("restaurant_id", restaurant.ID))
 		return errors.New("更新餐廳失敗")
 	}
 
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, restaurant)
 	logger.Info("更新餐廳成功", zap.String("name", restaurant.Name), zap.Int("id", restaurant.ID))
 	return nil
 }
+
+// resolveRestaurantCuisine 檢查管理端指定的料理分類，未指定 ID 時以料理名稱對應
+func (uc *RestaurantUseCase) resolveRestaurantCuisine(ctx context.Context, restaurant *domain.Restaurant) error {
+	if restaurant.CuisineID != nil {
+		taxonomy, err := uc.cuisineUseCase.Taxonomy(ctx)
+		if err != nil {
+			logger.Error("取得料理分類失敗", zap.Error(err))
+			return errors.New("取得料理分類失敗")
+		}
+		if _, ok := taxonomy.Get(*restaurant.CuisineID); !ok {
+			return domain.ErrInvalidCuisine
+		}
+		return nil
+	}
+
+	if restaurant.Cuisine != "" {
+		uc.cuisineUseCase.AssignCuisine(ctx, restaurant, "")
+		if restaurant.CuisineID == nil {
+			return domain.ErrInvalidCuisine
+		}
+	}
+
+	return nil
+}
+
+// localizeRestaurants 依請求語系填入餐廳清單的料理分類名稱
+func (uc *RestaurantUseCase) localizeRestaurants(ctx context.Context, restaurants []domain.Restaurant) {
+	targets := make([]*domain.Restaurant, len(restaurants))
+	for i := range restaurants {
+		targets[i] = &restaurants[i]
+	}
+	uc.cuisineUseCase.LocalizeRestaurants(ctx, targets...)
+}
+
+// GetOpeningHours 取得餐廳營業時間與目前是否營業
+func (uc *RestaurantUseCase) GetOpeningHours(ctx context.Context, restaurantID int) (*domain.OpeningHours, error) {
+	hours, err := uc.openingHoursRepo.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			return nil, err
+		}
+		logger.Error("取得營業時間失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("取得營業時間失敗")
+	}
+
+	if len(hours.Periods) > 0 {
+		openNow := hours.IsOpenAt(time.Now())
+		hours.OpenNow = &openNow
+	}
+
+	return hours, nil
+}
+
+// UpdateOpeningHours 更新餐廳營業時間（管理功能）
+func (uc *RestaurantUseCase) UpdateOpeningHours(ctx context.Context, restaurantID int, req *domain.UpdateOpeningHoursRequest) (*domain.OpeningHours, error) {
+	hours := &domain.OpeningHours{
+		RestaurantID: restaurantID,
+		TimeZone:     req.TimeZone,
+		Periods:      req.Periods,
+	}
+	if hours.TimeZone == "" {
+		hours.TimeZone = domain.DefaultRestaurantTimeZone
+	}
+	if hours.Periods == nil {
+		hours.Periods = []domain.OpeningPeriod{}
+	}
+
+	if err := hours.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.openingHoursRepo.Replace(ctx, hours); err != nil {
+		if errors.Is(err, domain.ErrRestaurantNotFound) {
+			return nil, err
+		}
+		logger.Error("更新營業時間失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("更新營業時間失敗")
+	}
+
+	return uc.GetOpeningHours(ctx, restaurantID)
+}
+
+// ImportOpeningHours 從外部 API 匯入餐廳營業時間（管理功能）
+func (uc *RestaurantUseCase) ImportOpeningHours(ctx context.Context, restaurantID int) (*domain.OpeningHours, error) {
+	restaurant, err := uc.restaurantRepo.GetByID(ctx, restaurantID)
+	if err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	if restaurant.GoogleID == "" || uc.externalAPI == nil {
+		return nil, domain.ErrRestaurantNoProvider
+	}
+
+	detail, err := uc.externalAPI.GetRestaurantDetails(ctx, restaurant.GoogleID)
+	if err != nil {
+		logger.Error("取得外部餐廳詳細資訊失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrExternalAPIFailed
+	}
+
+	current, err := uc.openingHoursRepo.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		logger.Error("取得營業時間失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("匯入營業時間失敗")
+	}
+
+	// 保留餐廳原本的時區設定
+	return uc.UpdateOpeningHours(ctx, restaurantID, &domain.UpdateOpeningHoursRequest{
+		TimeZone: current.TimeZone,
+		Periods:  detail.OpeningHours,
+	})
+}
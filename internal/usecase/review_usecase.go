@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultReviewPageSize 評論清單預設每頁筆數
+const defaultReviewPageSize = 20
+
+// ReviewUseCase 評論業務邏輯
+type ReviewUseCase struct {
+	reviewRepo     ReviewRepository
+	restaurantRepo RestaurantRepository
+}
+
+// NewReviewUseCase 建立評論用例
+func NewReviewUseCase(reviewRepo ReviewRepository, restaurantRepo RestaurantRepository) *ReviewUseCase {
+	return &ReviewUseCase{
+		reviewRepo:     reviewRepo,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// ListRestaurantReviews 取得餐廳的公開評論
+func (uc *ReviewUseCase) ListRestaurantReviews(ctx context.Context, restaurantID int, params *domain.ReviewListParams) (*domain.ReviewList, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultReviewPageSize
+	}
+
+	reviews, total, err := uc.reviewRepo.ListByRestaurant(ctx, restaurantID, limit, params.Offset)
+	if err != nil {
+		logger.Error("取得餐廳評論失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("取得餐廳評論失敗")
+	}
+
+	return &domain.ReviewList{
+		Reviews: reviews,
+		Total:   total,
+		Limit:   limit,
+		Offset:  params.Offset,
+	}, nil
+}
+
+// UpsertReview 新增或編輯使用者對餐廳的評論，回傳值表示是否為新建立的評論
+func (uc *ReviewUseCase) UpsertReview(ctx context.Context, userID, restaurantID int, req *domain.UpsertReviewRequest) (*domain.Review, bool, error) {
+	visitDate := ""
+	if req.VisitDate != "" {
+		date, err := time.Parse("2006-01-02", req.VisitDate)
+		if err != nil || date.After(time.Now()) {
+			return nil, false, domain.ErrInvalidVisitDate
+		}
+		visitDate = date.Format("2006-01-02")
+	}
+
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, false, domain.ErrRestaurantNotFound
+	}
+
+	photoURLs := make([]string, 0, len(req.PhotoURLs))
+	for _, url := range req.PhotoURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			photoURLs = append(photoURLs, url)
+		}
+	}
+
+	review := &domain.Review{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Rating:       req.Rating,
+		Content:      strings.TrimSpace(req.Content),
+		PhotoURLs:    photoURLs,
+		VisitDate:    visitDate,
+	}
+
+	created, err := uc.reviewRepo.Upsert(ctx, review)
+	if err != nil {
+		logger.Error("儲存評論失敗", zap.Error(err), zap.Int("user_id", userID), zap.Int("restaurant_id", restaurantID))
+		return nil, false, errors.New("儲存評論失敗")
+	}
+
+	logger.Info("儲存評論成功",
+		zap.Int("review_id", review.ID),
+		zap.Int("restaurant_id", restaurantID),
+		zap.Bool("created", created),
+	)
+
+	saved, err := uc.reviewRepo.GetByID(ctx, review.ID)
+	if err != nil {
+		logger.Warn("取得評論失敗", zap.Error(err), zap.Int("review_id", review.ID))
+		return review, created, nil
+	}
+	return saved, created, nil
+}
+
+// DeleteReview 刪除使用者對餐廳的評論
+func (uc *ReviewUseCase) DeleteReview(ctx context.Context, userID, restaurantID int) error {
+	if err := uc.reviewRepo.Delete(ctx, restaurantID, userID); err != nil {
+		if errors.Is(err, domain.ErrReviewNotFound) {
+			return err
+		}
+		logger.Error("刪除評論失敗", zap.Error(err), zap.Int("user_id", userID), zap.Int("restaurant_id", restaurantID))
+		return errors.New("刪除評論失敗")
+	}
+
+	return nil
+}
+
+// FlagReview 檢舉評論
+func (uc *ReviewUseCase) FlagReview(ctx context.Context, userID, reviewID int, req *domain.FlagReviewRequest) error {
+	review, err := uc.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, domain.ErrReviewNotFound) {
+			return err
+		}
+		logger.Error("取得評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return errors.New("檢舉評論失敗")
+	}
+	if review.Status == domain.ReviewStatusHidden {
+		return domain.ErrReviewNotFound
+	}
+	if review.UserID == userID {
+		return domain.ErrCannotFlagOwnReview
+	}
+
+	if err := uc.reviewRepo.Flag(ctx, reviewID, userID, strings.TrimSpace(req.Reason)); err != nil {
+		if errors.Is(err, domain.ErrReviewAlreadyFlagged) {
+			return err
+		}
+		logger.Error("檢舉評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return errors.New("檢舉評論失敗")
+	}
+
+	logger.Info("評論遭檢舉", zap.Int("review_id", reviewID), zap.Int("user_id", userID))
+	return nil
+}
+
+// ListFlaggedReviews 取得待審核的評論（版主功能）
+func (uc *ReviewUseCase) ListFlaggedReviews(ctx context.Context, params *domain.ReviewListParams) (*domain.ReviewList, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultReviewPageSize
+	}
+
+	reviews, total, err := uc.reviewRepo.ListFlagged(ctx, limit, params.Offset)
+	if err != nil {
+		logger.Error("取得待審核評論失敗", zap.Error(err))
+		return nil, errors.New("取得待審核評論失敗")
+	}
+
+	return &domain.ReviewList{
+		Reviews: reviews,
+		Total:   total,
+		Limit:   limit,
+		Offset:  params.Offset,
+	}, nil
+}
+
+// ModerateReview 審核評論（版主功能）
+func (uc *ReviewUseCase) ModerateReview(ctx context.Context, moderatorID, reviewID int, req *domain.ModerateReviewRequest) (*domain.Review, error) {
+	var status domain.ReviewStatus
+	switch req.Action {
+	case domain.ReviewModerationApprove:
+		status = domain.ReviewStatusPublished
+	case domain.ReviewModerationHide:
+		status = domain.ReviewStatusHidden
+	default:
+		return nil, domain.ErrInvalidModerationAction
+	}
+
+	if err := uc.reviewRepo.Moderate(ctx, reviewID, moderatorID, status, strings.TrimSpace(req.Note)); err != nil {
+		if errors.Is(err, domain.ErrReviewNotFound) {
+			return nil, err
+		}
+		logger.Error("審核評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return nil, errors.New("審核評論失敗")
+	}
+
+	logger.Info("評論審核完成",
+		zap.Int("review_id", reviewID),
+		zap.Int("moderator_id", moderatorID),
+		zap.String("action", string(req.Action)),
+	)
+
+	review, err := uc.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		logger.Error("取得評論失敗", zap.Error(err), zap.Int("review_id", reviewID))
+		return nil, errors.New("審核評論失敗")
+	}
+	return review, nil
+}
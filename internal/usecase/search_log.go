@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/geohash"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// searchLogGeohashPrecision 搜尋記錄保留的 geohash 精度（約 1.2 公里 x 0.6 公里）
+const searchLogGeohashPrecision = 6
+
+// newSearchLog 建立搜尋記錄，座標粗化為 geohash 格網中心
+func newSearchLog(source domain.SearchSource, lat, lng float64, radius int) *domain.SearchLog {
+	hash := geohash.Encode(lat, lng, searchLogGeohashPrecision)
+	cellLat, cellLng, _ := geohash.Center(hash)
+
+	return &domain.SearchLog{
+		Source:          source,
+		Geohash:         hash,
+		Latitude:        cellLat,
+		Longitude:       cellLng,
+		RequestedRadius: radius,
+	}
+}
+
+// recordSearchLog 記錄搜尋結果供美食沙漠分析，失敗時不影響搜尋
+func recordSearchLog(ctx context.Context, searchLogRepo SearchLogRepository, searchLog *domain.SearchLog) {
+	if searchLogRepo == nil || searchLog == nil || len(searchLog.Steps) == 0 {
+		return
+	}
+	if err := searchLogRepo.Create(ctx, searchLog); err != nil {
+		logger.Warn("記錄搜尋結果失敗", zap.Error(err), zap.String("geohash", searchLog.Geohash))
+	}
+}
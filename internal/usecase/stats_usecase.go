@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultStatsTimeZone 未指定時區時使用的預設時區
+	defaultStatsTimeZone = "Asia/Taipei"
+	// defaultStatsDays 未指定日期範圍時統計的天數
+	defaultStatsDays = 30
+	// maxStatsDays 單次統計允許的最大天數
+	maxStatsDays = 366
+)
+
+// StatsUseCase 統計業務邏輯
+type StatsUseCase struct {
+	statsRepo      StatsRepository
+	cuisineUseCase *CuisineUseCase
+}
+
+// NewStatsUseCase 建立統計用例
+func NewStatsUseCase(statsRepo StatsRepository, cuisineUseCase *CuisineUseCase) *StatsUseCase {
+	return &StatsUseCase{
+		statsRepo:      statsRepo,
+		cuisineUseCase: cuisineUseCase,
+	}
+}
+
+// GetUserStats 取得個人飲食統計
+func (uc *StatsUseCase) GetUserStats(ctx context.Context, userID int, req *domain.UserStatsRequest) (*domain.UserStats, error) {
+	params, err := uc.buildStatsParams(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.statsRepo.GetUserStats(ctx, userID, params)
+	if err != nil {
+		logger.Error("取得個人統計失敗", zap.Error(err), zap.Int("user_id", userID))
+		return nil, errors.New("取得個人統計失敗")
+	}
+
+	// 依請求語系填入料理分類名稱
+	for i := range stats.CuisineDistribution {
+		stats.CuisineDistribution[i].Cuisine = uc.cuisineUseCase.CuisineName(ctx, stats.CuisineDistribution[i].CuisineID)
+	}
+	for i := range stats.TopRestaurants {
+		stats.TopRestaurants[i].Cuisine = uc.cuisineUseCase.CuisineName(ctx, stats.TopRestaurants[i].CuisineID)
+	}
+
+	return stats, nil
+}
+
+// buildStatsParams 將請求的日期與時區轉換為查詢區間
+func (uc *StatsUseCase) buildStatsParams(req *domain.UserStatsRequest, now time.Time) (*domain.UserStatsParams, error) {
+	tz := req.TimeZone
+	if tz == "" {
+		tz = defaultStatsTimeZone
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, domain.ErrInvalidTimeZone
+	}
+
+	// 預設統計到今天為止（含當日）
+	today := now.In(loc)
+	to := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	if req.To != "" {
+		date, err := time.ParseInLocation("2006-01-02", req.To, loc)
+		if err != nil {
+			return nil, domain.ErrInvalidDateRange
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+
+	from := to.AddDate(0, 0, -defaultStatsDays)
+	if req.From != "" {
+		date, err := time.ParseInLocation("2006-01-02", req.From, loc)
+		if err != nil {
+			return nil, domain.ErrInvalidDateRange
+		}
+		from = date
+	}
+
+	if !from.Before(to) || to.Sub(from) > maxStatsDays*24*time.Hour {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	return &domain.UserStatsParams{
+		From:     from,
+		To:       to,
+		Location: loc,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// tagSlugPattern 標籤識別碼格式：小寫英數字與底線
+var tagSlugPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// TagUseCase 標籤業務邏輯
+type TagUseCase struct {
+	tagRepo        TagRepository
+	restaurantRepo RestaurantRepository
+}
+
+// NewTagUseCase 建立標籤用例
+func NewTagUseCase(tagRepo TagRepository, restaurantRepo RestaurantRepository) *TagUseCase {
+	return &TagUseCase{
+		tagRepo:        tagRepo,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// ListTags 取得標籤清單
+func (uc *TagUseCase) ListTags(ctx context.Context, category domain.TagCategory) ([]domain.Tag, error) {
+	if category != "" && !category.IsValid() {
+		return nil, domain.ErrInvalidTagCategory
+	}
+
+	tags, err := uc.tagRepo.List(ctx, category)
+	if err != nil {
+		logger.Error("取得標籤清單失敗", zap.Error(err))
+		return nil, errors.New("取得標籤清單失敗")
+	}
+
+	return tags, nil
+}
+
+// CreateTag 建立標籤（管理功能）
+func (uc *TagUseCase) CreateTag(ctx context.Context, req *domain.CreateTagRequest) (*domain.Tag, error) {
+	slugs := domain.NormalizeTagSlugs([]string{req.Slug})
+	if len(slugs) != 1 || !tagSlugPattern.MatchString(slugs[0]) {
+		return nil, domain.ErrInvalidTagSlug
+	}
+	if !req.Category.IsValid() {
+		return nil, domain.ErrInvalidTagCategory
+	}
+
+	tag := &domain.Tag{
+		Slug:     slugs[0],
+		Name:     req.Name,
+		Category: req.Category,
+	}
+
+	if err := uc.tagRepo.Create(ctx, tag); err != nil {
+		if errors.Is(err, domain.ErrTagExists) {
+			return nil, err
+		}
+		logger.Error("建立標籤失敗", zap.Error(err), zap.String("slug", tag.Slug))
+		return nil, errors.New("建立標籤失敗")
+	}
+
+	return tag, nil
+}
+
+// UpdateTag 更新標籤（管理功能）
+func (uc *TagUseCase) UpdateTag(ctx context.Context, id int, req *domain.UpdateTagRequest) (*domain.Tag, error) {
+	if !req.Category.IsValid() {
+		return nil, domain.ErrInvalidTagCategory
+	}
+
+	tag := &domain.Tag{
+		ID:       id,
+		Name:     req.Name,
+		Category: req.Category,
+	}
+
+	if err := uc.tagRepo.Update(ctx, tag); err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return nil, err
+		}
+		logger.Error("更新標籤失敗", zap.Error(err), zap.Int("tag_id", id))
+		return nil, errors.New("更新標籤失敗")
+	}
+
+	return tag, nil
+}
+
+// DeleteTag 刪除標籤（管理功能）
+func (uc *TagUseCase) DeleteTag(ctx context.Context, id int) error {
+	if err := uc.tagRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			return err
+		}
+		logger.Error("刪除標籤失敗", zap.Error(err), zap.Int("tag_id", id))
+		return errors.New("刪除標籤失敗")
+	}
+
+	return nil
+}
+
+// GetRestaurantTags 取得餐廳標籤
+func (uc *TagUseCase) GetRestaurantTags(ctx context.Context, restaurantID int) ([]domain.Tag, error) {
+	tags, err := uc.tagRepo.GetByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		logger.Error("取得餐廳標籤失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("取得餐廳標籤失敗")
+	}
+
+	return tags, nil
+}
+
+// SetRestaurantTags 設定餐廳標籤（管理功能）
+func (uc *TagUseCase) SetRestaurantTags(ctx context.Context, restaurantID int, req *domain.SetRestaurantTagsRequest) ([]domain.Tag, error) {
+	if _, err := uc.restaurantRepo.GetByID(ctx, restaurantID); err != nil {
+		logger.Error("餐廳不存在", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, domain.ErrRestaurantNotFound
+	}
+
+	slugs := domain.NormalizeTagSlugs(req.Tags)
+
+	tags := []domain.Tag{}
+	if len(slugs) > 0 {
+		found, err := uc.tagRepo.GetBySlugs(ctx, slugs)
+		if err != nil {
+			logger.Error("取得標籤失敗", zap.Error(err))
+			return nil, errors.New("設定餐廳標籤失敗")
+		}
+		if len(found) != len(slugs) {
+			return nil, domain.ErrUnknownTag
+		}
+		tags = found
+	}
+
+	tagIDs := make([]int, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	if err := uc.tagRepo.SetRestaurantTags(ctx, restaurantID, tagIDs); err != nil {
+		logger.Error("設定餐廳標籤失敗", zap.Error(err), zap.Int("restaurant_id", restaurantID))
+		return nil, errors.New("設定餐廳標籤失敗")
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// minCandidateWeight 候選餐廳的最低權重，避免任何餐廳完全無法被抽中
+const minCandidateWeight = 0.05
+
+// WeightingInput 計算候選權重所需的使用者資料
+type WeightingInput struct {
+	History           map[int]domain.RestaurantHistory // 依餐廳 ID 索引的遊戲歷史
+	CuisinePreference map[int]float64                  // 料理分類偏好比例（0-1），以料理分類 ID 為鍵
+	Now               time.Time
+}
+
+// WeightingStrategy 候選餐廳抽選權重策略
+type WeightingStrategy interface {
+	Name() string
+	Weigh(candidates []domain.RestaurantWithDistance, input *WeightingInput) []domain.CandidateWeight
+}
+
+// UniformWeighting 所有候選餐廳權重相同
+type UniformWeighting struct{}
+
+// Name 策略名稱
+func (UniformWeighting) Name() string {
+	return "uniform"
+}
+
+// Weigh 計算候選權重
+func (UniformWeighting) Weigh(candidates []domain.RestaurantWithDistance, input *WeightingInput) []domain.CandidateWeight {
+	weights := make([]domain.CandidateWeight, len(candidates))
+	for i, candidate := range candidates {
+		weights[i] = domain.CandidateWeight{
+			RestaurantID: candidate.ID,
+			Weight:       1,
+			WinDecay:     1,
+			VetoDecay:    1,
+			CuisineBoost: 1,
+		}
+	}
+	return weights
+}
+
+// NoveltyWeightingConfig 新鮮度權重設定
+type NoveltyWeightingConfig struct {
+	NoveltyBoost float64 // 從未出現過的餐廳權重倍數
+	WinPenalty   float64 // 剛被選中時的權重扣減比例（0-1）
+	VetoPenalty  float64 // 剛被否決時的權重扣減比例（0-1）
+	HalfLifeDays float64 // 扣減效果的半衰期（天）
+	CuisineBias  float64 // 偏好料理的加權強度，0 表示停用
+}
+
+// NoveltyWeighting 依使用者歷史提高新餐廳、降低近期選中或否決餐廳的權重
+type NoveltyWeighting struct {
+	config NoveltyWeightingConfig
+}
+
+// NewNoveltyWeighting 建立新鮮度權重策略
+func NewNoveltyWeighting(config NoveltyWeightingConfig) *NoveltyWeighting {
+	if config.HalfLifeDays <= 0 {
+		config.HalfLifeDays = 7
+	}
+	return &NoveltyWeighting{
+		config: config,
+	}
+}
+
+// Name 策略名稱
+func (w *NoveltyWeighting) Name() string {
+	return "novelty"
+}
+
+// Weigh 計算候選權重
+func (w *NoveltyWeighting) Weigh(candidates []domain.RestaurantWithDistance, input *WeightingInput) []domain.CandidateWeight {
+	weights := make([]domain.CandidateWeight, len(candidates))
+	for i, candidate := range candidates {
+		weight := domain.CandidateWeight{
+			RestaurantID: candidate.ID,
+			WinDecay:     1,
+			VetoDecay:    1,
+			CuisineBoost: 1,
+		}
+
+		history, served := input.History[candidate.ID]
+		if !served || history.ServedCount == 0 {
+			weight.Novel = true
+		}
+
+		if history.LastWonAt != nil {
+			weight.WinDecay = 1 - w.config.WinPenalty*w.recency(*history.LastWonAt, input.Now)
+		}
+		if history.LastVetoedAt != nil {
+			weight.VetoDecay = 1 - w.config.VetoPenalty*w.recency(*history.LastVetoedAt, input.Now)
+		}
+		if w.config.CuisineBias > 0 && candidate.CuisineID != nil {
+			weight.CuisineBoost = 1 + w.config.CuisineBias*input.CuisinePreference[*candidate.CuisineID]
+		}
+
+		weight.Weight = weight.WinDecay * weight.VetoDecay * weight.CuisineBoost
+		if weight.Novel && w.config.NoveltyBoost > 0 {
+			weight.Weight *= w.config.NoveltyBoost
+		}
+		weight.Weight = math.Max(weight.Weight, minCandidateWeight)
+
+		weights[i] = weight
+	}
+	return weights
+}
+
+// recency 計算事件的新近程度，剛發生為 1，每經過一個半衰期減半
+func (w *NoveltyWeighting) recency(at, now time.Time) float64 {
+	ageDays := now.Sub(at).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return math.Exp(-ageDays * math.Ln2 / w.config.HalfLifeDays)
+}
+
+// NewWeightingStrategy 依名稱建立權重策略
+func NewWeightingStrategy(name string, novelty NoveltyWeightingConfig) (WeightingStrategy, error) {
+	switch name {
+	case "", "uniform":
+		return UniformWeighting{}, nil
+	case "novelty":
+		return NewNoveltyWeighting(novelty), nil
+	default:
+		return nil, fmt.Errorf("未知的權重策略: %s", name)
+	}
+}
+
+// weightedDraw 依權重進行不放回抽樣並回傳排序後的餐廳（Efraimidis-Spirakis 演算法）
+func weightedDraw(candidates []domain.RestaurantWithDistance, weights []domain.CandidateWeight, limit int) []domain.RestaurantWithDistance {
+	type keyed struct {
+		restaurant domain.RestaurantWithDistance
+		key        float64
+	}
+
+	items := make([]keyed, len(candidates))
+	for i, candidate := range candidates {
+		items[i] = keyed{
+			restaurant: candidate,
+			key:        math.Pow(rand.Float64(), 1/weights[i].Weight),
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key > items[j].key
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	drawn := make([]domain.RestaurantWithDistance, len(items))
+	for i, item := range items {
+		drawn[i] = item.restaurant
+	}
+	return drawn
+}
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Task 排程執行的工作，回傳處理筆數
+type Task func(ctx context.Context) (int, error)
+
+// PeriodicWorker 依固定間隔重複執行工作的排程
+type PeriodicWorker struct {
+	name     string
+	task     Task
+	interval time.Duration
+}
+
+// NewPeriodicWorker 建立定期排程工作
+func NewPeriodicWorker(name string, task Task, interval time.Duration) *PeriodicWorker {
+	return &PeriodicWorker{
+		name:     name,
+		task:     task,
+		interval: interval,
+	}
+}
+
+// Start 啟動排程，立即執行一次後依間隔重複執行，直到 context 結束
+func (w *PeriodicWorker) Start(ctx context.Context) {
+	if w.interval <= 0 {
+		logger.Info("排程已停用", zap.String("worker", w.name))
+		return
+	}
+
+	logger.Info("排程啟動", zap.String("worker", w.name), zap.Duration("interval", w.interval))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info("排程停止", zap.String("worker", w.name))
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run 執行一次工作
+func (w *PeriodicWorker) run(ctx context.Context) {
+	count, err := w.task(ctx)
+	if err != nil {
+		logger.Error("排程執行失敗", zap.String("worker", w.name), zap.Error(err))
+		return
+	}
+	logger.Info("排程執行完成", zap.String("worker", w.name), zap.Int("count", count))
+}
@@ -0,0 +1,10 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_game_sessions_user_started;
+DROP INDEX IF EXISTS idx_game_session_restaurants_restaurant_id;
+
+-- 移除遊戲會話的新增欄位
+ALTER TABLE game_sessions
+DROP COLUMN IF EXISTS result_distance;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS game_session_restaurants;
@@ -0,0 +1,16 @@
+-- 記錄每場遊戲的候選餐廳名單
+CREATE TABLE IF NOT EXISTS game_session_restaurants (
+    session_id VARCHAR(36) NOT NULL REFERENCES game_sessions(id) ON DELETE CASCADE,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    distance DOUBLE PRECISION DEFAULT 0,
+    position INTEGER DEFAULT 0,
+    PRIMARY KEY (session_id, restaurant_id)
+);
+
+-- 記錄結果餐廳與起點的距離（公尺）
+ALTER TABLE game_sessions
+ADD COLUMN result_distance DOUBLE PRECISION;
+
+-- 建立索引以提升個人統計查詢效能
+CREATE INDEX idx_game_session_restaurants_restaurant_id ON game_session_restaurants(restaurant_id);
+CREATE INDEX idx_game_sessions_user_started ON game_sessions(user_id, started_at);
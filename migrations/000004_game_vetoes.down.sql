@@ -0,0 +1,6 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_game_session_restaurants_vetoed;
+
+-- 移除否決欄位
+ALTER TABLE game_session_restaurants
+DROP COLUMN IF EXISTS vetoed;
@@ -0,0 +1,6 @@
+-- 記錄使用者在遊戲中否決的餐廳
+ALTER TABLE game_session_restaurants
+ADD COLUMN vetoed BOOLEAN DEFAULT FALSE;
+
+-- 建立索引以提升候選餐廳歷史查詢效能
+CREATE INDEX idx_game_session_restaurants_vetoed ON game_session_restaurants(restaurant_id) WHERE vetoed = TRUE;
@@ -0,0 +1,7 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_search_logs_created_at;
+DROP INDEX IF EXISTS idx_search_logs_geohash;
+
+-- 刪除資料表（注意順序，先刪除有外鍵的表）
+DROP TABLE IF EXISTS search_expansions;
+DROP TABLE IF EXISTS search_logs;
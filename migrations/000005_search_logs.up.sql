@@ -0,0 +1,30 @@
+-- 建立餐廳搜尋記錄資料表（座標僅保留 geohash 格網中心，不記錄使用者）
+CREATE TABLE IF NOT EXISTS search_logs (
+    id SERIAL PRIMARY KEY,
+    source VARCHAR(20) NOT NULL,
+    geohash VARCHAR(12) NOT NULL,
+    latitude DECIMAL(10, 8) NOT NULL,
+    longitude DECIMAL(11, 8) NOT NULL,
+    requested_radius INTEGER NOT NULL,
+    final_radius INTEGER NOT NULL,
+    expansion_count INTEGER DEFAULT 0,
+    result_count INTEGER DEFAULT 0,
+    external_used BOOLEAN DEFAULT FALSE,
+    succeeded BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立搜尋半徑擴大記錄資料表
+CREATE TABLE IF NOT EXISTS search_expansions (
+    id SERIAL PRIMARY KEY,
+    search_log_id INTEGER NOT NULL REFERENCES search_logs(id) ON DELETE CASCADE,
+    step INTEGER NOT NULL,
+    radius INTEGER NOT NULL,
+    local_count INTEGER DEFAULT 0,
+    external_count INTEGER DEFAULT 0,
+    UNIQUE(search_log_id, step)
+);
+
+-- 建立索引以提升美食沙漠分析查詢效能
+CREATE INDEX idx_search_logs_geohash ON search_logs(geohash);
+CREATE INDEX idx_search_logs_created_at ON search_logs(created_at);
@@ -0,0 +1,5 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_coverage_cells_center;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS coverage_cells;
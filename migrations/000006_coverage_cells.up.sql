@@ -0,0 +1,15 @@
+-- 建立美食沙漠覆蓋格網資料表（由排程工作定期重建）
+CREATE TABLE IF NOT EXISTS coverage_cells (
+    geohash VARCHAR(12) PRIMARY KEY,
+    center_latitude DECIMAL(10, 8) NOT NULL,
+    center_longitude DECIMAL(11, 8) NOT NULL,
+    restaurant_count INTEGER DEFAULT 0,
+    cuisine_counts JSONB DEFAULT '{}'::jsonb,
+    search_count INTEGER DEFAULT 0,
+    failed_search_count INTEGER DEFAULT 0,
+    expanded_search_count INTEGER DEFAULT 0,
+    refreshed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立索引以提升地圖範圍查詢效能
+CREATE INDEX idx_coverage_cells_center ON coverage_cells(center_latitude, center_longitude);
@@ -0,0 +1,10 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_game_activity_cells_restaurant;
+DROP INDEX IF EXISTS idx_game_activity_cells_kind_date;
+DROP INDEX IF EXISTS idx_game_sessions_started_at;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS game_activity_cells;
+
+-- 移除遊戲開始位置欄位
+ALTER TABLE game_sessions DROP COLUMN IF EXISTS start_geohash;
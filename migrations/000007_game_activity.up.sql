@@ -0,0 +1,20 @@
+-- 記錄遊戲開始位置（僅保存粗化後的 geohash 格網，不保存原始座標）
+ALTER TABLE game_sessions ADD COLUMN IF NOT EXISTS start_geohash VARCHAR(12);
+
+-- 建立遊戲活動熱度每日彙總資料表
+-- kind: start（遊戲開始位置）、win（獲選餐廳位置）、origin（獲選餐廳的玩家來源位置）
+-- restaurant_id: 僅 origin 使用，其餘為 0
+CREATE TABLE IF NOT EXISTS game_activity_cells (
+    activity_date DATE NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    restaurant_id INTEGER NOT NULL DEFAULT 0,
+    geohash VARCHAR(12) NOT NULL,
+    count INTEGER DEFAULT 0,
+    refreshed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (activity_date, kind, restaurant_id, geohash)
+);
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_game_sessions_started_at ON game_sessions(started_at);
+CREATE INDEX idx_game_activity_cells_kind_date ON game_activity_cells(kind, activity_date);
+CREATE INDEX idx_game_activity_cells_restaurant ON game_activity_cells(restaurant_id, activity_date) WHERE restaurant_id > 0;
@@ -0,0 +1,2 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurants_lat_lng;
@@ -0,0 +1,2 @@
+-- 建立索引以提升地圖範圍查詢效能
+CREATE INDEX IF NOT EXISTS idx_restaurants_lat_lng ON restaurants(latitude, longitude) WHERE is_active = TRUE;
@@ -0,0 +1,11 @@
+-- 刪除函式
+DROP FUNCTION IF EXISTS restaurant_open_at(INTEGER, TEXT, TIMESTAMPTZ);
+
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurant_opening_hours_restaurant_id;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS restaurant_opening_hours;
+
+-- 移除餐廳時區欄位
+ALTER TABLE restaurants DROP COLUMN IF EXISTS time_zone;
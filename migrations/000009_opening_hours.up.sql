@@ -0,0 +1,37 @@
+-- 餐廳所在時區（營業時間以此時區計算）
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Taipei';
+
+-- 建立餐廳營業時段資料表
+-- open_minute / close_minute 為一週內的分鐘數（星期日 00:00 為 0），close_minute 小於等於 open_minute 表示跨越週六午夜
+-- close_minute 為 NULL 表示 24 小時營業
+CREATE TABLE IF NOT EXISTS restaurant_opening_hours (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    open_minute INTEGER NOT NULL CHECK (open_minute >= 0 AND open_minute < 10080),
+    close_minute INTEGER CHECK (close_minute >= 0 AND close_minute < 10080),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_restaurant_opening_hours_restaurant_id ON restaurant_opening_hours(restaurant_id);
+
+-- 判斷餐廳在指定時間是否營業（未設定營業時間的餐廳視為營業中）
+CREATE OR REPLACE FUNCTION restaurant_open_at(p_restaurant_id INTEGER, p_time_zone TEXT, p_at TIMESTAMPTZ)
+RETURNS BOOLEAN AS $$
+    WITH local_time AS (
+        SELECT EXTRACT(DOW FROM p_at AT TIME ZONE p_time_zone)::INTEGER * 1440
+             + EXTRACT(HOUR FROM p_at AT TIME ZONE p_time_zone)::INTEGER * 60
+             + EXTRACT(MINUTE FROM p_at AT TIME ZONE p_time_zone)::INTEGER AS minute_of_week
+    )
+    SELECT NOT EXISTS (SELECT 1 FROM restaurant_opening_hours WHERE restaurant_id = p_restaurant_id)
+        OR EXISTS (
+            SELECT 1
+            FROM restaurant_opening_hours h, local_time l
+            WHERE h.restaurant_id = p_restaurant_id
+              AND (
+                  h.close_minute IS NULL
+                  OR (h.open_minute < h.close_minute AND l.minute_of_week >= h.open_minute AND l.minute_of_week < h.close_minute)
+                  OR (h.open_minute >= h.close_minute AND (l.minute_of_week >= h.open_minute OR l.minute_of_week < h.close_minute))
+              )
+        );
+$$ LANGUAGE SQL STABLE;
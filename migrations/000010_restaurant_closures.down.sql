@@ -0,0 +1,10 @@
+-- 刪除函式
+DROP FUNCTION IF EXISTS restaurant_closed_on(INTEGER, TEXT, TIMESTAMPTZ);
+
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurant_closure_reports_restaurant_date;
+DROP INDEX IF EXISTS idx_restaurant_closures_restaurant_dates;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS restaurant_closure_reports;
+DROP TABLE IF EXISTS restaurant_closures;
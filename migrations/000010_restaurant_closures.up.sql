@@ -0,0 +1,38 @@
+-- 建立餐廳臨時休業資料表（春節、整修等，日期以餐廳時區計算，含起訖日）
+-- source: admin（管理員設定）、user_report（使用者回報達門檻自動建立）
+CREATE TABLE IF NOT EXISTS restaurant_closures (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(255),
+    source VARCHAR(20) NOT NULL DEFAULT 'admin',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+-- 建立使用者休業回報資料表（每位使用者每天對同一間餐廳只能回報一次）
+CREATE TABLE IF NOT EXISTS restaurant_closure_reports (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    report_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(restaurant_id, user_id, report_date)
+);
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_restaurant_closures_restaurant_dates ON restaurant_closures(restaurant_id, start_date, end_date);
+CREATE INDEX idx_restaurant_closure_reports_restaurant_date ON restaurant_closure_reports(restaurant_id, report_date);
+
+-- 判斷餐廳在指定時間是否休業（以餐廳時區的日期計算）
+CREATE OR REPLACE FUNCTION restaurant_closed_on(p_restaurant_id INTEGER, p_time_zone TEXT, p_at TIMESTAMPTZ)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1
+        FROM restaurant_closures
+        WHERE restaurant_id = p_restaurant_id
+          AND (p_at AT TIME ZONE p_time_zone)::DATE BETWEEN start_date AND end_date
+    );
+$$ LANGUAGE SQL STABLE;
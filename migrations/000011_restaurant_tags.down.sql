@@ -0,0 +1,7 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurant_tags_tag_id;
+DROP INDEX IF EXISTS idx_tags_category;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS restaurant_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,39 @@
+-- 建立標籤資料表（受管理的標籤詞彙）
+-- category: dietary（飲食需求）、feature（設施服務）、meal（用餐時段）
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL CHECK (category IN ('dietary', 'feature', 'meal')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立餐廳標籤關聯資料表
+CREATE TABLE IF NOT EXISTS restaurant_tags (
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (restaurant_id, tag_id)
+);
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_tags_category ON tags(category);
+CREATE INDEX idx_restaurant_tags_tag_id ON restaurant_tags(tag_id);
+
+-- 預設標籤
+INSERT INTO tags (slug, name, category) VALUES
+    ('vegetarian', '素食', 'dietary'),
+    ('vegan', '全素', 'dietary'),
+    ('halal', '清真', 'dietary'),
+    ('gluten_free', '無麩質', 'dietary'),
+    ('wifi', '無線網路', 'feature'),
+    ('power_outlets', '插座', 'feature'),
+    ('pet_friendly', '寵物友善', 'feature'),
+    ('wheelchair_accessible', '無障礙空間', 'feature'),
+    ('breakfast', '早餐', 'meal'),
+    ('brunch', '早午餐', 'meal'),
+    ('lunch', '午餐', 'meal'),
+    ('dinner', '晚餐', 'meal'),
+    ('late_night', '宵夜', 'meal')
+ON CONFLICT (slug) DO NOTHING;
@@ -0,0 +1,17 @@
+-- 還原餐廳料理類型文字欄位（保留原本的文字，新建立的餐廳以中文名稱回填）
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS cuisine VARCHAR(100);
+
+UPDATE restaurants r
+SET cuisine = cn.name
+FROM cuisine_names cn
+WHERE cn.locale = 'zh-TW' AND cn.cuisine_id = r.cuisine_id AND r.cuisine IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_cuisine ON restaurants(cuisine);
+
+DROP INDEX IF EXISTS idx_restaurants_cuisine_id;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS cuisine_id;
+
+-- 刪除料理分類資料表
+DROP TABLE IF EXISTS cuisine_provider_types;
+DROP TABLE IF EXISTS cuisine_names;
+DROP TABLE IF EXISTS cuisines;
@@ -0,0 +1,129 @@
+-- 建立料理分類資料表（階層式，例如 亞洲料理 › 日式料理 › 拉麵）
+CREATE TABLE IF NOT EXISTS cuisines (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(50) UNIQUE NOT NULL,
+    parent_id INTEGER REFERENCES cuisines(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立料理分類多語系名稱資料表
+CREATE TABLE IF NOT EXISTS cuisine_names (
+    cuisine_id INTEGER NOT NULL REFERENCES cuisines(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (cuisine_id, locale)
+);
+
+-- 建立外部資料來源地點類型對應資料表
+CREATE TABLE IF NOT EXISTS cuisine_provider_types (
+    provider VARCHAR(20) NOT NULL,
+    provider_type VARCHAR(100) NOT NULL,
+    cuisine_id INTEGER NOT NULL REFERENCES cuisines(id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, provider_type)
+);
+
+CREATE INDEX idx_cuisines_parent_id ON cuisines(parent_id);
+
+-- 預設料理分類（先建立上層分類）
+INSERT INTO cuisines (slug) VALUES
+    ('asian'), ('western'), ('latin_american'), ('seafood'), ('fast_food'), ('cafe'), ('bakery'), ('bar')
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO cuisines (slug, parent_id)
+SELECT child.slug, parent.id
+FROM (VALUES
+    ('chinese', 'asian'),
+    ('japanese', 'asian'),
+    ('korean', 'asian'),
+    ('thai', 'asian'),
+    ('vietnamese', 'asian'),
+    ('indian', 'asian'),
+    ('italian', 'western'),
+    ('french', 'western'),
+    ('american', 'western'),
+    ('mexican', 'latin_american')
+) AS child(slug, parent_slug)
+JOIN cuisines parent ON parent.slug = child.parent_slug
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO cuisines (slug, parent_id)
+SELECT child.slug, parent.id
+FROM (VALUES
+    ('ramen', 'japanese'),
+    ('sushi', 'japanese'),
+    ('pizza', 'italian'),
+    ('steakhouse', 'american')
+) AS child(slug, parent_slug)
+JOIN cuisines parent ON parent.slug = child.parent_slug
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO cuisine_names (cuisine_id, locale, name)
+SELECT c.id, n.locale, n.name
+FROM (VALUES
+    ('asian', 'zh-TW', '亞洲料理'), ('asian', 'en', 'Asian'),
+    ('western', 'zh-TW', '西式料理'), ('western', 'en', 'Western'),
+    ('latin_american', 'zh-TW', '拉丁美洲料理'), ('latin_american', 'en', 'Latin American'),
+    ('seafood', 'zh-TW', '海鮮'), ('seafood', 'en', 'Seafood'),
+    ('fast_food', 'zh-TW', '快餐'), ('fast_food', 'en', 'Fast Food'),
+    ('cafe', 'zh-TW', '咖啡廳'), ('cafe', 'en', 'Cafe'),
+    ('bakery', 'zh-TW', '烘焙'), ('bakery', 'en', 'Bakery'),
+    ('bar', 'zh-TW', '酒吧'), ('bar', 'en', 'Bar'),
+    ('chinese', 'zh-TW', '中式料理'), ('chinese', 'en', 'Chinese'),
+    ('japanese', 'zh-TW', '日式料理'), ('japanese', 'en', 'Japanese'),
+    ('korean', 'zh-TW', '韓式料理'), ('korean', 'en', 'Korean'),
+    ('thai', 'zh-TW', '泰式料理'), ('thai', 'en', 'Thai'),
+    ('vietnamese', 'zh-TW', '越南料理'), ('vietnamese', 'en', 'Vietnamese'),
+    ('indian', 'zh-TW', '印度料理'), ('indian', 'en', 'Indian'),
+    ('italian', 'zh-TW', '義式料理'), ('italian', 'en', 'Italian'),
+    ('french', 'zh-TW', '法式料理'), ('french', 'en', 'French'),
+    ('american', 'zh-TW', '美式料理'), ('american', 'en', 'American'),
+    ('mexican', 'zh-TW', '墨西哥料理'), ('mexican', 'en', 'Mexican'),
+    ('ramen', 'zh-TW', '拉麵'), ('ramen', 'en', 'Ramen'),
+    ('sushi', 'zh-TW', '壽司'), ('sushi', 'en', 'Sushi'),
+    ('pizza', 'zh-TW', '披薩'), ('pizza', 'en', 'Pizza'),
+    ('steakhouse', 'zh-TW', '牛排'), ('steakhouse', 'en', 'Steakhouse')
+) AS n(slug, locale, name)
+JOIN cuisines c ON c.slug = n.slug
+ON CONFLICT (cuisine_id, locale) DO NOTHING;
+
+-- Google Places 地點類型對應（多個類型符合時取最細的分類）
+INSERT INTO cuisine_provider_types (provider, provider_type, cuisine_id)
+SELECT 'google', m.provider_type, c.id
+FROM (VALUES
+    ('chinese_restaurant', 'chinese'),
+    ('japanese_restaurant', 'japanese'),
+    ('ramen_restaurant', 'ramen'),
+    ('sushi_restaurant', 'sushi'),
+    ('korean_restaurant', 'korean'),
+    ('thai_restaurant', 'thai'),
+    ('vietnamese_restaurant', 'vietnamese'),
+    ('indian_restaurant', 'indian'),
+    ('italian_restaurant', 'italian'),
+    ('pizza_restaurant', 'pizza'),
+    ('french_restaurant', 'french'),
+    ('american_restaurant', 'american'),
+    ('steak_house', 'steakhouse'),
+    ('steakhouse', 'steakhouse'),
+    ('mexican_restaurant', 'mexican'),
+    ('seafood_restaurant', 'seafood'),
+    ('fast_food_restaurant', 'fast_food'),
+    ('cafe', 'cafe'),
+    ('coffee_shop', 'cafe'),
+    ('bakery', 'bakery'),
+    ('bar', 'bar')
+) AS m(provider_type, slug)
+JOIN cuisines c ON c.slug = m.slug
+ON CONFLICT (provider, provider_type) DO NOTHING;
+
+-- 餐廳改為參照料理分類，並以原本的中文名稱回填
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS cuisine_id INTEGER REFERENCES cuisines(id) ON DELETE SET NULL;
+
+UPDATE restaurants r
+SET cuisine_id = cn.cuisine_id
+FROM cuisine_names cn
+WHERE cn.locale = 'zh-TW' AND cn.name = r.cuisine;
+
+CREATE INDEX idx_restaurants_cuisine_id ON restaurants(cuisine_id);
+
+-- 原本的料理類型文字欄位暫時保留，無法對應的自訂料理類型在確認對應結果後由後續遷移封存並移除
@@ -0,0 +1,12 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_menu_item_tags_tag_id;
+DROP INDEX IF EXISTS idx_menu_items_section_id;
+DROP INDEX IF EXISTS idx_menu_items_restaurant_id;
+DROP INDEX IF EXISTS idx_menu_sections_restaurant_id;
+
+ALTER TABLE restaurants DROP COLUMN IF EXISTS derive_price_level;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS menu_item_tags;
+DROP TABLE IF EXISTS menu_items;
+DROP TABLE IF EXISTS menu_sections;
@@ -0,0 +1,41 @@
+-- 建立菜單分類資料表
+CREATE TABLE IF NOT EXISTS menu_sections (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立菜單品項資料表
+CREATE TABLE IF NOT EXISTS menu_items (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    section_id INTEGER REFERENCES menu_sections(id) ON DELETE SET NULL,
+    name VARCHAR(200) NOT NULL,
+    description TEXT,
+    price NUMERIC(10, 2) NOT NULL CHECK (price >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'TWD',
+    image_url VARCHAR(500),
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 建立菜單品項標籤關聯資料表（沿用餐廳標籤字彙）
+CREATE TABLE IF NOT EXISTS menu_item_tags (
+    menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (menu_item_id, tag_id)
+);
+
+-- 價位等級是否由菜單價格計算
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS derive_price_level BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_menu_sections_restaurant_id ON menu_sections(restaurant_id, position);
+CREATE INDEX idx_menu_items_restaurant_id ON menu_items(restaurant_id, price);
+CREATE INDEX idx_menu_items_section_id ON menu_items(section_id);
+CREATE INDEX idx_menu_item_tags_tag_id ON menu_item_tags(tag_id);
@@ -0,0 +1,15 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurants_blended_rating;
+DROP INDEX IF EXISTS idx_reviews_flagged;
+DROP INDEX IF EXISTS idx_reviews_user_id;
+DROP INDEX IF EXISTS idx_reviews_restaurant_id;
+
+-- 刪除餐廳評論彙總欄位
+ALTER TABLE restaurants DROP COLUMN IF EXISTS blended_rating;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS review_rating;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS review_rating_sum;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS review_count;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS review_flags;
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,49 @@
+-- 建立使用者評論資料表（每位使用者對每間餐廳只有一則評論，可編輯）
+CREATE TABLE IF NOT EXISTS reviews (
+    id SERIAL PRIMARY KEY,
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    content TEXT,
+    photo_urls TEXT[] NOT NULL DEFAULT '{}',
+    visit_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'published' CHECK (status IN ('published', 'flagged', 'hidden')),
+    flag_count INTEGER NOT NULL DEFAULT 0,
+    moderated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMP,
+    moderation_note VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (restaurant_id, user_id)
+);
+
+-- 建立評論檢舉資料表（每位使用者對每則評論只能檢舉一次）
+CREATE TABLE IF NOT EXISTS review_flags (
+    review_id INTEGER NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id, user_id)
+);
+
+-- 餐廳評論彙總（新增、編輯、刪除與審核評論時遞增更新）
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS review_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS review_rating_sum INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS review_rating DECIMAL(3, 2)
+    GENERATED ALWAYS AS (CASE WHEN review_count > 0 THEN ROUND(review_rating_sum::NUMERIC / review_count, 2) END) STORED;
+
+-- 綜合評分：將外部評分視為 10 則評論的先驗值，與使用者評論加權平均
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS blended_rating DECIMAL(3, 2)
+    GENERATED ALWAYS AS (
+        CASE
+            WHEN review_count = 0 THEN COALESCE(rating, 0)
+            WHEN COALESCE(rating, 0) = 0 THEN ROUND(review_rating_sum::NUMERIC / review_count, 2)
+            ELSE ROUND((rating * 10 + review_rating_sum) / (10 + review_count), 2)
+        END
+    ) STORED;
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_reviews_restaurant_id ON reviews(restaurant_id, created_at DESC);
+CREATE INDEX idx_reviews_user_id ON reviews(user_id);
+CREATE INDEX idx_reviews_flagged ON reviews(flag_count DESC, updated_at) WHERE status = 'flagged';
+CREATE INDEX idx_restaurants_blended_rating ON restaurants(blended_rating);
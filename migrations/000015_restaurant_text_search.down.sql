@@ -0,0 +1,9 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurant_aliases_alias_trgm;
+DROP INDEX IF EXISTS idx_restaurants_address_trgm;
+DROP INDEX IF EXISTS idx_restaurants_name_trgm;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS restaurant_aliases;
+
+-- pg_trgm 擴充套件可能被其他物件使用，保留不刪除
@@ -0,0 +1,15 @@
+-- 啟用三元組模糊比對擴充套件
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 建立餐廳別名資料表（羅馬拼音、英文名稱或常用暱稱，供文字搜尋比對）
+CREATE TABLE IF NOT EXISTS restaurant_aliases (
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    alias VARCHAR(200) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (restaurant_id, alias)
+);
+
+-- 建立三元組索引以支援模糊比對與 ILIKE 部分比對
+CREATE INDEX idx_restaurants_name_trgm ON restaurants USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_restaurants_address_trgm ON restaurants USING GIN (address gin_trgm_ops);
+CREATE INDEX idx_restaurant_aliases_alias_trgm ON restaurant_aliases USING GIN (alias gin_trgm_ops);
@@ -0,0 +1,5 @@
+-- 刪除欄位
+ALTER TABLE restaurants DROP COLUMN IF EXISTS locked_fields;
+
+-- 刪除唯一限制（停用的重複餐廳、清除的外部 ID 與移至保留餐廳的使用者資料不會還原）
+ALTER TABLE restaurants DROP CONSTRAINT IF EXISTS restaurants_google_id_key;
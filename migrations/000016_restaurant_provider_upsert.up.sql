@@ -0,0 +1,111 @@
+-- 外部資料來源匯入的餐廳以 google_id 去重
+
+-- 手動建立的餐廳以 NULL 表示沒有外部 ID，避免空字串違反唯一限制
+UPDATE restaurants SET google_id = NULL WHERE google_id = '';
+
+-- 先前重複匯入的餐廳：保留最早建立的一筆，其餘餐廳的使用者資料移至保留的餐廳後停用並清除外部 ID
+CREATE TEMP TABLE provider_duplicates AS
+SELECT id AS duplicate_id, FIRST_VALUE(id) OVER (PARTITION BY google_id ORDER BY id) AS survivor_id
+FROM restaurants
+WHERE google_id IS NOT NULL;
+
+DELETE FROM provider_duplicates WHERE duplicate_id = survivor_id;
+
+-- 同一使用者（或遊戲、標籤）在保留的餐廳已有資料時不移動，多間重複餐廳只移動最早的一筆
+UPDATE favorite_restaurants t
+SET restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.restaurant_id = d.duplicate_id
+  AND t.id IN (
+      SELECT DISTINCT ON (d2.survivor_id, f.user_id) f.id
+      FROM favorite_restaurants f
+      JOIN provider_duplicates d2 ON d2.duplicate_id = f.restaurant_id
+      ORDER BY d2.survivor_id, f.user_id, f.id
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM favorite_restaurants s
+      WHERE s.restaurant_id = d.survivor_id AND s.user_id = t.user_id
+  );
+
+UPDATE reviews t
+SET restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.restaurant_id = d.duplicate_id
+  AND t.id IN (
+      SELECT DISTINCT ON (d2.survivor_id, rv.user_id) rv.id
+      FROM reviews rv
+      JOIN provider_duplicates d2 ON d2.duplicate_id = rv.restaurant_id
+      ORDER BY d2.survivor_id, rv.user_id, rv.id
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM reviews s
+      WHERE s.restaurant_id = d.survivor_id AND s.user_id = t.user_id
+  );
+
+UPDATE game_session_restaurants t
+SET restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.restaurant_id = d.duplicate_id
+  AND (t.session_id, t.restaurant_id) IN (
+      SELECT DISTINCT ON (d2.survivor_id, g.session_id) g.session_id, g.restaurant_id
+      FROM game_session_restaurants g
+      JOIN provider_duplicates d2 ON d2.duplicate_id = g.restaurant_id
+      ORDER BY d2.survivor_id, g.session_id, g.restaurant_id
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM game_session_restaurants s
+      WHERE s.restaurant_id = d.survivor_id AND s.session_id = t.session_id
+  );
+
+UPDATE restaurant_tags t
+SET restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.restaurant_id = d.duplicate_id
+  AND (t.tag_id, t.restaurant_id) IN (
+      SELECT DISTINCT ON (d2.survivor_id, rt.tag_id) rt.tag_id, rt.restaurant_id
+      FROM restaurant_tags rt
+      JOIN provider_duplicates d2 ON d2.duplicate_id = rt.restaurant_id
+      ORDER BY d2.survivor_id, rt.tag_id, rt.restaurant_id
+  )
+  AND NOT EXISTS (
+      SELECT 1 FROM restaurant_tags s
+      WHERE s.restaurant_id = d.survivor_id AND s.tag_id = t.tag_id
+  );
+
+UPDATE game_sessions t
+SET result_restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.result_restaurant_id = d.duplicate_id;
+
+UPDATE advertisements t
+SET restaurant_id = d.survivor_id
+FROM provider_duplicates d
+WHERE t.restaurant_id = d.duplicate_id;
+
+-- 評論移動後重新計算評分彙總
+UPDATE restaurants r
+SET review_count = s.count, review_rating_sum = s.rating_sum
+FROM (
+    SELECT x.id, COUNT(rv.id) AS count, COALESCE(SUM(rv.rating), 0) AS rating_sum
+    FROM (
+        SELECT duplicate_id AS id FROM provider_duplicates
+        UNION
+        SELECT survivor_id FROM provider_duplicates
+    ) x
+    LEFT JOIN reviews rv ON rv.restaurant_id = x.id AND rv.status <> 'hidden'
+    GROUP BY x.id
+) s
+WHERE r.id = s.id;
+
+UPDATE restaurants r
+SET is_active = FALSE, google_id = NULL, updated_at = CURRENT_TIMESTAMP
+FROM provider_duplicates d
+WHERE r.id = d.duplicate_id;
+
+DROP TABLE provider_duplicates;
+
+ALTER TABLE restaurants ADD CONSTRAINT restaurants_google_id_key UNIQUE (google_id);
+
+-- 管理員修改過的欄位，外部資料同步時不覆寫
+-- 可用值：name、address、location、phone、rating、price_level、cuisine、image_url
+ALTER TABLE restaurants ADD COLUMN locked_fields TEXT[] NOT NULL DEFAULT '{}';
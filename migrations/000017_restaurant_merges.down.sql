@@ -0,0 +1,13 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurant_merges_merged_at;
+DROP INDEX IF EXISTS idx_restaurant_merges_active_duplicate;
+DROP INDEX IF EXISTS idx_restaurant_duplicate_candidates_duplicate_id;
+DROP INDEX IF EXISTS idx_restaurant_duplicate_candidates_status;
+
+-- 刪除欄位（已合併的餐廳維持停用）
+ALTER TABLE restaurants DROP COLUMN IF EXISTS merged_into_id;
+
+-- 刪除資料表
+DROP TABLE IF EXISTS restaurant_merge_moves;
+DROP TABLE IF EXISTS restaurant_merges;
+DROP TABLE IF EXISTS restaurant_duplicate_candidates;
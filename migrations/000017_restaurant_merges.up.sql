@@ -0,0 +1,43 @@
+-- 疑似重複的餐廳（名稱相似且座標相近），由排程偵測後交由管理員合併或略過
+-- restaurant_id 固定小於 duplicate_id，避免同一組重複記錄
+CREATE TABLE IF NOT EXISTS restaurant_duplicate_candidates (
+    restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    duplicate_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    name_similarity DECIMAL(4, 3) NOT NULL,
+    distance DOUBLE PRECISION NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dismissed', 'merged')),
+    detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP,
+    PRIMARY KEY (restaurant_id, duplicate_id),
+    CHECK (restaurant_id < duplicate_id)
+);
+
+-- 餐廳合併記錄（稽核與復原用），合併後重複的餐廳停用並指向保留的餐廳
+CREATE TABLE IF NOT EXISTS restaurant_merges (
+    id SERIAL PRIMARY KEY,
+    survivor_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    duplicate_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+    moved_google_id VARCHAR(255), -- 由重複餐廳移至保留餐廳的外部 ID
+    merged_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    merged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    undone_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    undone_at TIMESTAMP
+);
+
+-- 合併時移動的資料列，復原時依此移回
+-- entity: favorite、game_result、game_candidate、advertisement、review、tag
+CREATE TABLE IF NOT EXISTS restaurant_merge_moves (
+    merge_id INTEGER NOT NULL REFERENCES restaurant_merges(id) ON DELETE CASCADE,
+    entity VARCHAR(30) NOT NULL,
+    entity_key VARCHAR(64) NOT NULL,
+    PRIMARY KEY (merge_id, entity, entity_key)
+);
+
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS merged_into_id INTEGER REFERENCES restaurants(id) ON DELETE SET NULL;
+
+-- 建立索引以提升查詢效能
+CREATE INDEX idx_restaurant_duplicate_candidates_status ON restaurant_duplicate_candidates(status, name_similarity DESC);
+CREATE INDEX idx_restaurant_duplicate_candidates_duplicate_id ON restaurant_duplicate_candidates(duplicate_id);
+CREATE UNIQUE INDEX idx_restaurant_merges_active_duplicate ON restaurant_merges(duplicate_id) WHERE undone_at IS NULL;
+CREATE INDEX idx_restaurant_merges_merged_at ON restaurant_merges(merged_at DESC);
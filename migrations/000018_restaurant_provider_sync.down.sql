@@ -0,0 +1,8 @@
+-- 刪除索引
+DROP INDEX IF EXISTS idx_restaurants_provider_sync;
+
+-- 刪除欄位
+ALTER TABLE restaurants DROP COLUMN IF EXISTS provider_sync_error;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS provider_sync_status;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS provider_sync_attempted_at;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS provider_synced_at;
@@ -0,0 +1,10 @@
+-- 外部資料來源同步狀態（排程定期重新取得 Google Places 詳細資訊）
+-- provider_sync_status: ok（已同步）、closed_temporarily（暫停營業）、closed（永久歇業，已停用）、not_found（外部已查無此地點）、error（同步失敗）
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS provider_synced_at TIMESTAMP; -- NULL 表示尚未取得詳細資訊
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS provider_sync_attempted_at TIMESTAMP;
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS provider_sync_status VARCHAR(30);
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS provider_sync_error TEXT;
+
+-- 建立索引以提升待同步餐廳查詢效能
+CREATE INDEX idx_restaurants_provider_sync ON restaurants(provider_synced_at, provider_sync_attempted_at)
+    WHERE google_id IS NOT NULL AND is_active = TRUE;
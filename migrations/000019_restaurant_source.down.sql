@@ -0,0 +1,5 @@
+-- 刪除其他資料來源的料理分類對應
+DELETE FROM cuisine_provider_types WHERE provider IN ('osm', 'foursquare');
+
+-- 刪除欄位
+ALTER TABLE restaurants DROP COLUMN IF EXISTS source;
@@ -0,0 +1,42 @@
+-- 餐廳資料來源：manual（管理員建立）、google、osm、foursquare
+-- 非 Google 資料來源的外部 ID 以「來源:」前綴存於 google_id，共用同一個唯一索引
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'manual';
+
+UPDATE restaurants SET source = 'google' WHERE google_id IS NOT NULL;
+
+-- OpenStreetMap 的 cuisine 與 amenity 標籤對應料理分類
+INSERT INTO cuisine_provider_types (provider, provider_type, cuisine_id)
+SELECT 'osm', m.provider_type, c.id
+FROM (VALUES
+    ('chinese', 'chinese'),
+    ('japanese', 'japanese'),
+    ('ramen', 'ramen'),
+    ('sushi', 'sushi'),
+    ('korean', 'korean'),
+    ('thai', 'thai'),
+    ('vietnamese', 'vietnamese'),
+    ('indian', 'indian'),
+    ('italian', 'italian'),
+    ('pizza', 'pizza'),
+    ('french', 'french'),
+    ('american', 'american'),
+    ('steak_house', 'steakhouse'),
+    ('mexican', 'mexican'),
+    ('seafood', 'seafood'),
+    ('burger', 'fast_food'),
+    ('fast_food', 'fast_food'),
+    ('coffee_shop', 'cafe'),
+    ('cafe', 'cafe'),
+    ('bakery', 'bakery'),
+    ('bar', 'bar'),
+    ('pub', 'bar')
+) AS m(provider_type, slug)
+JOIN cuisines c ON c.slug = m.slug
+ON CONFLICT (provider, provider_type) DO NOTHING;
+
+-- Foursquare 分類名稱轉為小寫底線格式後與 Google 地點類型相同
+INSERT INTO cuisine_provider_types (provider, provider_type, cuisine_id)
+SELECT 'foursquare', provider_type, cuisine_id
+FROM cuisine_provider_types
+WHERE provider = 'google'
+ON CONFLICT (provider, provider_type) DO NOTHING;
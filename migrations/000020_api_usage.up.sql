@@ -0,0 +1,10 @@
+-- 外部 API 請求用量（每日與每月計數，用於額度控管）
+-- period: day（period_start 為當日，UTC）或 month（period_start 為當月第一天，UTC）
+CREATE TABLE IF NOT EXISTS api_usage (
+    provider VARCHAR(20) NOT NULL,
+    period VARCHAR(10) NOT NULL,
+    period_start DATE NOT NULL,
+    request_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, period, period_start)
+);
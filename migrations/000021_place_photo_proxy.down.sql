@@ -0,0 +1,3 @@
+-- 無法還原：代理路徑不含 API 金鑰，且金鑰不應再寫回資料庫
+-- 需要時重新同步外部資料即可取得原始照片網址
+SELECT 1;
@@ -0,0 +1,15 @@
+-- 餐廳照片改由 /media/places/{照片參照} 代理，原本的 Google 照片網址含 API 金鑰
+-- 舊版 Places API：photoreference 參數即為照片參照
+UPDATE restaurants
+SET image_url = '/media/places/' || substring(image_url from '[?&]photoreference=([A-Za-z0-9_-]+)')
+WHERE image_url LIKE 'https://maps.googleapis.com/maps/api/place/photo?%'
+  AND image_url ~ '[?&]photoreference=[A-Za-z0-9_-]+';
+
+-- Places API (New)：places/{地點 ID}/photos/{照片 ID} 轉換為「地點 ID.照片 ID」
+UPDATE restaurants
+SET image_url = regexp_replace(
+        image_url,
+        '^https://places\.googleapis\.com/v1/places/([A-Za-z0-9_-]+)/photos/([A-Za-z0-9_-]+)/media(\?.*)?$',
+        '/media/places/\1.\2'
+    )
+WHERE image_url ~ '^https://places\.googleapis\.com/v1/places/[A-Za-z0-9_-]+/photos/[A-Za-z0-9_-]+/media(\?.*)?$';
@@ -0,0 +1,3 @@
+-- 照片代理只下載餐廳實際使用的照片，以圖片網址查詢餐廳
+-- 網址長度不固定，使用雜湊索引避免 B-tree 的索引項目大小限制
+CREATE INDEX IF NOT EXISTS idx_restaurants_image_url ON restaurants USING hash (image_url);
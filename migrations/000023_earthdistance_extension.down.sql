@@ -0,0 +1,2 @@
+-- 不移除擴充套件：000001 建立的位置索引依賴 earthdistance
+SELECT 1;
@@ -0,0 +1,6 @@
+-- 附近餐廳查詢使用 earth_box 與 ll_to_earth，需要 cube 與 earthdistance 擴充套件
+-- 已建立的資料庫可能是手動安裝擴充套件，這裡補上並確保位置索引存在
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_location ON restaurants USING gist(ll_to_earth(latitude, longitude));
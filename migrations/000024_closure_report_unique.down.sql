@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_restaurant_closures_user_report_day;
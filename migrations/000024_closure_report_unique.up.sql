@@ -0,0 +1,12 @@
+-- 使用者回報自動建立的休業記錄每間餐廳每天只保留一筆，避免同時達門檻的回報重複建立
+DELETE FROM restaurant_closures c
+USING restaurant_closures d
+WHERE c.source = 'user_report'
+  AND d.source = 'user_report'
+  AND c.restaurant_id = d.restaurant_id
+  AND c.start_date = d.start_date
+  AND c.id > d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_restaurant_closures_user_report_day
+    ON restaurant_closures(restaurant_id, start_date)
+    WHERE source = 'user_report';
@@ -0,0 +1,16 @@
+-- 還原餐廳料理類型文字欄位：優先使用封存的原始文字，其餘以中文名稱回填
+ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS cuisine VARCHAR(100);
+
+UPDATE restaurants r
+SET cuisine = a.cuisine
+FROM restaurant_cuisine_archive a
+WHERE a.restaurant_id = r.id;
+
+UPDATE restaurants r
+SET cuisine = cn.name
+FROM cuisine_names cn
+WHERE cn.locale = 'zh-TW' AND cn.cuisine_id = r.cuisine_id AND r.cuisine IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_restaurants_cuisine ON restaurants(cuisine);
+
+DROP TABLE IF EXISTS restaurant_cuisine_archive;
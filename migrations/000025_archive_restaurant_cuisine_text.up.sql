@@ -0,0 +1,20 @@
+-- 封存無法對應料理分類的餐廳料理類型文字，再移除舊的文字欄位
+-- 封存的資料可用來補建料理分類或手動修正 cuisine_id
+CREATE TABLE IF NOT EXISTS restaurant_cuisine_archive (
+    restaurant_id INTEGER PRIMARY KEY REFERENCES restaurants(id) ON DELETE CASCADE,
+    cuisine VARCHAR(100) NOT NULL,
+    archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO restaurant_cuisine_archive (restaurant_id, cuisine)
+SELECT r.id, r.cuisine
+FROM restaurants r
+WHERE r.cuisine IS NOT NULL AND r.cuisine <> ''
+  AND NOT EXISTS (
+      SELECT 1 FROM cuisine_names cn
+      WHERE cn.locale = 'zh-TW' AND cn.cuisine_id = r.cuisine_id AND cn.name = r.cuisine
+  )
+ON CONFLICT (restaurant_id) DO NOTHING;
+
+DROP INDEX IF EXISTS idx_restaurants_cuisine;
+ALTER TABLE restaurants DROP COLUMN IF EXISTS cuisine;
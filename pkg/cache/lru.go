@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// lruEntry LRU 快取項目
+type lruEntry struct {
+	key         string
+	restaurants []domain.Restaurant
+	expiresAt   time.Time
+}
+
+// LRUPlaceCache 記憶體內的外部地點搜尋快取，超過容量時淘汰最久未使用的項目
+type LRUPlaceCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // 最近使用的項目在前
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+// NewLRUPlaceCache 建立記憶體內的外部地點搜尋快取
+func NewLRUPlaceCache(capacity int) *LRUPlaceCache {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &LRUPlaceCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get 取得快取的搜尋結果，過期的項目視為不存在
+func (c *LRUPlaceCache) Get(ctx context.Context, key string) ([]domain.Restaurant, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	return append([]domain.Restaurant(nil), entry.restaurants...), true, nil
+}
+
+// Set 儲存搜尋結果
+func (c *LRUPlaceCache) Set(ctx context.Context, key string, restaurants []domain.Restaurant, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{
+		key:         key,
+		restaurants: append([]domain.Restaurant(nil), restaurants...),
+		expiresAt:   c.now().Add(ttl),
+	}
+	if element, exists := c.entries[key]; exists {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// DiskPhotoCache 以本機檔案儲存的餐廳照片快取，檔名為快取鍵的 SHA-256，修改時間即下載時間
+type DiskPhotoCache struct {
+	dir string
+}
+
+// NewDiskPhotoCache 建立照片快取並確保目錄存在
+func NewDiskPhotoCache(dir string) (*DiskPhotoCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskPhotoCache{dir: dir}, nil
+}
+
+// Get 取得快取的照片，查無資料時回傳 false；是否過期由呼叫端依 FetchedAt 判斷
+func (c *DiskPhotoCache) Get(ctx context.Context, key string) (*domain.PlacePhoto, bool, error) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// 讀取前已被清除
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &domain.PlacePhoto{
+		Data:        data,
+		ContentType: http.DetectContentType(data),
+		FetchedAt:   info.ModTime(),
+	}, true, nil
+}
+
+// Set 寫入照片，先寫入暫存檔再改名，避免同時讀取到寫入一半的檔案
+func (c *DiskPhotoCache) Set(ctx context.Context, key string, photo *domain.PlacePhoto) error {
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(photo.Data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if !photo.FetchedAt.IsZero() {
+		if err := os.Chtimes(tmp.Name(), photo.FetchedAt, photo.FetchedAt); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Prune 刪除下載時間早於 olderThan 的照片與殘留的暫存檔，回傳刪除的檔案數
+func (c *DiskPhotoCache) Prune(ctx context.Context, olderThan time.Time) (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		// 暫存檔保留一小時，避免刪除寫入中的檔案
+		cutoff := olderThan
+		if strings.HasPrefix(entry.Name(), ".tmp-") {
+			cutoff = time.Now().Add(-time.Hour)
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.dir, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// path 取得快取鍵對應的檔案路徑
+func (c *DiskPhotoCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
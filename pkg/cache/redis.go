@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// redisKeyPrefix Redis 快取鍵的前綴
+const redisKeyPrefix = "place-search:"
+
+// RedisPlaceCache 以 Redis 儲存外部地點搜尋快取，多個服務實例可共用
+type RedisPlaceCache struct {
+	client *redis.Client
+}
+
+// NewRedisPlaceCache 依連線網址（redis://host:port/db）建立 Redis 快取，password 非空時覆寫網址中的密碼
+func NewRedisPlaceCache(url, password string) (*RedisPlaceCache, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		options.Password = password
+	}
+	return &RedisPlaceCache{client: redis.NewClient(options)}, nil
+}
+
+// Ping 檢查 Redis 連線
+func (c *RedisPlaceCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close 關閉 Redis 連線
+func (c *RedisPlaceCache) Close() error {
+	return c.client.Close()
+}
+
+// cachedRestaurant 快取的餐廳資料，額外保存 API 回應中不輸出、但匯入時需要的外部欄位
+type cachedRestaurant struct {
+	domain.Restaurant
+	ProviderTypes  []string `json:"provider_types,omitempty"`
+	BusinessStatus string   `json:"business_status,omitempty"`
+	ProviderTags   []string `json:"provider_tags,omitempty"`
+}
+
+// Get 取得快取的搜尋結果
+func (c *RedisPlaceCache) Get(ctx context.Context, key string) ([]domain.Restaurant, bool, error) {
+	data, err := c.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var cached []cachedRestaurant
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, err
+	}
+
+	restaurants := make([]domain.Restaurant, len(cached))
+	for i, item := range cached {
+		restaurants[i] = item.Restaurant
+		restaurants[i].ProviderTypes = item.ProviderTypes
+		restaurants[i].BusinessStatus = item.BusinessStatus
+		restaurants[i].ProviderTags = item.ProviderTags
+	}
+	return restaurants, true, nil
+}
+
+// Set 儲存搜尋結果並設定過期時間
+func (c *RedisPlaceCache) Set(ctx context.Context, key string, restaurants []domain.Restaurant, ttl time.Duration) error {
+	cached := make([]cachedRestaurant, len(restaurants))
+	for i, restaurant := range restaurants {
+		cached[i] = cachedRestaurant{
+			Restaurant:     restaurant,
+			ProviderTypes:  restaurant.ProviderTypes,
+			BusinessStatus: restaurant.BusinessStatus,
+			ProviderTags:   restaurant.ProviderTags,
+		}
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, redisKeyPrefix+key, data, ttl).Err()
+}
@@ -0,0 +1,231 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// 多資料來源查詢模式
+const (
+	CompositeModeSequential = "sequential" // 依優先順序查詢，結果數量足夠即停止
+	CompositeModeParallel   = "parallel"   // 同時查詢所有資料來源後合併
+)
+
+// RestaurantProvider 外部餐廳資料來源
+type RestaurantProvider interface {
+	SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error)
+	GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error)
+}
+
+// NamedProvider 具名的外部餐廳資料來源，名稱即餐廳的資料來源（google、osm、foursquare）
+type NamedProvider struct {
+	Name     string
+	Provider RestaurantProvider
+}
+
+// CompositeOptions 多資料來源設定
+type CompositeOptions struct {
+	Mode          string                            // sequential 或 parallel
+	MinResults    int                               // sequential 模式下結果達此數量即不再查詢下一個資料來源
+	QuotaCooldown time.Duration                     // 資料來源額度用盡後暫停使用的時間
+	Dedupe        domain.DuplicateDetectionSettings // 判斷不同資料來源是否為同一間餐廳
+}
+
+// CompositeService 依序或同時查詢多個外部資料來源，合併並去除重複的餐廳
+// 資料來源失敗或額度用盡時改用下一個資料來源
+type CompositeService struct {
+	providers []NamedProvider
+	options   CompositeOptions
+
+	mu             sync.Mutex
+	exhaustedUntil map[string]time.Time // 額度用盡的資料來源與恢復時間
+	now            func() time.Time
+}
+
+// NewCompositeService 建立多資料來源服務，providers 依優先順序排列
+func NewCompositeService(providers []NamedProvider, options CompositeOptions) *CompositeService {
+	if options.Mode == "" {
+		options.Mode = CompositeModeSequential
+	}
+	if options.MinResults <= 0 {
+		options.MinResults = 1
+	}
+	if options.QuotaCooldown <= 0 {
+		options.QuotaCooldown = time.Hour
+	}
+
+	return &CompositeService{
+		providers:      providers,
+		options:        options,
+		exhaustedUntil: make(map[string]time.Time),
+		now:            time.Now,
+	}
+}
+
+// providerResult 單一資料來源的搜尋結果
+type providerResult struct {
+	restaurants []domain.Restaurant
+	err         error
+}
+
+// SearchNearbyRestaurants 搜尋附近餐廳，結果依資料來源優先順序合併
+func (s *CompositeService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	providers := s.availableProviders()
+	if len(providers) == 0 {
+		return nil, domain.ErrAPIQuotaExceeded
+	}
+
+	search := func(ctx context.Context, provider NamedProvider) providerResult {
+		restaurants, err := provider.Provider.SearchNearbyRestaurants(ctx, lat, lng, radius)
+		if err != nil {
+			s.handleError(provider.Name, err)
+			return providerResult{err: err}
+		}
+		for i := range restaurants {
+			if restaurants[i].Source == "" {
+				restaurants[i].Source = provider.Name
+			}
+		}
+		return providerResult{restaurants: restaurants}
+	}
+
+	results := make([]providerResult, len(providers))
+	if s.options.Mode == CompositeModeParallel {
+		var wg sync.WaitGroup
+		for i, provider := range providers {
+			wg.Add(1)
+			go func(i int, provider NamedProvider) {
+				defer wg.Done()
+				results[i] = search(ctx, provider)
+			}(i, provider)
+		}
+		wg.Wait()
+	}
+
+	var merged []domain.Restaurant
+	var lastErr error
+	succeeded := false
+	for i, provider := range providers {
+		if s.options.Mode != CompositeModeParallel {
+			if len(merged) >= s.options.MinResults || ctx.Err() != nil {
+				break
+			}
+			results[i] = search(ctx, provider)
+		}
+
+		if results[i].err != nil {
+			lastErr = results[i].err
+			continue
+		}
+		succeeded = true
+		merged = s.mergeResults(merged, results[i].restaurants)
+	}
+
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
+	}
+
+	logger.Info("多資料來源搜尋完成",
+		zap.String("mode", s.options.Mode),
+		zap.Int("providers", len(providers)),
+		zap.Int("count", len(merged)),
+	)
+	return merged, nil
+}
+
+// GetRestaurantDetails 依外部地點 ID 的前綴向對應的資料來源取得詳細資訊
+func (s *CompositeService) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	source, _ := domain.ParseProviderPlaceID(placeID)
+	for _, provider := range s.providers {
+		if provider.Name != source {
+			continue
+		}
+		if s.isExhausted(provider.Name) {
+			return nil, domain.ErrAPIQuotaExceeded
+		}
+
+		restaurant, err := provider.Provider.GetRestaurantDetails(ctx, placeID)
+		if err != nil {
+			s.handleError(provider.Name, err)
+			return nil, err
+		}
+		if restaurant.Source == "" {
+			restaurant.Source = provider.Name
+		}
+		return restaurant, nil
+	}
+
+	return nil, fmt.Errorf("%w: 未設定資料來源 %s", domain.ErrExternalAPIFailed, source)
+}
+
+// mergeResults 合併資料來源結果：同一間餐廳保留優先資料來源的資料，並以其他資料來源補齊空白欄位
+func (s *CompositeService) mergeResults(merged, incoming []domain.Restaurant) []domain.Restaurant {
+	for i := range incoming {
+		duplicate := false
+		for j := range merged {
+			if merged[j].Source != incoming[i].Source && s.options.Dedupe.IsDuplicate(&merged[j], &incoming[i]) {
+				merged[j].FillMissingFrom(&incoming[i])
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, incoming[i])
+		}
+	}
+	return merged
+}
+
+// availableProviders 取得額度未用盡的資料來源
+func (s *CompositeService) availableProviders() []NamedProvider {
+	providers := make([]NamedProvider, 0, len(s.providers))
+	for _, provider := range s.providers {
+		if !s.isExhausted(provider.Name) {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// isExhausted 資料來源是否仍在額度用盡的暫停期間
+func (s *CompositeService) isExhausted(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, exists := s.exhaustedUntil[name]
+	if !exists {
+		return false
+	}
+	if s.now().After(until) {
+		delete(s.exhaustedUntil, name)
+		return false
+	}
+	return true
+}
+
+// handleError 記錄資料來源錯誤，額度用盡時暫停使用該資料來源
+func (s *CompositeService) handleError(name string, err error) {
+	if errors.Is(err, domain.ErrProviderPlaceNotFound) {
+		return
+	}
+	if !errors.Is(err, domain.ErrAPIQuotaExceeded) {
+		logger.Warn("外部資料來源查詢失敗，改用下一個資料來源", zap.String("provider", name), zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	s.exhaustedUntil[name] = s.now().Add(s.options.QuotaCooldown)
+	s.mu.Unlock()
+
+	logger.Warn("外部資料來源額度用盡，暫停使用",
+		zap.String("provider", name),
+		zap.Duration("cooldown", s.options.QuotaCooldown),
+	)
+}
@@ -0,0 +1,177 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// fakeProvider 測試用的外部資料來源
+type fakeProvider struct {
+	mu          sync.Mutex
+	restaurants []domain.Restaurant
+	err         error
+	searches    int
+	details     []string
+}
+
+func (p *fakeProvider) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.searches++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return append([]domain.Restaurant(nil), p.restaurants...), nil
+}
+
+func (p *fakeProvider) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.details = append(p.details, placeID)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &domain.Restaurant{GoogleID: placeID, Name: "詳細"}, nil
+}
+
+var testDedupe = domain.DuplicateDetectionSettings{MaxDistance: 80, MinNameSimilarity: 0.5}
+
+func TestCompositeSequentialFallback(t *testing.T) {
+	failing := &fakeProvider{err: domain.ErrGoogleAPIFailed}
+	osm := &fakeProvider{restaurants: []domain.Restaurant{
+		{Name: "阿宗麵線", GoogleID: "osm:node/1", Latitude: 25.0433, Longitude: 121.5070},
+	}}
+	unused := &fakeProvider{}
+
+	service := NewCompositeService([]NamedProvider{
+		{Name: "google", Provider: failing},
+		{Name: "osm", Provider: osm},
+		{Name: "foursquare", Provider: unused},
+	}, CompositeOptions{Mode: CompositeModeSequential, MinResults: 1, Dedupe: testDedupe})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.04, 121.5, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 1 || restaurants[0].Source != "osm" {
+		t.Fatalf("restaurants = %+v", restaurants)
+	}
+	if failing.searches != 1 || osm.searches != 1 || unused.searches != 0 {
+		t.Errorf("searches = %d/%d/%d, want 1/1/0", failing.searches, osm.searches, unused.searches)
+	}
+}
+
+func TestCompositeAllProvidersFail(t *testing.T) {
+	service := NewCompositeService([]NamedProvider{
+		{Name: "google", Provider: &fakeProvider{err: domain.ErrGoogleAPIFailed}},
+		{Name: "osm", Provider: &fakeProvider{err: domain.ErrExternalAPIFailed}},
+	}, CompositeOptions{Dedupe: testDedupe})
+
+	if _, err := service.SearchNearbyRestaurants(context.Background(), 25.04, 121.5, 500); !errors.Is(err, domain.ErrExternalAPIFailed) {
+		t.Errorf("error = %v, want %v", err, domain.ErrExternalAPIFailed)
+	}
+}
+
+func TestCompositeQuotaCooldown(t *testing.T) {
+	google := &fakeProvider{err: domain.ErrAPIQuotaExceeded}
+	osm := &fakeProvider{restaurants: []domain.Restaurant{{Name: "甲", GoogleID: "osm:node/1"}}}
+
+	service := NewCompositeService([]NamedProvider{
+		{Name: "google", Provider: google},
+		{Name: "osm", Provider: osm},
+	}, CompositeOptions{QuotaCooldown: time.Hour, Dedupe: testDedupe})
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.SearchNearbyRestaurants(context.Background(), 25.04, 121.5, 500); err != nil {
+			t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+		}
+	}
+	if google.searches != 1 || osm.searches != 2 {
+		t.Errorf("額度用盡期間不應再查詢 google: searches = %d/%d", google.searches, osm.searches)
+	}
+	if _, err := service.GetRestaurantDetails(context.Background(), "ChIJabc"); !errors.Is(err, domain.ErrAPIQuotaExceeded) {
+		t.Errorf("GetRestaurantDetails() error = %v, want %v", err, domain.ErrAPIQuotaExceeded)
+	}
+
+	// 暫停期間結束後恢復使用
+	now = now.Add(2 * time.Hour)
+	google.err = nil
+	if _, err := service.SearchNearbyRestaurants(context.Background(), 25.04, 121.5, 500); err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if google.searches != 2 {
+		t.Errorf("google searches = %d, want 2", google.searches)
+	}
+}
+
+func TestCompositeParallelMergeAndDedupe(t *testing.T) {
+	google := &fakeProvider{restaurants: []domain.Restaurant{
+		{Name: "Din Tai Fung", GoogleID: "ChIJdtf", Source: "google", Latitude: 25.0336, Longitude: 121.5300, Rating: 4.6},
+	}}
+	osm := &fakeProvider{restaurants: []domain.Restaurant{
+		// 與 Google 的鼎泰豐相距約 10 公尺且名稱相似，視為同一間
+		{Name: "Din Tai Fung Xinyi", GoogleID: "osm:node/1", Latitude: 25.0337, Longitude: 121.5300, Phone: "02 2321 8928", ProviderTags: []string{"wheelchair_accessible"}},
+		{Name: "Yongkang Beef Noodle", GoogleID: "osm:node/2", Latitude: 25.0330, Longitude: 121.5295},
+	}}
+	foursquare := &fakeProvider{restaurants: []domain.Restaurant{
+		// 名稱相同但距離超過 80 公尺，視為不同餐廳
+		{Name: "Din Tai Fung", GoogleID: "foursquare:x", Latitude: 25.0400, Longitude: 121.5300},
+	}}
+
+	service := NewCompositeService([]NamedProvider{
+		{Name: "google", Provider: google},
+		{Name: "osm", Provider: osm},
+		{Name: "foursquare", Provider: foursquare},
+	}, CompositeOptions{Mode: CompositeModeParallel, Dedupe: testDedupe})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.53, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 3 {
+		t.Fatalf("len = %d, want 3: %+v", len(restaurants), restaurants)
+	}
+
+	merged := restaurants[0]
+	if merged.GoogleID != "ChIJdtf" || merged.Rating != 4.6 {
+		t.Errorf("應保留優先資料來源的資料: %+v", merged)
+	}
+	if merged.Phone != "02 2321 8928" || len(merged.ProviderTags) != 1 {
+		t.Errorf("應以其他資料來源補齊空白欄位: %+v", merged)
+	}
+	if restaurants[1].Source != "osm" || restaurants[2].Source != "foursquare" {
+		t.Errorf("sources = %s, %s", restaurants[1].Source, restaurants[2].Source)
+	}
+}
+
+func TestCompositeDetailsRouting(t *testing.T) {
+	google := &fakeProvider{}
+	osm := &fakeProvider{}
+	service := NewCompositeService([]NamedProvider{
+		{Name: "google", Provider: google},
+		{Name: "osm", Provider: osm},
+	}, CompositeOptions{Dedupe: testDedupe})
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "osm:way/42")
+	if err != nil {
+		t.Fatalf("GetRestaurantDetails() error = %v", err)
+	}
+	if restaurant.Source != "osm" || len(osm.details) != 1 || len(google.details) != 0 {
+		t.Errorf("應交由 osm 取得詳細資訊: source = %s, details = %v/%v", restaurant.Source, google.details, osm.details)
+	}
+
+	if _, err := service.GetRestaurantDetails(context.Background(), "ChIJabc"); err != nil || len(google.details) != 1 {
+		t.Errorf("沒有前綴的 ID 應交由 google: err = %v, details = %v", err, google.details)
+	}
+
+	if _, err := service.GetRestaurantDetails(context.Background(), "foursquare:abc"); !errors.Is(err, domain.ErrExternalAPIFailed) {
+		t.Errorf("未設定的資料來源 error = %v, want %v", err, domain.ErrExternalAPIFailed)
+	}
+}
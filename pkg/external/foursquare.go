@@ -0,0 +1,227 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	foursquareBaseURL = "https://api.foursquare.com/v3"
+	// foursquareDiningCategory Foursquare「Dining and Drinking」分類
+	foursquareDiningCategory = "13000"
+	// foursquareMaxResults Foursquare 搜尋每次最多回傳的地點數
+	foursquareMaxResults = 50
+	// foursquareFields 只取餐廳需要的欄位以降低費用
+	foursquareFields = "fsq_id,name,geocodes,location,categories,rating,price,tel,photos,hours,date_closed"
+)
+
+// FoursquareService Foursquare Places API 服務
+type FoursquareService struct {
+	apiKey   string
+	client   *http.Client
+	baseURL  string
+	language string
+}
+
+// NewFoursquareService 建立 Foursquare Places API 服務
+func NewFoursquareService(apiKey, language string) *FoursquareService {
+	return &FoursquareService{
+		apiKey:   apiKey,
+		client:   &http.Client{},
+		baseURL:  foursquareBaseURL,
+		language: language,
+	}
+}
+
+// FoursquareSearchResponse Foursquare 搜尋回應結構
+type FoursquareSearchResponse struct {
+	Results []FoursquarePlace `json:"results"`
+}
+
+// FoursquarePlace Foursquare 地點結構
+type FoursquarePlace struct {
+	FsqID    string `json:"fsq_id"`
+	Name     string `json:"name"`
+	Geocodes struct {
+		Main LatLng `json:"main"`
+	} `json:"geocodes"`
+	Location struct {
+		FormattedAddress string `json:"formatted_address"`
+	} `json:"location"`
+	Categories []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"categories"`
+	Rating float64 `json:"rating"` // 0-10
+	Price  int     `json:"price"`  // 1-4
+	Tel    string  `json:"tel"`
+	Photos []struct {
+		Prefix string `json:"prefix"`
+		Suffix string `json:"suffix"`
+	} `json:"photos"`
+	Hours struct {
+		Regular []FoursquareHours `json:"regular"`
+	} `json:"hours"`
+	DateClosed string `json:"date_closed"` // 有值表示已永久歇業
+}
+
+// FoursquareHours Foursquare 營業時段結構
+type FoursquareHours struct {
+	Day   int    `json:"day"`   // 1 為星期一，7 為星期日
+	Open  string `json:"open"`  // HHMM
+	Close string `json:"close"` // HHMM，跨日時可能以 + 開頭
+}
+
+// SearchNearbyRestaurants 搜尋附近餐廳
+func (s *FoursquareService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	params := url.Values{}
+	params.Set("ll", fmt.Sprintf("%f,%f", lat, lng))
+	params.Set("radius", fmt.Sprintf("%d", radius))
+	params.Set("categories", foursquareDiningCategory)
+	params.Set("limit", fmt.Sprintf("%d", foursquareMaxResults))
+	params.Set("fields", foursquareFields)
+
+	var searchResp FoursquareSearchResponse
+	if err := s.get(ctx, "/places/search", params, &searchResp); err != nil {
+		return nil, err
+	}
+
+	restaurants := make([]domain.Restaurant, 0, len(searchResp.Results))
+	for _, place := range searchResp.Results {
+		restaurants = append(restaurants, convertFoursquarePlace(place))
+	}
+
+	logger.Info("Foursquare 搜尋成功",
+		zap.Int("count", len(restaurants)),
+		zap.Float64("lat", lat),
+		zap.Float64("lng", lng),
+		zap.Int("radius", radius),
+	)
+
+	return restaurants, nil
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊，placeID 格式為 foursquare:{fsq_id}
+func (s *FoursquareService) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	_, fsqID := domain.ParseProviderPlaceID(placeID)
+
+	var place FoursquarePlace
+	if err := s.get(ctx, "/places/"+url.PathEscape(fsqID), url.Values{"fields": {foursquareFields}}, &place); err != nil {
+		return nil, err
+	}
+
+	restaurant := convertFoursquarePlace(place)
+	return &restaurant, nil
+}
+
+// get 發送 Foursquare API 請求並解析 JSON 回應
+func (s *FoursquareService) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		logger.Error("建立請求失敗", zap.Error(err))
+		return err
+	}
+	req.Header.Set("Authorization", s.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if s.language != "" {
+		req.Header.Set("Accept-Language", s.language)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Error("Foursquare API 請求失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return domain.ErrProviderPlaceNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		logger.Error("Foursquare API 請求額度已用盡")
+		return domain.ErrAPIQuotaExceeded
+	case resp.StatusCode != http.StatusOK:
+		logger.Error("Foursquare API HTTP 錯誤", zap.Int("status_code", resp.StatusCode), zap.String("path", path))
+		return fmt.Errorf("%w: Foursquare HTTP %d", domain.ErrExternalAPIFailed, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		logger.Error("解析 Foursquare API 回應失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIFailed, err)
+	}
+
+	return nil
+}
+
+// convertFoursquarePlace 將 Foursquare 地點轉換為餐廳實體
+func convertFoursquarePlace(place FoursquarePlace) domain.Restaurant {
+	var imageURL string
+	if len(place.Photos) > 0 {
+		imageURL = place.Photos[0].Prefix + "400x300" + place.Photos[0].Suffix
+	}
+
+	// 分類名稱轉為小寫底線格式，例如 Ramen Restaurant → ramen_restaurant
+	providerTypes := make([]string, 0, len(place.Categories))
+	for _, category := range place.Categories {
+		providerTypes = append(providerTypes, foursquareCategoryType(category.Name))
+	}
+
+	businessStatus := domain.ProviderBusinessOperational
+	if place.DateClosed != "" {
+		businessStatus = domain.ProviderBusinessClosedPermanently
+	}
+
+	return domain.Restaurant{
+		Name:           place.Name,
+		Address:        place.Location.FormattedAddress,
+		Phone:          place.Tel,
+		Latitude:       place.Geocodes.Main.Latitude,
+		Longitude:      place.Geocodes.Main.Longitude,
+		Rating:         float32(place.Rating / 2),
+		PriceLevel:     place.Price,
+		GoogleID:       domain.ProviderPlaceID(domain.RestaurantSourceFoursquare, place.FsqID),
+		Source:         domain.RestaurantSourceFoursquare,
+		ImageURL:       imageURL,
+		IsActive:       true,
+		OpeningHours:   convertFoursquareHours(place.Hours.Regular),
+		ProviderTypes:  providerTypes,
+		BusinessStatus: businessStatus,
+	}
+}
+
+// foursquareCategoryType 將 Foursquare 分類名稱轉為小寫底線格式
+func foursquareCategoryType(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
+
+// convertFoursquareHours 將 Foursquare 營業時段轉換為營業時段，結束時間早於開始時間時視為跨日
+func convertFoursquareHours(hours []FoursquareHours) []domain.OpeningPeriod {
+	result := make([]domain.OpeningPeriod, 0, len(hours))
+	for _, hour := range hours {
+		day := hour.Day % 7
+		closeTime := strings.TrimPrefix(hour.Close, "+")
+		closeDay := day
+		if strings.HasPrefix(hour.Close, "+") || closeTime <= hour.Open {
+			closeDay = (day + 1) % 7
+		}
+		result = append(result, domain.OpeningPeriod{
+			OpenDay:   day,
+			OpenTime:  formatPlaceTime(hour.Open),
+			CloseDay:  closeDay,
+			CloseTime: formatPlaceTime(closeTime),
+		})
+	}
+	return result
+}
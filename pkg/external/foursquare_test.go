@@ -0,0 +1,97 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// newTestFoursquareService 建立連線至測試伺服器的 Foursquare 服務
+func newTestFoursquareService(t *testing.T, handler http.HandlerFunc) *FoursquareService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	service := NewFoursquareService("test-key", "zh-TW")
+	service.baseURL = server.URL
+	return service
+}
+
+func TestFoursquareSearchNearby(t *testing.T) {
+	service := newTestFoursquareService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/places/search" || r.Header.Get("Authorization") != "test-key" {
+			t.Errorf("request = %s, Authorization = %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("ll") == "" || r.URL.Query().Get("fields") != foursquareFields {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"results":[{
+			"fsq_id":"4b0588","name":"拉麵店","geocodes":{"main":{"latitude":25.03,"longitude":121.56}},
+			"location":{"formatted_address":"臺北市信義區松仁路100號"},
+			"categories":[{"id":13272,"name":"Ramen Restaurant"}],"rating":8.6,"price":2,
+			"photos":[{"prefix":"https://fastly.4sqi.net/img/general/","suffix":"/photo.jpg"}],
+			"hours":{"regular":[{"day":7,"open":"1130","close":"2100"},{"day":5,"open":"1800","close":"+0200"}]}
+		}]}`)
+	})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 1 {
+		t.Fatalf("len = %d, want 1", len(restaurants))
+	}
+
+	restaurant := restaurants[0]
+	if restaurant.GoogleID != "foursquare:4b0588" || restaurant.Source != domain.RestaurantSourceFoursquare {
+		t.Errorf("GoogleID = %q, Source = %q", restaurant.GoogleID, restaurant.Source)
+	}
+	if restaurant.Rating != 4.3 || restaurant.PriceLevel != 2 || restaurant.ImageURL != "https://fastly.4sqi.net/img/general/400x300/photo.jpg" {
+		t.Errorf("restaurant = %+v", restaurant)
+	}
+	if !reflect.DeepEqual(restaurant.ProviderTypes, []string{"ramen_restaurant"}) {
+		t.Errorf("ProviderTypes = %v", restaurant.ProviderTypes)
+	}
+
+	wantHours := []domain.OpeningPeriod{
+		{OpenDay: 0, OpenTime: "11:30", CloseDay: 0, CloseTime: "21:00"},
+		{OpenDay: 5, OpenTime: "18:00", CloseDay: 6, CloseTime: "02:00"},
+	}
+	if !reflect.DeepEqual(restaurant.OpeningHours, wantHours) {
+		t.Errorf("OpeningHours = %+v, want %+v", restaurant.OpeningHours, wantHours)
+	}
+}
+
+func TestFoursquareGetRestaurantDetails(t *testing.T) {
+	service := newTestFoursquareService(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/places/closed":
+			fmt.Fprint(w, `{"fsq_id":"closed","name":"歇業","date_closed":"2024-01-01"}`)
+		case "/places/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "foursquare:closed")
+	if err != nil {
+		t.Fatalf("GetRestaurantDetails() error = %v", err)
+	}
+	if restaurant.BusinessStatus != domain.ProviderBusinessClosedPermanently {
+		t.Errorf("BusinessStatus = %q", restaurant.BusinessStatus)
+	}
+
+	if _, err := service.GetRestaurantDetails(context.Background(), "foursquare:limited"); !errors.Is(err, domain.ErrAPIQuotaExceeded) {
+		t.Errorf("error = %v, want %v", err, domain.ErrAPIQuotaExceeded)
+	}
+	if _, err := service.GetRestaurantDetails(context.Background(), "foursquare:missing"); !errors.Is(err, domain.ErrProviderPlaceNotFound) {
+		t.Errorf("error = %v, want %v", err, domain.ErrProviderPlaceNotFound)
+	}
+}
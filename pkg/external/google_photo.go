@@ -0,0 +1,98 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxPlacePhotoBytes 照片大小上限
+const maxPlacePhotoBytes = 10 << 20
+
+// GooglePhotoService 於伺服器端以 API 金鑰下載 Google Places 照片
+// 依照片參照格式選擇舊版 Places API 或 Places API (New)，兩者皆會重新導向至實際圖片
+type GooglePhotoService struct {
+	apiKey        string
+	client        *resilientClient
+	legacyBaseURL string
+	newBaseURL    string
+}
+
+// NewGooglePhotoService 建立 Google Places 照片下載服務
+func NewGooglePhotoService(apiKey string, options GooglePlacesOptions) *GooglePhotoService {
+	return &GooglePhotoService{
+		apiKey:        apiKey,
+		client:        newResilientClient(domain.RestaurantSourceGoogle, options.Resilience, options.Quota),
+		legacyBaseURL: placesBaseURL,
+		newBaseURL:    placesNewBaseURL,
+	}
+}
+
+// FetchPlacePhoto 下載照片，查無照片時回傳 domain.ErrProviderPlaceNotFound
+func (s *GooglePhotoService) FetchPlacePhoto(ctx context.Context, ref string, maxWidth int) (*domain.PlacePhoto, error) {
+	var requestURL string
+	if name, isNew := domain.PlacePhotoName(ref); isNew {
+		params := url.Values{}
+		params.Set("maxWidthPx", fmt.Sprintf("%d", maxWidth))
+		params.Set("key", s.apiKey)
+		requestURL = fmt.Sprintf("%s/%s/media?%s", s.newBaseURL, name, params.Encode())
+	} else {
+		params := url.Values{}
+		params.Set("maxwidth", fmt.Sprintf("%d", maxWidth))
+		params.Set("photoreference", ref)
+		params.Set("key", s.apiKey)
+		requestURL = fmt.Sprintf("%s/photo?%s", s.legacyBaseURL, params.Encode())
+	}
+
+	resp, err := s.client.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	})
+	if err != nil {
+		if isFailFast(err) {
+			return nil, err
+		}
+		logger.Error("Google Places 照片請求失敗", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound:
+		// 照片參照無效或已過期
+		return nil, domain.ErrProviderPlaceNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		logger.Error("Google Places 照片請求額度已用盡")
+		return nil, domain.ErrAPIQuotaExceeded
+	case resp.StatusCode != http.StatusOK:
+		logger.Error("Google Places 照片 HTTP 錯誤", zap.Int("status_code", resp.StatusCode))
+		return nil, fmt.Errorf("%w: HTTP %d", domain.ErrGoogleAPIFailed, resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(contentType, "image/") {
+		logger.Error("Google Places 照片回應不是圖片", zap.String("content_type", contentType))
+		return nil, fmt.Errorf("%w: 回應不是圖片（%s）", domain.ErrGoogleAPIFailed, contentType)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPlacePhotoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+	if len(data) > maxPlacePhotoBytes {
+		return nil, fmt.Errorf("%w: 照片超過大小上限", domain.ErrGoogleAPIFailed)
+	}
+
+	return &domain.PlacePhoto{
+		Data:        data,
+		ContentType: contentType,
+		FetchedAt:   time.Now(),
+	}, nil
+}
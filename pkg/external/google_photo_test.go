@@ -0,0 +1,76 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+func TestGooglePhotoServiceFetchPlacePhoto(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/photo", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("photoreference") != "legacyRef" || r.URL.Query().Get("maxwidth") != "400" {
+			t.Errorf("舊版照片請求參數錯誤: %s", r.URL.RawQuery)
+		}
+		// 舊版 API 會重新導向至實際圖片
+		http.Redirect(w, r, "/image", http.StatusFound)
+	})
+	mux.HandleFunc("/places/abc/photos/p1/media", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("maxWidthPx") != "400" || r.URL.Query().Get("key") != "test-key" {
+			t.Errorf("新版照片請求參數錯誤: %s", r.URL.RawQuery)
+		}
+		http.Redirect(w, r, "/image", http.StatusFound)
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write([]byte("jpeg-data"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	service := NewGooglePhotoService("test-key", GooglePlacesOptions{})
+	service.legacyBaseURL = server.URL
+	service.newBaseURL = server.URL
+
+	for _, ref := range []string{"legacyRef", "abc.p1"} {
+		photo, err := service.FetchPlacePhoto(context.Background(), ref, 400)
+		if err != nil {
+			t.Fatalf("FetchPlacePhoto(%q) error = %v", ref, err)
+		}
+		if string(photo.Data) != "jpeg-data" || photo.ContentType != "image/jpeg" || photo.FetchedAt.IsZero() {
+			t.Errorf("FetchPlacePhoto(%q) = %+v", ref, photo)
+		}
+	}
+}
+
+func TestGooglePhotoServiceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "照片不存在", status: http.StatusNotFound, want: domain.ErrProviderPlaceNotFound},
+		{name: "請求過多", status: http.StatusTooManyRequests, want: domain.ErrAPIQuotaExceeded},
+		{name: "權限錯誤", status: http.StatusForbidden, want: domain.ErrGoogleAPIFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			service := NewGooglePhotoService("test-key", GooglePlacesOptions{})
+			service.legacyBaseURL = server.URL
+
+			if _, err := service.FetchPlacePhoto(context.Background(), "legacyRef", 400); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
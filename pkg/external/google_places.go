@@ -6,30 +6,73 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/shaunchuang/food-roulette-backend/internal/domain"
 	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
 	"go.uber.org/zap"
 )
 
+const (
+	placesBaseURL = "https://maps.googleapis.com/maps/api/place"
+	// maxPlacesPages Google Places 附近搜尋最多回傳的頁數（每頁 20 筆）
+	maxPlacesPages = 3
+	// placesPageTokenDelay next_page_token 發出後需等待一段時間才會生效
+	placesPageTokenDelay = 2 * time.Second
+	// placesPageTokenRetries next_page_token 尚未生效時的重試次數
+	placesPageTokenRetries = 2
+)
+
+// GooglePlacesOptions Google Places 服務設定
+type GooglePlacesOptions struct {
+	MaxPages             int       // 搜尋最多讀取的頁數（1-3）
+	Language             string    // 回傳結果的語言，例如 zh-TW
+	PriceLevelThresholds []float64 // 以價格區間換算價位等級的分界（新台幣，僅 Places API (New) 使用）
+	Resilience           ResilienceOptions
+	Quota                QuotaTracker // 請求額度控管，nil 表示不限
+}
+
 // GooglePlacesService Google Places API 服務
 type GooglePlacesService struct {
-	apiKey string
-	client *http.Client
+	apiKey         string
+	client         *resilientClient
+	baseURL        string
+	maxPages       int
+	language       string
+	pageTokenDelay time.Duration
 }
 
 // NewGooglePlacesService 建立 Google Places 服務
-func NewGooglePlacesService(apiKey string) *GooglePlacesService {
+func NewGooglePlacesService(apiKey string, options GooglePlacesOptions) *GooglePlacesService {
+	if options.MaxPages <= 0 {
+		options.MaxPages = 1
+	}
+	if options.MaxPages > maxPlacesPages {
+		options.MaxPages = maxPlacesPages
+	}
+
 	return &GooglePlacesService{
-		apiKey: apiKey,
-		client: &http.Client{},
+		apiKey:         apiKey,
+		client:         newResilientClient(domain.RestaurantSourceGoogle, options.Resilience, options.Quota),
+		baseURL:        placesBaseURL,
+		maxPages:       options.MaxPages,
+		language:       options.Language,
+		pageTokenDelay: placesPageTokenDelay,
 	}
 }
 
+// NearbySearchOptions 附近搜尋條件
+type NearbySearchOptions struct {
+	Keyword string // 關鍵字，例如「拉麵」
+	Type    string // 地點類型，例如 restaurant、cafe；空白表示不限
+}
+
 // PlacesResponse Google Places API 回應結構
 type PlacesResponse struct {
-	Results []PlaceResult `json:"results"`
-	Status  string        `json:"status"`
+	Results       []PlaceResult `json:"results"`
+	Status        string        `json:"status"`
+	ErrorMessage  string        `json:"error_message"`
+	NextPageToken string        `json:"next_page_token"`
 }
 
 // PlaceResult 地點結果結構
@@ -65,13 +108,27 @@ type Photo struct {
 
 // OpeningHours 營業時間結構
 type OpeningHours struct {
-	OpenNow bool `json:"open_now"`
+	OpenNow bool          `json:"open_now"`
+	Periods []PlacePeriod `json:"periods"`
+}
+
+// PlacePeriod 營業時段結構
+type PlacePeriod struct {
+	Open  PlaceTimePoint  `json:"open"`
+	Close *PlaceTimePoint `json:"close"` // 沒有 close 表示 24 小時營業
+}
+
+// PlaceTimePoint 營業時段的時間點結構
+type PlaceTimePoint struct {
+	Day  int    `json:"day"`  // 0 為星期日
+	Time string `json:"time"` // HHMM
 }
 
 // PlaceDetailResponse 地點詳細資訊回應結構
 type PlaceDetailResponse struct {
-	Result PlaceDetail `json:"result"`
-	Status string      `json:"status"`
+	Result       PlaceDetail `json:"result"`
+	Status       string      `json:"status"`
+	ErrorMessage string      `json:"error_message"`
 }
 
 // PlaceDetail 地點詳細資訊結構
@@ -87,48 +144,47 @@ type PlaceDetail struct {
 	Photos               []Photo      `json:"photos"`
 	OpeningHours         OpeningHours `json:"opening_hours"`
 	Website              string       `json:"website"`
+	BusinessStatus       string       `json:"business_status"` // OPERATIONAL、CLOSED_TEMPORARILY 或 CLOSED_PERMANENTLY
 }
 
 // SearchNearbyRestaurants 搜尋附近餐廳
 func (s *GooglePlacesService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
-	baseURL := "https://maps.googleapis.com/maps/api/place/nearbysearch/json"
+	return s.SearchNearby(ctx, lat, lng, radius, NearbySearchOptions{Type: "restaurant"})
+}
 
+// SearchNearby 依關鍵字與地點類型搜尋附近地點，依 next_page_token 最多讀取 MaxPages 頁
+func (s *GooglePlacesService) SearchNearby(ctx context.Context, lat, lng float64, radius int, options NearbySearchOptions) ([]domain.Restaurant, error) {
 	params := url.Values{}
 	params.Set("location", fmt.Sprintf("%f,%f", lat, lng))
 	params.Set("radius", fmt.Sprintf("%d", radius))
-	params.Set("type", "restaurant")
-	params.Set("key", s.apiKey)
-
-	requestURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
-
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
-	if err != nil {
-		logger.Error("建立請求失敗", zap.Error(err))
-		return nil, err
+	if options.Type != "" {
+		params.Set("type", options.Type)
 	}
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		logger.Error("Google Places API 請求失敗", zap.Error(err))
-		return nil, err
+	if options.Keyword != "" {
+		params.Set("keyword", options.Keyword)
 	}
-	defer resp.Body.Close()
 
-	var placesResp PlacesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&placesResp); err != nil {
-		logger.Error("解析 Google Places API 回應失敗", zap.Error(err))
-		return nil, err
-	}
+	var restaurants []domain.Restaurant
+	pageToken := ""
+	for page := 1; page <= s.maxPages; page++ {
+		placesResp, err := s.fetchNearbyPage(ctx, params, pageToken)
+		if err != nil {
+			// 已取得部分結果時回傳已讀取的頁面
+			if len(restaurants) > 0 {
+				logger.Warn("Google Places 讀取下一頁失敗，回傳已取得的結果", zap.Error(err), zap.Int("page", page))
+				break
+			}
+			return nil, err
+		}
 
-	if placesResp.Status != "OK" {
-		logger.Error("Google Places API 錯誤", zap.String("status", placesResp.Status))
-		return nil, fmt.Errorf("Google Places API 錯誤: %s", placesResp.Status)
-	}
+		for _, place := range placesResp.Results {
+			restaurants = append(restaurants, s.convertToRestaurant(place))
+		}
 
-	restaurants := make([]domain.Restaurant, 0, len(placesResp.Results))
-	for _, place := range placesResp.Results {
-		restaurant := s.convertToRestaurant(place)
-		restaurants = append(restaurants, restaurant)
+		pageToken = placesResp.NextPageToken
+		if pageToken == "" {
+			break
+		}
 	}
 
 	logger.Info("Google Places 搜尋成功",
@@ -136,44 +192,72 @@ func (s *GooglePlacesService) SearchNearbyRestaurants(ctx context.Context, lat,
 		zap.Float64("lat", lat),
 		zap.Float64("lng", lng),
 		zap.Int("radius", radius),
+		zap.String("keyword", options.Keyword),
+		zap.String("type", options.Type),
 	)
 
 	return restaurants, nil
 }
 
-// GetRestaurantDetails 取得餐廳詳細資訊
-func (s *GooglePlacesService) GetRestaurantDetails(ctx context.Context, googleID string) (*domain.Restaurant, error) {
-	baseURL := "https://maps.googleapis.com/maps/api/place/details/json"
+// fetchNearbyPage 讀取附近搜尋的一頁結果，next_page_token 尚未生效時等待後重試
+func (s *GooglePlacesService) fetchNearbyPage(ctx context.Context, params url.Values, pageToken string) (*PlacesResponse, error) {
+	if pageToken != "" {
+		// 使用 pagetoken 時其他參數會被忽略
+		params = url.Values{"pagetoken": {pageToken}}
+	}
 
-	params := url.Values{}
-	params.Set("place_id", googleID)
-	params.Set("fields", "place_id,name,formatted_address,formatted_phone_number,geometry,types,rating,price_level,photos,opening_hours,website")
-	params.Set("key", s.apiKey)
+	attempts := 1
+	if pageToken != "" {
+		attempts += placesPageTokenRetries
+	}
 
-	requestURL := fmt.Sprintf("%s?%s", baseURL, params.Encode())
+	var placesResp PlacesResponse
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if pageToken != "" {
+			if err := sleepContext(ctx, s.pageTokenDelay); err != nil {
+				return nil, err
+			}
+		}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
-	if err != nil {
-		logger.Error("建立詳細資訊請求失敗", zap.Error(err))
-		return nil, err
+		placesResp = PlacesResponse{}
+		if err := s.get(ctx, "/nearbysearch/json", params, &placesResp); err != nil {
+			return nil, err
+		}
+		if placesResp.Status != "INVALID_REQUEST" || pageToken == "" {
+			break
+		}
 	}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		logger.Error("Google Places 詳細資訊請求失敗", zap.Error(err))
-		return nil, err
+	switch placesResp.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		placesResp.Results = nil
+		placesResp.NextPageToken = ""
+	default:
+		return nil, placesStatusError(placesResp.Status, placesResp.ErrorMessage)
 	}
-	defer resp.Body.Close()
+
+	return &placesResp, nil
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊
+func (s *GooglePlacesService) GetRestaurantDetails(ctx context.Context, googleID string) (*domain.Restaurant, error) {
+	params := url.Values{}
+	params.Set("place_id", googleID)
+	params.Set("fields", "place_id,name,formatted_address,formatted_phone_number,geometry,types,rating,price_level,photos,opening_hours,website,business_status")
 
 	var detailResp PlaceDetailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&detailResp); err != nil {
-		logger.Error("解析 Google Places 詳細資訊回應失敗", zap.Error(err))
+	if err := s.get(ctx, "/details/json", params, &detailResp); err != nil {
 		return nil, err
 	}
 
-	if detailResp.Status != "OK" {
-		logger.Error("Google Places 詳細資訊 API 錯誤", zap.String("status", detailResp.Status))
-		return nil, fmt.Errorf("Google Places 詳細資訊 API 錯誤: %s", detailResp.Status)
+	switch detailResp.Status {
+	case "OK":
+	case "NOT_FOUND":
+		logger.Warn("Google Places 查無此地點", zap.String("google_id", googleID))
+		return nil, domain.ErrProviderPlaceNotFound
+	default:
+		return nil, placesStatusError(detailResp.Status, detailResp.ErrorMessage)
 	}
 
 	restaurant := s.convertDetailToRestaurant(detailResp.Result)
@@ -183,27 +267,92 @@ func (s *GooglePlacesService) GetRestaurantDetails(ctx context.Context, googleID
 	return &restaurant, nil
 }
 
+// get 發送 Google Places API 請求並解析 JSON 回應
+func (s *GooglePlacesService) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("key", s.apiKey)
+	if s.language != "" {
+		query.Set("language", s.language)
+	}
+
+	requestURL := fmt.Sprintf("%s%s?%s", s.baseURL, path, query.Encode())
+
+	resp, err := s.client.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	})
+	if err != nil {
+		if isFailFast(err) {
+			return err
+		}
+		logger.Error("Google Places API 請求失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("Google Places API HTTP 錯誤", zap.Int("status_code", resp.StatusCode), zap.String("path", path))
+		return fmt.Errorf("%w: HTTP %d", domain.ErrGoogleAPIFailed, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		logger.Error("解析 Google Places API 回應失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+
+	return nil
+}
+
+// CircuitState 取得斷路器狀態
+func (s *GooglePlacesService) CircuitState() string {
+	return s.client.CircuitState()
+}
+
+// placesStatusError 將 Google Places API 狀態轉換為錯誤
+func placesStatusError(status, message string) error {
+	logger.Error("Google Places API 錯誤", zap.String("status", status), zap.String("message", message))
+	if status == "OVER_QUERY_LIMIT" {
+		return domain.ErrAPIQuotaExceeded
+	}
+	if message != "" {
+		return fmt.Errorf("%w: %s（%s）", domain.ErrGoogleAPIFailed, status, message)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrGoogleAPIFailed, status)
+}
+
+// sleepContext 等待指定時間，context 取消時提前結束
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // convertToRestaurant 將 Google Places 結果轉換為餐廳實體
 func (s *GooglePlacesService) convertToRestaurant(place PlaceResult) domain.Restaurant {
 	var imageURL string
 	if len(place.Photos) > 0 {
-		imageURL = s.getPhotoURL(place.Photos[0].PhotoReference, 400)
+		imageURL = domain.PlacePhotoPath(place.Photos[0].PhotoReference)
 	}
 
-	// 判斷料理類型
-	cuisine := s.determineCuisineType(place.Types)
-
 	return domain.Restaurant{
-		Name:       place.Name,
-		Address:    place.FormattedAddress,
-		Latitude:   place.Geometry.Location.Lat,
-		Longitude:  place.Geometry.Location.Lng,
-		Rating:     float32(place.Rating),
-		PriceLevel: place.PriceLevel,
-		Cuisine:    cuisine,
-		GoogleID:   place.PlaceID,
-		ImageURL:   imageURL,
-		IsActive:   true,
+		Name:          place.Name,
+		Address:       place.FormattedAddress,
+		Latitude:      place.Geometry.Location.Lat,
+		Longitude:     place.Geometry.Location.Lng,
+		Rating:        float32(place.Rating),
+		PriceLevel:    place.PriceLevel,
+		GoogleID:      place.PlaceID,
+		ImageURL:      imageURL,
+		IsActive:      true,
+		Source:        domain.RestaurantSourceGoogle,
+		ProviderTypes: place.Types,
 	}
 }
 
@@ -211,60 +360,48 @@ func (s *GooglePlacesService) convertToRestaurant(place PlaceResult) domain.Rest
 func (s *GooglePlacesService) convertDetailToRestaurant(detail PlaceDetail) domain.Restaurant {
 	var imageURL string
 	if len(detail.Photos) > 0 {
-		imageURL = s.getPhotoURL(detail.Photos[0].PhotoReference, 400)
+		imageURL = domain.PlacePhotoPath(detail.Photos[0].PhotoReference)
 	}
 
-	// 判斷料理類型
-	cuisine := s.determineCuisineType(detail.Types)
-
 	return domain.Restaurant{
-		Name:       detail.Name,
-		Address:    detail.FormattedAddress,
-		Phone:      detail.FormattedPhoneNumber,
-		Latitude:   detail.Geometry.Location.Lat,
-		Longitude:  detail.Geometry.Location.Lng,
-		Rating:     float32(detail.Rating),
-		PriceLevel: detail.PriceLevel,
-		Cuisine:    cuisine,
-		GoogleID:   detail.PlaceID,
-		ImageURL:   imageURL,
-		IsActive:   true,
+		Name:           detail.Name,
+		Address:        detail.FormattedAddress,
+		Phone:          detail.FormattedPhoneNumber,
+		Latitude:       detail.Geometry.Location.Lat,
+		Longitude:      detail.Geometry.Location.Lng,
+		Rating:         float32(detail.Rating),
+		PriceLevel:     detail.PriceLevel,
+		GoogleID:       detail.PlaceID,
+		ImageURL:       imageURL,
+		IsActive:       true,
+		Source:         domain.RestaurantSourceGoogle,
+		OpeningHours:   s.convertOpeningPeriods(detail.OpeningHours.Periods),
+		ProviderTypes:  detail.Types,
+		BusinessStatus: detail.BusinessStatus,
 	}
 }
 
-// getPhotoURL 取得照片 URL
-func (s *GooglePlacesService) getPhotoURL(photoReference string, maxWidth int) string {
-	return fmt.Sprintf("https://maps.googleapis.com/maps/api/place/photo?maxwidth=%d&photoreference=%s&key=%s",
-		maxWidth, photoReference, s.apiKey)
-}
-
-// determineCuisineType 根據 Google Places 類型判斷料理類型
-func (s *GooglePlacesService) determineCuisineType(types []string) string {
-	cuisineMap := map[string]string{
-		"chinese_restaurant":    "中式料理",
-		"japanese_restaurant":   "日式料理",
-		"korean_restaurant":     "韓式料理",
-		"italian_restaurant":    "義式料理",
-		"american_restaurant":   "美式料理",
-		"thai_restaurant":       "泰式料理",
-		"indian_restaurant":     "印度料理",
-		"mexican_restaurant":    "墨西哥料理",
-		"french_restaurant":     "法式料理",
-		"vietnamese_restaurant": "越南料理",
-		"fast_food_restaurant":  "快餐",
-		"pizza_restaurant":      "披薩",
-		"seafood_restaurant":    "海鮮",
-		"steakhouse":            "牛排",
-		"bakery":                "烘焙",
-		"cafe":                  "咖啡廳",
-		"bar":                   "酒吧",
-	}
-
-	for _, placeType := range types {
-		if cuisine, exists := cuisineMap[placeType]; exists {
-			return cuisine
+// convertOpeningPeriods 將 Google Places 營業時段轉換為營業時段
+func (s *GooglePlacesService) convertOpeningPeriods(periods []PlacePeriod) []domain.OpeningPeriod {
+	result := make([]domain.OpeningPeriod, 0, len(periods))
+	for _, period := range periods {
+		converted := domain.OpeningPeriod{
+			OpenDay:  period.Open.Day,
+			OpenTime: formatPlaceTime(period.Open.Time),
+		}
+		if period.Close != nil {
+			converted.CloseDay = period.Close.Day
+			converted.CloseTime = formatPlaceTime(period.Close.Time)
 		}
+		result = append(result, converted)
 	}
+	return result
+}
 
-	return "餐廳"
+// formatPlaceTime 將 HHMM 格式轉換為 HH:MM
+func formatPlaceTime(value string) string {
+	if len(value) != 4 {
+		return value
+	}
+	return value[:2] + ":" + value[2:]
 }
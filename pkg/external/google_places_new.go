@@ -0,0 +1,445 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	placesNewBaseURL = "https://places.googleapis.com/v1"
+	// placesNewMaxResults Places API (New) 每次請求最多回傳的地點數
+	placesNewMaxResults = 20
+	// placesNewPriceCurrency 價格區間換算價位等級時使用的幣別（與價位分界設定一致）
+	placesNewPriceCurrency = "TWD"
+)
+
+// Places API (New) 依回傳欄位計費，搜尋只取列表需要的欄位，詳細資訊才取營業時間與設施
+const (
+	placesNewSearchFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.types," +
+		"places.rating,places.priceLevel,places.priceRange,places.photos,places.businessStatus"
+	// searchText 分頁需要 nextPageToken，searchNearby 沒有此欄位
+	placesNewTextSearchFieldMask = placesNewSearchFieldMask + ",nextPageToken"
+	placesNewDetailFieldMask     = "id,displayName,formattedAddress,nationalPhoneNumber,location,types," +
+		"rating,priceLevel,priceRange,photos,businessStatus,regularOpeningHours,websiteUri," +
+		"accessibilityOptions,allowsDogs,servesVegetarianFood,servesBreakfast,servesBrunch,servesLunch,servesDinner"
+)
+
+// GooglePlacesNewService Google Places API (New) 服務
+type GooglePlacesNewService struct {
+	apiKey               string
+	client               *resilientClient
+	baseURL              string
+	maxPages             int
+	language             string
+	priceLevelThresholds []float64
+}
+
+// NewGooglePlacesNewService 建立 Google Places API (New) 服務
+func NewGooglePlacesNewService(apiKey string, options GooglePlacesOptions) *GooglePlacesNewService {
+	if options.MaxPages <= 0 {
+		options.MaxPages = 1
+	}
+	if options.MaxPages > maxPlacesPages {
+		options.MaxPages = maxPlacesPages
+	}
+
+	return &GooglePlacesNewService{
+		apiKey:               apiKey,
+		client:               newResilientClient(domain.RestaurantSourceGoogle, options.Resilience, options.Quota),
+		baseURL:              placesNewBaseURL,
+		maxPages:             options.MaxPages,
+		language:             options.Language,
+		priceLevelThresholds: options.PriceLevelThresholds,
+	}
+}
+
+// NewPlace Places API (New) 地點結構
+type NewPlace struct {
+	ID                   string                `json:"id"`
+	DisplayName          LocalizedText         `json:"displayName"`
+	FormattedAddress     string                `json:"formattedAddress"`
+	NationalPhoneNumber  string                `json:"nationalPhoneNumber"`
+	Location             LatLng                `json:"location"`
+	Types                []string              `json:"types"`
+	Rating               float64               `json:"rating"`
+	PriceLevel           string                `json:"priceLevel"` // PRICE_LEVEL_INEXPENSIVE 等
+	PriceRange           *PriceRange           `json:"priceRange"`
+	Photos               []NewPhoto            `json:"photos"`
+	BusinessStatus       string                `json:"businessStatus"`
+	RegularOpeningHours  *NewOpeningHours      `json:"regularOpeningHours"`
+	WebsiteURI           string                `json:"websiteUri"`
+	AccessibilityOptions *AccessibilityOptions `json:"accessibilityOptions"`
+	AllowsDogs           *bool                 `json:"allowsDogs"`
+	ServesVegetarianFood *bool                 `json:"servesVegetarianFood"`
+	ServesBreakfast      *bool                 `json:"servesBreakfast"`
+	ServesBrunch         *bool                 `json:"servesBrunch"`
+	ServesLunch          *bool                 `json:"servesLunch"`
+	ServesDinner         *bool                 `json:"servesDinner"`
+}
+
+// LocalizedText 多語系文字結構
+type LocalizedText struct {
+	Text         string `json:"text"`
+	LanguageCode string `json:"languageCode"`
+}
+
+// LatLng 座標結構
+type LatLng struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// PriceRange 價格區間結構
+type PriceRange struct {
+	StartPrice *Money `json:"startPrice"`
+	EndPrice   *Money `json:"endPrice"` // 沒有 endPrice 表示「startPrice 以上」
+}
+
+// Money 金額結構
+type Money struct {
+	CurrencyCode string `json:"currencyCode"`
+	Units        string `json:"units"` // int64 以字串表示
+	Nanos        int    `json:"nanos"`
+}
+
+// NewPhoto Places API (New) 照片結構
+type NewPhoto struct {
+	Name     string `json:"name"` // places/{place_id}/photos/{photo_id}
+	WidthPx  int    `json:"widthPx"`
+	HeightPx int    `json:"heightPx"`
+}
+
+// NewOpeningHours Places API (New) 營業時間結構
+type NewOpeningHours struct {
+	OpenNow bool             `json:"openNow"`
+	Periods []NewPlacePeriod `json:"periods"`
+}
+
+// NewPlacePeriod Places API (New) 營業時段結構
+type NewPlacePeriod struct {
+	Open  NewPlaceTimePoint  `json:"open"`
+	Close *NewPlaceTimePoint `json:"close"` // 沒有 close 表示 24 小時營業
+}
+
+// NewPlaceTimePoint Places API (New) 營業時段的時間點結構
+type NewPlaceTimePoint struct {
+	Day    int `json:"day"` // 0 為星期日
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+}
+
+// AccessibilityOptions 無障礙設施結構
+type AccessibilityOptions struct {
+	WheelchairAccessibleParking  *bool `json:"wheelchairAccessibleParking"`
+	WheelchairAccessibleEntrance *bool `json:"wheelchairAccessibleEntrance"`
+	WheelchairAccessibleRestroom *bool `json:"wheelchairAccessibleRestroom"`
+	WheelchairAccessibleSeating  *bool `json:"wheelchairAccessibleSeating"`
+}
+
+// NewPlacesResponse Places API (New) 搜尋回應結構
+type NewPlacesResponse struct {
+	Places        []NewPlace `json:"places"`
+	NextPageToken string     `json:"nextPageToken"`
+}
+
+// NewPlacesErrorResponse Places API (New) 錯誤回應結構
+type NewPlacesErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"` // NOT_FOUND、RESOURCE_EXHAUSTED、PERMISSION_DENIED 等
+	} `json:"error"`
+}
+
+// errNewPlaceNotFound Places API (New) 回傳 404，取得詳細資訊時轉換為 domain.ErrProviderPlaceNotFound
+var errNewPlaceNotFound = fmt.Errorf("%w: NOT_FOUND", domain.ErrGoogleAPIFailed)
+
+// SearchNearbyRestaurants 搜尋附近餐廳
+func (s *GooglePlacesNewService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	return s.SearchNearby(ctx, lat, lng, radius, NearbySearchOptions{Type: "restaurant"})
+}
+
+// SearchNearby 依關鍵字與地點類型搜尋附近地點
+// 沒有關鍵字時使用 searchNearby（不分頁），有關鍵字時使用 searchText 並依 nextPageToken 最多讀取 MaxPages 頁
+func (s *GooglePlacesNewService) SearchNearby(ctx context.Context, lat, lng float64, radius int, options NearbySearchOptions) ([]domain.Restaurant, error) {
+	circle := map[string]interface{}{
+		"center": LatLng{Latitude: lat, Longitude: lng},
+		"radius": float64(radius),
+	}
+
+	var restaurants []domain.Restaurant
+	if options.Keyword == "" {
+		body := map[string]interface{}{
+			"maxResultCount":      placesNewMaxResults,
+			"locationRestriction": map[string]interface{}{"circle": circle},
+		}
+		if options.Type != "" {
+			body["includedTypes"] = []string{options.Type}
+		}
+		if s.language != "" {
+			body["languageCode"] = s.language
+		}
+
+		var placesResp NewPlacesResponse
+		if err := s.do(ctx, http.MethodPost, "/places:searchNearby", body, placesNewSearchFieldMask, &placesResp); err != nil {
+			return nil, err
+		}
+		for _, place := range placesResp.Places {
+			restaurants = append(restaurants, s.convertToRestaurant(place))
+		}
+	} else {
+		body := map[string]interface{}{
+			"textQuery":    options.Keyword,
+			"pageSize":     placesNewMaxResults,
+			"locationBias": map[string]interface{}{"circle": circle},
+		}
+		if options.Type != "" {
+			body["includedType"] = options.Type
+		}
+		if s.language != "" {
+			body["languageCode"] = s.language
+		}
+
+		for page := 1; page <= s.maxPages; page++ {
+			var placesResp NewPlacesResponse
+			if err := s.do(ctx, http.MethodPost, "/places:searchText", body, placesNewTextSearchFieldMask, &placesResp); err != nil {
+				// 已取得部分結果時回傳已讀取的頁面
+				if len(restaurants) > 0 {
+					logger.Warn("Google Places 讀取下一頁失敗，回傳已取得的結果", zap.Error(err), zap.Int("page", page))
+					break
+				}
+				return nil, err
+			}
+			for _, place := range placesResp.Places {
+				restaurants = append(restaurants, s.convertToRestaurant(place))
+			}
+
+			if placesResp.NextPageToken == "" {
+				break
+			}
+			body["pageToken"] = placesResp.NextPageToken
+		}
+	}
+
+	logger.Info("Google Places (New) 搜尋成功",
+		zap.Int("count", len(restaurants)),
+		zap.Float64("lat", lat),
+		zap.Float64("lng", lng),
+		zap.Int("radius", radius),
+		zap.String("keyword", options.Keyword),
+		zap.String("type", options.Type),
+	)
+
+	return restaurants, nil
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊
+func (s *GooglePlacesNewService) GetRestaurantDetails(ctx context.Context, googleID string) (*domain.Restaurant, error) {
+	path := "/places/" + url.PathEscape(googleID)
+	if s.language != "" {
+		path += "?languageCode=" + url.QueryEscape(s.language)
+	}
+
+	var place NewPlace
+	if err := s.do(ctx, http.MethodGet, path, nil, placesNewDetailFieldMask, &place); err != nil {
+		if errors.Is(err, errNewPlaceNotFound) {
+			logger.Warn("Google Places 查無此地點", zap.String("google_id", googleID))
+			return nil, domain.ErrProviderPlaceNotFound
+		}
+		return nil, err
+	}
+
+	restaurant := s.convertToRestaurant(place)
+
+	logger.Info("Google Places (New) 詳細資訊取得成功", zap.String("google_id", googleID))
+
+	return &restaurant, nil
+}
+
+// CircuitState 取得斷路器狀態
+func (s *GooglePlacesNewService) CircuitState() string {
+	return s.client.CircuitState()
+}
+
+// do 發送 Places API (New) 請求，以 X-Goog-FieldMask 指定回傳欄位並解析 JSON 回應
+func (s *GooglePlacesNewService) do(ctx context.Context, method, path string, body interface{}, fieldMask string, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	// 重試時需要重新建立請求內容
+	resp, err := s.client.do(ctx, func() (*http.Request, error) {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Goog-Api-Key", s.apiKey)
+		req.Header.Set("X-Goog-FieldMask", fieldMask)
+		return req, nil
+	})
+	if err != nil {
+		if isFailFast(err) {
+			return err
+		}
+		logger.Error("Google Places (New) 請求失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp NewPlacesErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		logger.Error("Google Places (New) 錯誤",
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("status", errResp.Error.Status),
+			zap.String("message", errResp.Error.Message),
+			zap.String("path", path),
+		)
+
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return errNewPlaceNotFound
+		case resp.StatusCode == http.StatusTooManyRequests || errResp.Error.Status == "RESOURCE_EXHAUSTED":
+			return domain.ErrAPIQuotaExceeded
+		case errResp.Error.Status != "":
+			return fmt.Errorf("%w: %s（%s）", domain.ErrGoogleAPIFailed, errResp.Error.Status, errResp.Error.Message)
+		default:
+			return fmt.Errorf("%w: HTTP %d", domain.ErrGoogleAPIFailed, resp.StatusCode)
+		}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		logger.Error("解析 Google Places (New) 回應失敗", zap.Error(err), zap.String("path", path))
+		return fmt.Errorf("%w: %v", domain.ErrGoogleAPIFailed, err)
+	}
+
+	return nil
+}
+
+// convertToRestaurant 將 Places API (New) 地點轉換為餐廳實體
+func (s *GooglePlacesNewService) convertToRestaurant(place NewPlace) domain.Restaurant {
+	var imageURL string
+	if len(place.Photos) > 0 {
+		imageURL = domain.PlacePhotoPath(domain.NewAPIPhotoRef(place.Photos[0].Name))
+	}
+
+	restaurant := domain.Restaurant{
+		Name:           place.DisplayName.Text,
+		Address:        place.FormattedAddress,
+		Phone:          place.NationalPhoneNumber,
+		Latitude:       place.Location.Latitude,
+		Longitude:      place.Location.Longitude,
+		Rating:         float32(place.Rating),
+		PriceLevel:     s.convertPriceLevel(place.PriceLevel, place.PriceRange),
+		GoogleID:       place.ID,
+		ImageURL:       imageURL,
+		IsActive:       true,
+		Source:         domain.RestaurantSourceGoogle,
+		ProviderTypes:  place.Types,
+		BusinessStatus: place.BusinessStatus,
+		ProviderTags:   convertPlaceTags(place),
+	}
+	if place.RegularOpeningHours != nil {
+		restaurant.OpeningHours = convertNewOpeningPeriods(place.RegularOpeningHours.Periods)
+	}
+
+	return restaurant
+}
+
+// convertPriceLevel 將價位等級轉換為 1-4，沒有價位等級時以價格區間中間值換算
+func (s *GooglePlacesNewService) convertPriceLevel(priceLevel string, priceRange *PriceRange) int {
+	switch priceLevel {
+	case "PRICE_LEVEL_INEXPENSIVE":
+		return 1
+	case "PRICE_LEVEL_MODERATE":
+		return 2
+	case "PRICE_LEVEL_EXPENSIVE":
+		return 3
+	case "PRICE_LEVEL_VERY_EXPENSIVE":
+		return 4
+	}
+
+	if priceRange == nil || priceRange.StartPrice == nil || len(s.priceLevelThresholds) == 0 {
+		return 0
+	}
+
+	var prices []float64
+	for _, price := range []*Money{priceRange.StartPrice, priceRange.EndPrice} {
+		if price == nil || price.CurrencyCode != placesNewPriceCurrency {
+			continue
+		}
+		units, err := strconv.ParseFloat(price.Units, 64)
+		if err != nil {
+			continue
+		}
+		prices = append(prices, units+float64(price.Nanos)/1e9)
+	}
+	return domain.DerivePriceLevel(prices, s.priceLevelThresholds)
+}
+
+// convertPlaceTags 將無障礙設施與供餐資訊轉換為標籤識別碼
+func convertPlaceTags(place NewPlace) []string {
+	isTrue := func(value *bool) bool { return value != nil && *value }
+
+	var tags []string
+	if options := place.AccessibilityOptions; options != nil &&
+		(isTrue(options.WheelchairAccessibleEntrance) || isTrue(options.WheelchairAccessibleSeating)) {
+		tags = append(tags, "wheelchair_accessible")
+	}
+	if isTrue(place.AllowsDogs) {
+		tags = append(tags, "pet_friendly")
+	}
+	if isTrue(place.ServesVegetarianFood) {
+		tags = append(tags, "vegetarian")
+	}
+	if isTrue(place.ServesBreakfast) {
+		tags = append(tags, "breakfast")
+	}
+	if isTrue(place.ServesBrunch) {
+		tags = append(tags, "brunch")
+	}
+	if isTrue(place.ServesLunch) {
+		tags = append(tags, "lunch")
+	}
+	if isTrue(place.ServesDinner) {
+		tags = append(tags, "dinner")
+	}
+	return tags
+}
+
+// convertNewOpeningPeriods 將 Places API (New) 營業時段轉換為營業時段
+func convertNewOpeningPeriods(periods []NewPlacePeriod) []domain.OpeningPeriod {
+	result := make([]domain.OpeningPeriod, 0, len(periods))
+	for _, period := range periods {
+		converted := domain.OpeningPeriod{
+			OpenDay:  period.Open.Day,
+			OpenTime: fmt.Sprintf("%02d:%02d", period.Open.Hour, period.Open.Minute),
+		}
+		if period.Close != nil {
+			converted.CloseDay = period.Close.Day
+			converted.CloseTime = fmt.Sprintf("%02d:%02d", period.Close.Hour, period.Close.Minute)
+		}
+		result = append(result, converted)
+	}
+	return result
+}
@@ -0,0 +1,196 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// newTestPlacesNewService 建立連線至測試伺服器的 Places API (New) 服務
+func newTestPlacesNewService(t *testing.T, maxPages int, handler http.HandlerFunc) *GooglePlacesNewService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	service := NewGooglePlacesNewService("test-key", GooglePlacesOptions{
+		MaxPages:             maxPages,
+		Language:             "zh-TW",
+		PriceLevelThresholds: []float64{150, 400, 1000},
+	})
+	service.baseURL = server.URL
+	return service
+}
+
+func TestGooglePlacesNewSearchNearby(t *testing.T) {
+	service := newTestPlacesNewService(t, 3, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/places:searchNearby" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Goog-Api-Key") != "test-key" {
+			t.Errorf("X-Goog-Api-Key = %q", r.Header.Get("X-Goog-Api-Key"))
+		}
+		if r.Header.Get("X-Goog-FieldMask") != placesNewSearchFieldMask {
+			t.Errorf("X-Goog-FieldMask = %q", r.Header.Get("X-Goog-FieldMask"))
+		}
+
+		var body struct {
+			IncludedTypes       []string `json:"includedTypes"`
+			LanguageCode        string   `json:"languageCode"`
+			LocationRestriction struct {
+				Circle struct {
+					Center LatLng  `json:"center"`
+					Radius float64 `json:"radius"`
+				} `json:"circle"`
+			} `json:"locationRestriction"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("解析請求內容失敗: %v", err)
+		}
+		if !reflect.DeepEqual(body.IncludedTypes, []string{"restaurant"}) || body.LanguageCode != "zh-TW" ||
+			body.LocationRestriction.Circle.Radius != 500 || body.LocationRestriction.Circle.Center.Latitude != 25.03 {
+			t.Errorf("body = %+v", body)
+		}
+
+		fmt.Fprint(w, `{"places":[
+			{"id":"a","displayName":{"text":"甲"},"location":{"latitude":25.03,"longitude":121.56},"priceLevel":"PRICE_LEVEL_EXPENSIVE","rating":4.5,
+			 "photos":[{"name":"places/a/photos/p1"}],"businessStatus":"OPERATIONAL"},
+			{"id":"b","displayName":{"text":"乙"},"priceRange":{"startPrice":{"currencyCode":"TWD","units":"200"},"endPrice":{"currencyCode":"TWD","units":"400"}}}
+		]}`)
+	})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 2 {
+		t.Fatalf("len = %d, want 2", len(restaurants))
+	}
+
+	first := restaurants[0]
+	if first.GoogleID != "a" || first.Name != "甲" || first.PriceLevel != 3 || first.Rating != 4.5 || first.Longitude != 121.56 {
+		t.Errorf("first = %+v", first)
+	}
+	if first.ImageURL != "/media/places/a.p1" || first.BusinessStatus != domain.ProviderBusinessOperational {
+		t.Errorf("ImageURL = %q, BusinessStatus = %q", first.ImageURL, first.BusinessStatus)
+	}
+	// 沒有價位等級時以價格區間中間值（300）換算
+	if restaurants[1].PriceLevel != 2 {
+		t.Errorf("price level from range = %d, want 2", restaurants[1].PriceLevel)
+	}
+}
+
+func TestGooglePlacesNewSearchText(t *testing.T) {
+	var requests int
+	service := newTestPlacesNewService(t, 2, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/places:searchText" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Goog-FieldMask") != placesNewTextSearchFieldMask {
+			t.Errorf("X-Goog-FieldMask = %q", r.Header.Get("X-Goog-FieldMask"))
+		}
+
+		var body struct {
+			TextQuery    string `json:"textQuery"`
+			IncludedType string `json:"includedType"`
+			PageToken    string `json:"pageToken"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("解析請求內容失敗: %v", err)
+		}
+		if body.TextQuery != "拉麵" || body.IncludedType != "restaurant" {
+			t.Errorf("body = %+v", body)
+		}
+
+		// 每頁都回傳 nextPageToken，應在 MaxPages 頁後停止
+		fmt.Fprintf(w, `{"places":[{"id":"p%d","displayName":{"text":"店"}}],"nextPageToken":"page-%d"}`, requests, requests+1)
+		if requests == 2 && body.PageToken != "page-2" {
+			t.Errorf("pageToken = %q, want page-2", body.PageToken)
+		}
+	})
+
+	restaurants, err := service.SearchNearby(context.Background(), 25.03, 121.56, 500, NearbySearchOptions{Keyword: "拉麵", Type: "restaurant"})
+	if err != nil {
+		t.Fatalf("SearchNearby() error = %v", err)
+	}
+	if len(restaurants) != 2 || requests != 2 {
+		t.Errorf("len = %d, requests = %d, want 2 and 2", len(restaurants), requests)
+	}
+}
+
+func TestGooglePlacesNewGetRestaurantDetails(t *testing.T) {
+	service := newTestPlacesNewService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/places/abc" || r.URL.Query().Get("languageCode") != "zh-TW" {
+			t.Errorf("request = %s %s", r.Method, r.URL.String())
+		}
+		if r.Header.Get("X-Goog-FieldMask") != placesNewDetailFieldMask {
+			t.Errorf("X-Goog-FieldMask = %q", r.Header.Get("X-Goog-FieldMask"))
+		}
+
+		fmt.Fprint(w, `{
+			"id":"abc","displayName":{"text":"甲"},"nationalPhoneNumber":"02 1234 5678","priceLevel":"PRICE_LEVEL_INEXPENSIVE",
+			"businessStatus":"CLOSED_TEMPORARILY",
+			"regularOpeningHours":{"periods":[
+				{"open":{"day":1,"hour":11,"minute":30},"close":{"day":1,"hour":21,"minute":0}},
+				{"open":{"day":6,"hour":0,"minute":0}}
+			]},
+			"accessibilityOptions":{"wheelchairAccessibleEntrance":true},
+			"servesVegetarianFood":true,"allowsDogs":false,"servesDinner":true
+		}`)
+	})
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("GetRestaurantDetails() error = %v", err)
+	}
+	if restaurant.Phone != "02 1234 5678" || restaurant.PriceLevel != 1 || restaurant.BusinessStatus != domain.ProviderBusinessClosedTemporarily {
+		t.Errorf("restaurant = %+v", restaurant)
+	}
+
+	wantHours := []domain.OpeningPeriod{
+		{OpenDay: 1, OpenTime: "11:30", CloseDay: 1, CloseTime: "21:00"},
+		{OpenDay: 6, OpenTime: "00:00"},
+	}
+	if !reflect.DeepEqual(restaurant.OpeningHours, wantHours) {
+		t.Errorf("OpeningHours = %+v, want %+v", restaurant.OpeningHours, wantHours)
+	}
+
+	wantTags := []string{"wheelchair_accessible", "vegetarian", "dinner"}
+	if !reflect.DeepEqual(restaurant.ProviderTags, wantTags) {
+		t.Errorf("ProviderTags = %v, want %v", restaurant.ProviderTags, wantTags)
+	}
+}
+
+func TestGooglePlacesNewErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    error
+	}{
+		{name: "查無地點", statusCode: http.StatusNotFound, body: `{"error":{"code":404,"status":"NOT_FOUND"}}`, wantErr: domain.ErrProviderPlaceNotFound},
+		{name: "額度用盡", statusCode: http.StatusTooManyRequests, body: `{"error":{"code":429,"status":"RESOURCE_EXHAUSTED"}}`, wantErr: domain.ErrAPIQuotaExceeded},
+		{name: "金鑰無效", statusCode: http.StatusForbidden, body: `{"error":{"code":403,"status":"PERMISSION_DENIED","message":"denied"}}`, wantErr: domain.ErrGoogleAPIFailed},
+		{name: "伺服器錯誤", statusCode: http.StatusBadGateway, body: ``, wantErr: domain.ErrGoogleAPIFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestPlacesNewService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				fmt.Fprint(w, tt.body)
+			})
+
+			if _, err := service.GetRestaurantDetails(context.Background(), "abc"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
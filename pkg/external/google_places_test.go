@@ -0,0 +1,187 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Logger = zap.NewNop()
+	os.Exit(m.Run())
+}
+
+// newTestPlacesService 建立連線至測試伺服器的舊版 Places 服務
+func newTestPlacesService(t *testing.T, maxPages int, handler http.HandlerFunc) *GooglePlacesService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	service := NewGooglePlacesService("test-key", GooglePlacesOptions{MaxPages: maxPages, Language: "zh-TW"})
+	service.baseURL = server.URL
+	service.pageTokenDelay = 0
+	return service
+}
+
+func TestGooglePlacesSearchNearbyPagination(t *testing.T) {
+	var requests int
+	service := newTestPlacesService(t, 3, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/nearbysearch/json" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("key") != "test-key" || query.Get("language") != "zh-TW" {
+			t.Errorf("缺少金鑰或語言參數: %s", r.URL.RawQuery)
+		}
+
+		switch query.Get("pagetoken") {
+		case "":
+			if query.Get("type") != "restaurant" || query.Get("radius") != "500" {
+				t.Errorf("第一頁參數錯誤: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"status":"OK","next_page_token":"page-2","results":[{"place_id":"a","name":"甲"},{"place_id":"b","name":"乙"}]}`)
+		case "page-2":
+			// next_page_token 尚未生效時回傳 INVALID_REQUEST，應等待後重試
+			if requests == 2 {
+				fmt.Fprint(w, `{"status":"INVALID_REQUEST"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"OK","results":[{"place_id":"c","name":"丙"}]}`)
+		default:
+			t.Errorf("未預期的 pagetoken: %s", query.Get("pagetoken"))
+		}
+	})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 3 || restaurants[2].GoogleID != "c" {
+		t.Fatalf("restaurants = %+v", restaurants)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestGooglePlacesSearchNearbyMaxPages(t *testing.T) {
+	var requests int
+	service := newTestPlacesService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"status":"OK","next_page_token":"page-2","results":[{"place_id":"a","name":"甲"}]}`)
+	})
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 1 || requests != 1 {
+		t.Errorf("len = %d, requests = %d, want 1 and 1", len(restaurants), requests)
+	}
+}
+
+func TestGooglePlacesSearchNearbyKeyword(t *testing.T) {
+	service := newTestPlacesService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("keyword") != "拉麵" || query.Get("type") != "cafe" {
+			t.Errorf("關鍵字或類型參數錯誤: %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"status":"OK","results":[{"place_id":"a","name":"甲","types":["cafe"]}]}`)
+	})
+
+	restaurants, err := service.SearchNearby(context.Background(), 25.03, 121.56, 500, NearbySearchOptions{Keyword: "拉麵", Type: "cafe"})
+	if err != nil {
+		t.Fatalf("SearchNearby() error = %v", err)
+	}
+	if len(restaurants) != 1 || restaurants[0].ProviderTypes[0] != "cafe" {
+		t.Errorf("restaurants = %+v", restaurants)
+	}
+}
+
+func TestGooglePlacesStatusMapping(t *testing.T) {
+	tests := []struct {
+		status  string
+		wantErr error
+	}{
+		{status: "ZERO_RESULTS", wantErr: nil},
+		{status: "OVER_QUERY_LIMIT", wantErr: domain.ErrAPIQuotaExceeded},
+		{status: "REQUEST_DENIED", wantErr: domain.ErrGoogleAPIFailed},
+		{status: "INVALID_REQUEST", wantErr: domain.ErrGoogleAPIFailed},
+		{status: "UNKNOWN_ERROR", wantErr: domain.ErrGoogleAPIFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			service := newTestPlacesService(t, 3, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"status":%q,"error_message":"測試"}`, tt.status)
+			})
+
+			restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+			if tt.wantErr == nil {
+				if err != nil || len(restaurants) != 0 {
+					t.Errorf("got %v, %v; want no results and no error", restaurants, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGooglePlacesHTTPError(t *testing.T) {
+	service := newTestPlacesService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500); !errors.Is(err, domain.ErrGoogleAPIFailed) {
+		t.Errorf("error = %v, want %v", err, domain.ErrGoogleAPIFailed)
+	}
+}
+
+func TestGooglePlacesGetRestaurantDetails(t *testing.T) {
+	service := newTestPlacesService(t, 1, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/details/json" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("place_id") {
+		case "missing":
+			fmt.Fprint(w, `{"status":"NOT_FOUND"}`)
+		default:
+			fmt.Fprint(w, `{"status":"OK","result":{
+				"place_id":"a","name":"甲","formatted_phone_number":"02 1234 5678","price_level":2,
+				"business_status":"CLOSED_PERMANENTLY",
+				"geometry":{"location":{"lat":25.03,"lng":121.56}},
+				"opening_hours":{"periods":[{"open":{"day":1,"time":"1130"},"close":{"day":1,"time":"2100"}}]}
+			}}`)
+		}
+	})
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("GetRestaurantDetails() error = %v", err)
+	}
+	if restaurant.Name != "甲" || restaurant.PriceLevel != 2 || restaurant.Latitude != 25.03 {
+		t.Errorf("restaurant = %+v", restaurant)
+	}
+	if restaurant.BusinessStatus != domain.ProviderBusinessClosedPermanently {
+		t.Errorf("BusinessStatus = %q", restaurant.BusinessStatus)
+	}
+	if len(restaurant.OpeningHours) != 1 || restaurant.OpeningHours[0].OpenTime != "11:30" || restaurant.OpeningHours[0].CloseTime != "21:00" {
+		t.Errorf("OpeningHours = %+v", restaurant.OpeningHours)
+	}
+
+	if _, err := service.GetRestaurantDetails(context.Background(), "missing"); !errors.Is(err, domain.ErrProviderPlaceNotFound) {
+		t.Errorf("error = %v, want %v", err, domain.ErrProviderPlaceNotFound)
+	}
+}
@@ -0,0 +1,387 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// offlineCellSize 空間索引格網的大小（度，約 1 公里）
+	offlineCellSize = 0.01
+	// offlineMaxResults 每次搜尋最多回傳的餐廳數，與 Google Places 三頁結果相同
+	offlineMaxResults = 60
+)
+
+// offlineCell 空間索引格網座標
+type offlineCell struct {
+	lat, lng int
+}
+
+// OfflineService 以本地 OpenStreetMap 資料檔提供餐廳資料，適用於開發、測試與沒有外部 API 額度的地區
+// 支援 GeoJSON（FeatureCollection 或 osmium export 的 GeoJSON Sequence）與 Overpass JSON
+type OfflineService struct {
+	restaurants []domain.Restaurant
+	byPlaceID   map[string]int
+	grid        map[offlineCell][]int
+}
+
+// LoadOfflineService 讀取本地資料檔並建立離線資料來源
+func LoadOfflineService(path string) (*OfflineService, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	service, err := NewOfflineService(file)
+	if err != nil {
+		return nil, fmt.Errorf("讀取離線資料檔 %s 失敗: %w", path, err)
+	}
+	return service, nil
+}
+
+// NewOfflineService 解析資料內容並建立空間索引，只保留有名稱的餐飲地點
+func NewOfflineService(r io.Reader) (*OfflineService, error) {
+	service := &OfflineService{
+		byPlaceID: make(map[string]int),
+		grid:      make(map[offlineCell][]int),
+	}
+
+	// GeoJSON Sequence 的每筆資料可能以 RS（0x1E）字元開頭
+	decoder := json.NewDecoder(&recordSeparatorReader{r: bufio.NewReader(r)})
+	skipped := 0
+	for {
+		var document offlineDocument
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		for _, element := range document.elements() {
+			if !osmFoodAmenities[element.Tags["amenity"]] || element.Tags["name"] == "" {
+				skipped++
+				continue
+			}
+			service.add(ConvertOSMElement(element))
+		}
+	}
+
+	logger.Info("離線餐廳資料載入完成",
+		zap.Int("count", len(service.restaurants)),
+		zap.Int("skipped", skipped),
+	)
+	return service, nil
+}
+
+// add 將餐廳加入索引，同一地點重複出現時以後者為準
+func (s *OfflineService) add(restaurant domain.Restaurant) {
+	if index, exists := s.byPlaceID[restaurant.GoogleID]; exists {
+		s.restaurants[index] = restaurant
+		return
+	}
+
+	index := len(s.restaurants)
+	s.restaurants = append(s.restaurants, restaurant)
+	s.byPlaceID[restaurant.GoogleID] = index
+
+	cell := offlineCellOf(restaurant.Latitude, restaurant.Longitude)
+	s.grid[cell] = append(s.grid[cell], index)
+}
+
+// Len 離線資料中的餐廳數
+func (s *OfflineService) Len() int {
+	return len(s.restaurants)
+}
+
+// Restaurants 取得所有離線餐廳資料
+func (s *OfflineService) Restaurants() []domain.Restaurant {
+	restaurants := make([]domain.Restaurant, len(s.restaurants))
+	for i := range s.restaurants {
+		restaurants[i] = cloneRestaurant(&s.restaurants[i])
+	}
+	return restaurants
+}
+
+// SearchNearbyRestaurants 搜尋附近餐廳，依距離由近到遠排序
+func (s *OfflineService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 依半徑換算需要掃描的格網範圍
+	latDelta := float64(radius) / 111320
+	lngDelta := latDelta / math.Max(math.Cos(lat*math.Pi/180), 0.01)
+	minCell := offlineCellOf(lat-latDelta, lng-lngDelta)
+	maxCell := offlineCellOf(lat+latDelta, lng+lngDelta)
+
+	type candidate struct {
+		index    int
+		distance float64
+	}
+	var candidates []candidate
+	for cellLat := minCell.lat; cellLat <= maxCell.lat; cellLat++ {
+		for cellLng := minCell.lng; cellLng <= maxCell.lng; cellLng++ {
+			for _, index := range s.grid[offlineCell{lat: cellLat, lng: cellLng}] {
+				restaurant := &s.restaurants[index]
+				distance := domain.DistanceMeters(lat, lng, restaurant.Latitude, restaurant.Longitude)
+				if distance <= float64(radius) {
+					candidates = append(candidates, candidate{index: index, distance: distance})
+				}
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	if len(candidates) > offlineMaxResults {
+		candidates = candidates[:offlineMaxResults]
+	}
+
+	restaurants := make([]domain.Restaurant, 0, len(candidates))
+	for _, c := range candidates {
+		restaurants = append(restaurants, cloneRestaurant(&s.restaurants[c.index]))
+	}
+	return restaurants, nil
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊，placeID 格式為 osm:node/123
+func (s *OfflineService) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	index, exists := s.byPlaceID[placeID]
+	if !exists {
+		return nil, domain.ErrProviderPlaceNotFound
+	}
+	restaurant := cloneRestaurant(&s.restaurants[index])
+	return &restaurant, nil
+}
+
+// offlineCellOf 取得座標所在的格網
+func offlineCellOf(lat, lng float64) offlineCell {
+	return offlineCell{
+		lat: int(math.Floor(lat / offlineCellSize)),
+		lng: int(math.Floor(lng / offlineCellSize)),
+	}
+}
+
+// cloneRestaurant 複製餐廳資料，避免呼叫端修改索引中共用的切片
+func cloneRestaurant(restaurant *domain.Restaurant) domain.Restaurant {
+	clone := *restaurant
+	clone.OpeningHours = append([]domain.OpeningPeriod(nil), restaurant.OpeningHours...)
+	clone.ProviderTypes = append([]string(nil), restaurant.ProviderTypes...)
+	clone.ProviderTags = append([]string(nil), restaurant.ProviderTags...)
+	return clone
+}
+
+// recordSeparatorReader 移除 GeoJSON Sequence（RFC 8142）的 RS 字元
+type recordSeparatorReader struct {
+	r *bufio.Reader
+}
+
+func (r *recordSeparatorReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if b == 0x1e {
+			continue
+		}
+		p[n] = b
+		n++
+		if r.r.Buffered() == 0 {
+			break
+		}
+	}
+	return n, nil
+}
+
+// offlineDocument 資料檔中的一筆 JSON：GeoJSON FeatureCollection、單一 Feature 或 Overpass 回應
+type offlineDocument struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+	Elements []OSMElement     `json:"elements"`
+	geoJSONFeature
+}
+
+// geoJSONFeature GeoJSON Feature 結構
+type geoJSONFeature struct {
+	ID         json.RawMessage        `json:"id"`
+	Geometry   *geoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONGeometry GeoJSON 幾何結構，座標依類型巢狀不同層數
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// elements 將資料轉換為 OpenStreetMap 元素
+func (d *offlineDocument) elements() []OSMElement {
+	switch d.Type {
+	case "FeatureCollection":
+		elements := make([]OSMElement, 0, len(d.Features))
+		for i := range d.Features {
+			if element, ok := d.Features[i].element(); ok {
+				elements = append(elements, element)
+			}
+		}
+		return elements
+	case "Feature":
+		if element, ok := d.geoJSONFeature.element(); ok {
+			return []OSMElement{element}
+		}
+		return nil
+	default:
+		return d.Elements
+	}
+}
+
+// element 將 GeoJSON Feature 轉換為 OpenStreetMap 元素，沒有 OSM ID 或座標時回傳 false
+func (f *geoJSONFeature) element() (OSMElement, bool) {
+	if f.Geometry == nil {
+		return OSMElement{}, false
+	}
+	var coordinates interface{}
+	if err := json.Unmarshal(f.Geometry.Coordinates, &coordinates); err != nil {
+		return OSMElement{}, false
+	}
+	lat, lng, ok := geoJSONCenter(coordinates)
+	if !ok {
+		return OSMElement{}, false
+	}
+
+	tags := make(map[string]string, len(f.Properties))
+	for key, value := range f.Properties {
+		switch v := value.(type) {
+		case string:
+			tags[key] = v
+		case map[string]interface{}:
+			// osmtogeojson 舊版將 OSM 標籤放在 properties.tags
+			if key == "tags" {
+				for tagKey, tagValue := range v {
+					if s, ok := tagValue.(string); ok {
+						tags[tagKey] = s
+					}
+				}
+			}
+		}
+	}
+
+	elementType, elementID, ok := geoJSONOSMID(f.ID, f.Properties, f.Geometry.Type)
+	if !ok {
+		return OSMElement{}, false
+	}
+
+	return OSMElement{
+		Type:   elementType,
+		ID:     elementID,
+		Center: &OSMCenter{Lat: lat, Lon: lng},
+		Tags:   tags,
+	}, true
+}
+
+// osmTypePrefixes osmium 唯一 ID 的前綴（n123、w123、r123）
+var osmTypePrefixes = map[byte]string{'n': "node", 'w': "way", 'r': "relation"}
+
+// geoJSONOSMID 取得 Feature 對應的 OSM 元素類型與 ID
+// 支援 node/123（osmtogeojson、Overpass Turbo）、n123（osmium --add-unique-id=type_id）
+// 以及 properties 的 @type、@id（osmium -a type,id），只有數字 ID 時依幾何類型判斷
+func geoJSONOSMID(rawID json.RawMessage, properties map[string]interface{}, geometryType string) (string, int64, bool) {
+	var id string
+	if len(rawID) > 0 {
+		var s string
+		if err := json.Unmarshal(rawID, &s); err == nil {
+			id = s
+		} else {
+			id = string(rawID)
+		}
+	}
+	if value, ok := properties["@id"]; ok {
+		switch v := value.(type) {
+		case string:
+			id = v
+		case float64:
+			id = strconv.FormatInt(int64(v), 10)
+		}
+	}
+
+	if elementType, elementID, found := strings.Cut(id, "/"); found {
+		parsed, err := strconv.ParseInt(elementID, 10, 64)
+		return elementType, parsed, err == nil && (elementType == "node" || elementType == "way" || elementType == "relation")
+	}
+	if len(id) > 1 {
+		if elementType, ok := osmTypePrefixes[id[0]]; ok {
+			if parsed, err := strconv.ParseInt(id[1:], 10, 64); err == nil {
+				return elementType, parsed, true
+			}
+		}
+	}
+
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	if elementType, ok := properties["@type"].(string); ok {
+		return elementType, parsed, true
+	}
+	if geometryType == "Point" {
+		return "node", parsed, true
+	}
+	return "way", parsed, true
+}
+
+// geoJSONCenter 取得 GeoJSON 座標的中心點：Point 直接使用，其他幾何使用外框中心（與 Overpass out center 相同）
+func geoJSONCenter(coordinates interface{}) (float64, float64, bool) {
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+	minLng, maxLng := math.Inf(1), math.Inf(-1)
+
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		values, ok := value.([]interface{})
+		if !ok {
+			return
+		}
+		if len(values) >= 2 {
+			lng, lngOK := values[0].(float64)
+			lat, latOK := values[1].(float64)
+			if lngOK && latOK {
+				minLat, maxLat = math.Min(minLat, lat), math.Max(maxLat, lat)
+				minLng, maxLng = math.Min(minLng, lng), math.Max(maxLng, lng)
+				return
+			}
+		}
+		for _, child := range values {
+			walk(child)
+		}
+	}
+	walk(coordinates)
+
+	if math.IsInf(minLat, 1) {
+		return 0, 0, false
+	}
+	return (minLat + maxLat) / 2, (minLng + maxLng) / 2, true
+}
@@ -0,0 +1,118 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+const testFeatureCollection = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","id":"node/1","geometry":{"type":"Point","coordinates":[121.5654,25.0330]},
+	 "properties":{"amenity":"restaurant","name":"拉麵店","cuisine":"ramen","opening_hours":"24/7"}},
+	{"type":"Feature","id":"way/2","geometry":{"type":"Polygon","coordinates":[[[121.5660,25.0340],[121.5670,25.0340],[121.5670,25.0350],[121.5660,25.0340]]]},
+	 "properties":{"@id":"way/2","tags":{"amenity":"cafe","name":"咖啡店"}}},
+	{"type":"Feature","id":"node/3","geometry":{"type":"Point","coordinates":[121.6000,25.0800]},
+	 "properties":{"amenity":"fast_food","name":"遠方漢堡"}},
+	{"type":"Feature","id":"node/4","geometry":{"type":"Point","coordinates":[121.5654,25.0331]},
+	 "properties":{"amenity":"bank","name":"銀行"}},
+	{"type":"Feature","id":"node/5","geometry":{"type":"Point","coordinates":[121.5654,25.0332]},
+	 "properties":{"amenity":"restaurant"}}
+]}`
+
+func TestOfflineSearchNearby(t *testing.T) {
+	service, err := NewOfflineService(strings.NewReader(testFeatureCollection))
+	if err != nil {
+		t.Fatalf("NewOfflineService() error = %v", err)
+	}
+	if service.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3（略過非餐飲與沒有名稱的地點）", service.Len())
+	}
+
+	restaurants, err := service.SearchNearbyRestaurants(context.Background(), 25.0330, 121.5654, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 2 {
+		t.Fatalf("len = %d, want 2: %+v", len(restaurants), restaurants)
+	}
+	if restaurants[0].GoogleID != "osm:node/1" || restaurants[1].GoogleID != "osm:way/2" {
+		t.Errorf("應依距離排序: %s, %s", restaurants[0].GoogleID, restaurants[1].GoogleID)
+	}
+	if restaurants[0].Source != domain.RestaurantSourceOSM || restaurants[0].Cuisine != "ramen" || len(restaurants[0].OpeningHours) != 1 {
+		t.Errorf("restaurant = %+v", restaurants[0])
+	}
+	if cafe := restaurants[1]; cafe.Name != "咖啡店" || math.Abs(cafe.Latitude-25.0345) > 1e-9 || math.Abs(cafe.Longitude-121.5665) > 1e-9 {
+		t.Errorf("way 應使用外框中心點: %+v", cafe)
+	}
+
+	// 修改回傳結果不影響索引
+	restaurants[0].ProviderTypes[0] = "changed"
+	again, _ := service.SearchNearbyRestaurants(context.Background(), 25.0330, 121.5654, 500)
+	if again[0].ProviderTypes[0] != "ramen" {
+		t.Errorf("ProviderTypes = %v", again[0].ProviderTypes)
+	}
+
+	far, err := service.SearchNearbyRestaurants(context.Background(), 25.0330, 121.5654, 10000)
+	if err != nil || len(far) != 3 {
+		t.Errorf("擴大半徑後 len = %d, error = %v, want 3", len(far), err)
+	}
+}
+
+func TestOfflineGetRestaurantDetails(t *testing.T) {
+	service, err := NewOfflineService(strings.NewReader(testFeatureCollection))
+	if err != nil {
+		t.Fatalf("NewOfflineService() error = %v", err)
+	}
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "osm:way/2")
+	if err != nil || restaurant.Name != "咖啡店" {
+		t.Errorf("GetRestaurantDetails() = %+v, %v", restaurant, err)
+	}
+	if _, err := service.GetRestaurantDetails(context.Background(), "osm:node/4"); !errors.Is(err, domain.ErrProviderPlaceNotFound) {
+		t.Errorf("error = %v, want %v", err, domain.ErrProviderPlaceNotFound)
+	}
+}
+
+func TestOfflineFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		wantID string
+	}{
+		{
+			name:   "osmium GeoJSON Sequence",
+			data:   "\x1e{\"type\":\"Feature\",\"id\":\"n10\",\"geometry\":{\"type\":\"Point\",\"coordinates\":[121.5,25.0]},\"properties\":{\"amenity\":\"cafe\",\"name\":\"甲\"}}\n\x1e{\"type\":\"Feature\",\"id\":\"w11\",\"geometry\":{\"type\":\"LineString\",\"coordinates\":[[121.5,25.0],[121.6,25.1]]},\"properties\":{\"amenity\":\"cafe\",\"name\":\"乙\"}}\n",
+			wantID: "osm:node/10",
+		},
+		{
+			name:   "osmium @type 與 @id 屬性",
+			data:   `{"type":"Feature","geometry":{"type":"Point","coordinates":[121.5,25.0]},"properties":{"@type":"node","@id":12,"amenity":"restaurant","name":"丙"}}`,
+			wantID: "osm:node/12",
+		},
+		{
+			name:   "Overpass JSON",
+			data:   `{"elements":[{"type":"node","id":13,"lat":25.0,"lon":121.5,"tags":{"amenity":"restaurant","name":"丁"}}]}`,
+			wantID: "osm:node/13",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewOfflineService(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("NewOfflineService() error = %v", err)
+			}
+			if _, err := service.GetRestaurantDetails(context.Background(), tt.wantID); err != nil {
+				t.Errorf("GetRestaurantDetails(%s) error = %v", tt.wantID, err)
+			}
+		})
+	}
+
+	if _, err := NewOfflineService(strings.NewReader(`{"type":`)); err == nil {
+		t.Error("格式錯誤的資料檔應回傳錯誤")
+	}
+}
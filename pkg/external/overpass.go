@@ -0,0 +1,336 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultOverpassURL 公用 Overpass API 端點
+const DefaultOverpassURL = "https://overpass-api.de/api/interpreter"
+
+// osmFoodAmenities 視為餐廳的 OpenStreetMap amenity 值
+var osmFoodAmenities = map[string]bool{
+	"restaurant": true,
+	"fast_food":  true,
+	"cafe":       true,
+	"food_court": true,
+}
+
+// OverpassService OpenStreetMap Overpass API 服務
+type OverpassService struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOverpassService 建立 Overpass API 服務，baseURL 為空時使用公用端點
+func NewOverpassService(baseURL string) *OverpassService {
+	if baseURL == "" {
+		baseURL = DefaultOverpassURL
+	}
+	return &OverpassService{
+		baseURL: baseURL,
+		client:  &http.Client{},
+	}
+}
+
+// OverpassResponse Overpass API 回應結構
+type OverpassResponse struct {
+	Elements []OSMElement `json:"elements"`
+}
+
+// OSMElement OpenStreetMap 元素結構（node、way 或 relation）
+type OSMElement struct {
+	Type   string            `json:"type"`
+	ID     int64             `json:"id"`
+	Lat    float64           `json:"lat"`
+	Lon    float64           `json:"lon"`
+	Center *OSMCenter        `json:"center"` // way 與 relation 的中心點（out center）
+	Tags   map[string]string `json:"tags"`
+}
+
+// OSMCenter way 與 relation 的中心點結構
+type OSMCenter struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// SearchNearbyRestaurants 搜尋附近餐廳
+func (s *OverpassService) SearchNearbyRestaurants(ctx context.Context, lat, lng float64, radius int) ([]domain.Restaurant, error) {
+	query := fmt.Sprintf(`[out:json][timeout:25];
+nwr["amenity"~"^(restaurant|fast_food|cafe|food_court)$"]["name"](around:%d,%f,%f);
+out center tags;`, radius, lat, lng)
+
+	var overpassResp OverpassResponse
+	if err := s.query(ctx, query, &overpassResp); err != nil {
+		return nil, err
+	}
+
+	restaurants := make([]domain.Restaurant, 0, len(overpassResp.Elements))
+	for _, element := range overpassResp.Elements {
+		restaurants = append(restaurants, ConvertOSMElement(element))
+	}
+
+	logger.Info("Overpass 搜尋成功",
+		zap.Int("count", len(restaurants)),
+		zap.Float64("lat", lat),
+		zap.Float64("lng", lng),
+		zap.Int("radius", radius),
+	)
+
+	return restaurants, nil
+}
+
+// GetRestaurantDetails 取得餐廳詳細資訊，placeID 格式為 osm:node/123
+func (s *OverpassService) GetRestaurantDetails(ctx context.Context, placeID string) (*domain.Restaurant, error) {
+	_, id := domain.ParseProviderPlaceID(placeID)
+	elementType, elementID, found := strings.Cut(id, "/")
+	if _, err := strconv.ParseInt(elementID, 10, 64); !found || err != nil {
+		return nil, domain.ErrProviderPlaceNotFound
+	}
+	switch elementType {
+	case "node", "way", "relation":
+	default:
+		return nil, domain.ErrProviderPlaceNotFound
+	}
+
+	query := fmt.Sprintf("[out:json][timeout:25];\n%s(%s);\nout center tags;", elementType, elementID)
+
+	var overpassResp OverpassResponse
+	if err := s.query(ctx, query, &overpassResp); err != nil {
+		return nil, err
+	}
+	if len(overpassResp.Elements) == 0 {
+		logger.Warn("OpenStreetMap 查無此地點", zap.String("place_id", placeID))
+		return nil, domain.ErrProviderPlaceNotFound
+	}
+
+	restaurant := ConvertOSMElement(overpassResp.Elements[0])
+	return &restaurant, nil
+}
+
+// query 發送 Overpass QL 查詢並解析 JSON 回應
+func (s *OverpassService) query(ctx context.Context, query string, out interface{}) error {
+	form := url.Values{"data": {query}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		logger.Error("建立請求失敗", zap.Error(err))
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Error("Overpass API 請求失敗", zap.Error(err))
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIFailed, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		logger.Error("Overpass API 請求過於頻繁")
+		return domain.ErrAPIQuotaExceeded
+	case resp.StatusCode != http.StatusOK:
+		logger.Error("Overpass API HTTP 錯誤", zap.Int("status_code", resp.StatusCode))
+		return fmt.Errorf("%w: Overpass HTTP %d", domain.ErrExternalAPIFailed, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		logger.Error("解析 Overpass API 回應失敗", zap.Error(err))
+		return fmt.Errorf("%w: %v", domain.ErrExternalAPIFailed, err)
+	}
+
+	return nil
+}
+
+// ConvertOSMElement 將 OpenStreetMap 元素轉換為餐廳實體
+// amenity 已不是餐飲類型（例如改為 disused:amenity）時視為永久歇業
+func ConvertOSMElement(element OSMElement) domain.Restaurant {
+	tags := element.Tags
+	lat, lng := element.Lat, element.Lon
+	if element.Center != nil {
+		lat, lng = element.Center.Lat, element.Center.Lon
+	}
+
+	var providerTypes []string
+	var cuisine string
+	for _, value := range strings.Split(tags["cuisine"], ";") {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		if cuisine == "" {
+			cuisine = value
+		}
+		providerTypes = append(providerTypes, value)
+	}
+	if amenity := tags["amenity"]; amenity != "" {
+		providerTypes = append(providerTypes, amenity)
+	}
+
+	businessStatus := domain.ProviderBusinessOperational
+	if !osmFoodAmenities[tags["amenity"]] {
+		businessStatus = domain.ProviderBusinessClosedPermanently
+	}
+
+	phone := tags["phone"]
+	if phone == "" {
+		phone = tags["contact:phone"]
+	}
+
+	return domain.Restaurant{
+		Name:           tags["name"],
+		Address:        osmAddress(tags),
+		Latitude:       lat,
+		Longitude:      lng,
+		Phone:          phone,
+		GoogleID:       domain.ProviderPlaceID(domain.RestaurantSourceOSM, fmt.Sprintf("%s/%d", element.Type, element.ID)),
+		Source:         domain.RestaurantSourceOSM,
+		IsActive:       true,
+		Cuisine:        cuisine,
+		OpeningHours:   ParseOSMOpeningHours(tags["opening_hours"]),
+		ProviderTypes:  providerTypes,
+		ProviderTags:   osmTags(tags),
+		BusinessStatus: businessStatus,
+	}
+}
+
+// osmAddress 組合 OpenStreetMap 的地址標籤
+func osmAddress(tags map[string]string) string {
+	if full := tags["addr:full"]; full != "" {
+		return full
+	}
+
+	var parts []string
+	for _, key := range []string{"addr:city", "addr:district", "addr:suburb", "addr:street", "addr:housenumber"} {
+		if value := strings.TrimSpace(tags[key]); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// osmTags 將 OpenStreetMap 的設施與飲食標籤轉換為標籤識別碼
+func osmTags(tags map[string]string) []string {
+	yes := func(key string) bool {
+		value := tags[key]
+		return value == "yes" || value == "only"
+	}
+
+	var result []string
+	if yes("wheelchair") {
+		result = append(result, "wheelchair_accessible")
+	}
+	if yes("dog") {
+		result = append(result, "pet_friendly")
+	}
+	if internet := tags["internet_access"]; internet == "wlan" || internet == "yes" {
+		result = append(result, "wifi")
+	}
+	for _, diet := range []string{"vegetarian", "vegan", "halal", "gluten_free"} {
+		if yes("diet:" + diet) {
+			result = append(result, diet)
+		}
+	}
+	return result
+}
+
+// osmWeekdays OpenStreetMap opening_hours 的星期縮寫（0 為星期日）
+var osmWeekdays = map[string]int{"Su": 0, "Mo": 1, "Tu": 2, "We": 3, "Th": 4, "Fr": 5, "Sa": 6}
+
+// ParseOSMOpeningHours 解析常見格式的 OpenStreetMap opening_hours，例如「Mo-Fr 11:00-14:00,17:00-21:00; Sa,Su 10:00-22:00」
+// 只支援星期與時段組合及 24/7，遇到無法解析的規則（假日、月份等）時回傳 nil
+func ParseOSMOpeningHours(value string) []domain.OpeningPeriod {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	if value == "24/7" {
+		// 與 Google Places 相同，以沒有結束時間的時段表示 24 小時營業
+		return []domain.OpeningPeriod{{OpenDay: 0, OpenTime: "00:00"}}
+	}
+
+	var periods []domain.OpeningPeriod
+	for _, rule := range strings.Split(value, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		daySpec, timeSpec, found := strings.Cut(rule, " ")
+		if !found {
+			return nil
+		}
+		days, ok := parseOSMDays(daySpec)
+		if !ok {
+			return nil
+		}
+		timeSpec = strings.TrimSpace(timeSpec)
+		if timeSpec == "off" {
+			continue
+		}
+
+		for _, span := range strings.Split(timeSpec, ",") {
+			open, close, found := strings.Cut(strings.TrimSpace(span), "-")
+			if !found || !validOSMTime(open) || !validOSMTime(close) {
+				return nil
+			}
+			for _, day := range days {
+				closeDay := day
+				if close <= open {
+					closeDay = (day + 1) % 7
+				}
+				if close == "24:00" {
+					close, closeDay = "00:00", (day+1)%7
+				}
+				periods = append(periods, domain.OpeningPeriod{OpenDay: day, OpenTime: open, CloseDay: closeDay, CloseTime: close})
+			}
+		}
+	}
+	return periods
+}
+
+// parseOSMDays 解析星期規則，例如 Mo-Fr、Sa,Su
+func parseOSMDays(spec string) ([]int, bool) {
+	var days []int
+	for _, part := range strings.Split(spec, ",") {
+		start, end, isRange := strings.Cut(part, "-")
+		startDay, ok := osmWeekdays[start]
+		if !ok {
+			return nil, false
+		}
+		if !isRange {
+			days = append(days, startDay)
+			continue
+		}
+		endDay, ok := osmWeekdays[end]
+		if !ok {
+			return nil, false
+		}
+		for day := startDay; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == endDay {
+				break
+			}
+		}
+	}
+	return days, true
+}
+
+// validOSMTime 檢查 HH:MM 格式
+func validOSMTime(value string) bool {
+	if len(value) != 5 || value[2] != ':' {
+		return false
+	}
+	hour, errHour := strconv.Atoi(value[:2])
+	minute, errMinute := strconv.Atoi(value[3:])
+	return errHour == nil && errMinute == nil && hour <= 24 && minute < 60
+}
@@ -0,0 +1,132 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+func TestOverpassSearchNearby(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("解析表單失敗: %v", err)
+		}
+		if !strings.Contains(r.PostForm.Get("data"), "around:500,25.") {
+			t.Errorf("query = %s", r.PostForm.Get("data"))
+		}
+		fmt.Fprint(w, `{"elements":[
+			{"type":"node","id":1,"lat":25.03,"lon":121.56,"tags":{"amenity":"restaurant","name":"拉麵店","cuisine":"Ramen; japanese",
+			 "addr:city":"臺北市","addr:street":"松仁路","addr:housenumber":"100號","wheelchair":"yes","diet:vegetarian":"only",
+			 "opening_hours":"Mo-Fr 11:00-14:00,17:00-21:00; Sa 18:00-02:00; Su off"}},
+			{"type":"way","id":2,"center":{"lat":25.04,"lon":121.57},"tags":{"amenity":"cafe","name":"咖啡店","contact:phone":"02 1234 5678"}}
+		]}`)
+	}))
+	defer server.Close()
+
+	restaurants, err := NewOverpassService(server.URL).SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500)
+	if err != nil {
+		t.Fatalf("SearchNearbyRestaurants() error = %v", err)
+	}
+	if len(restaurants) != 2 {
+		t.Fatalf("len = %d, want 2", len(restaurants))
+	}
+
+	ramen := restaurants[0]
+	if ramen.GoogleID != "osm:node/1" || ramen.Source != domain.RestaurantSourceOSM || ramen.Cuisine != "ramen" {
+		t.Errorf("ramen = %+v", ramen)
+	}
+	if ramen.Address != "臺北市 松仁路 100號" {
+		t.Errorf("Address = %q", ramen.Address)
+	}
+	if !reflect.DeepEqual(ramen.ProviderTypes, []string{"ramen", "japanese", "restaurant"}) {
+		t.Errorf("ProviderTypes = %v", ramen.ProviderTypes)
+	}
+	if !reflect.DeepEqual(ramen.ProviderTags, []string{"wheelchair_accessible", "vegetarian"}) {
+		t.Errorf("ProviderTags = %v", ramen.ProviderTags)
+	}
+	// 週一至週五各兩個時段，加上週六跨日時段
+	if len(ramen.OpeningHours) != 11 {
+		t.Fatalf("OpeningHours = %+v", ramen.OpeningHours)
+	}
+	if last := ramen.OpeningHours[10]; last != (domain.OpeningPeriod{OpenDay: 6, OpenTime: "18:00", CloseDay: 0, CloseTime: "02:00"}) {
+		t.Errorf("跨日時段 = %+v", last)
+	}
+
+	cafe := restaurants[1]
+	if cafe.GoogleID != "osm:way/2" || cafe.Latitude != 25.04 || cafe.Phone != "02 1234 5678" {
+		t.Errorf("cafe = %+v", cafe)
+	}
+}
+
+func TestOverpassGetRestaurantDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		switch {
+		case strings.Contains(r.PostForm.Get("data"), "node(1)"):
+			// 已歇業的店家常改為 disused:amenity
+			fmt.Fprint(w, `{"elements":[{"type":"node","id":1,"lat":25.03,"lon":121.56,"tags":{"disused:amenity":"restaurant","name":"拉麵店"}}]}`)
+		case strings.Contains(r.PostForm.Get("data"), "node(3)"):
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"elements":[]}`)
+		}
+	}))
+	defer server.Close()
+	service := NewOverpassService(server.URL)
+
+	restaurant, err := service.GetRestaurantDetails(context.Background(), "osm:node/1")
+	if err != nil {
+		t.Fatalf("GetRestaurantDetails() error = %v", err)
+	}
+	if restaurant.BusinessStatus != domain.ProviderBusinessClosedPermanently {
+		t.Errorf("BusinessStatus = %q", restaurant.BusinessStatus)
+	}
+
+	tests := []struct {
+		placeID string
+		wantErr error
+	}{
+		{placeID: "osm:node/2", wantErr: domain.ErrProviderPlaceNotFound},
+		{placeID: "osm:node/3", wantErr: domain.ErrAPIQuotaExceeded},
+		{placeID: "osm:area/1", wantErr: domain.ErrProviderPlaceNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := service.GetRestaurantDetails(context.Background(), tt.placeID); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.placeID, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseOSMOpeningHours(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []domain.OpeningPeriod
+	}{
+		{value: "24/7", want: []domain.OpeningPeriod{{OpenDay: 0, OpenTime: "00:00"}}},
+		{value: "Sa,Su 10:00-24:00", want: []domain.OpeningPeriod{
+			{OpenDay: 6, OpenTime: "10:00", CloseDay: 0, CloseTime: "00:00"},
+			{OpenDay: 0, OpenTime: "10:00", CloseDay: 1, CloseTime: "00:00"},
+		}},
+		{value: "Fr-Mo 20:00-23:00", want: []domain.OpeningPeriod{
+			{OpenDay: 5, OpenTime: "20:00", CloseDay: 5, CloseTime: "23:00"},
+			{OpenDay: 6, OpenTime: "20:00", CloseDay: 6, CloseTime: "23:00"},
+			{OpenDay: 0, OpenTime: "20:00", CloseDay: 0, CloseTime: "23:00"},
+			{OpenDay: 1, OpenTime: "20:00", CloseDay: 1, CloseTime: "23:00"},
+		}},
+		{value: "Mo-Fr 11:00-21:00; PH off", want: nil},
+		{value: "sunrise-sunset", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseOSMOpeningHours(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseOSMOpeningHours(%q) = %+v, want %+v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+	"github.com/shaunchuang/food-roulette-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultRequestTimeout 未設定逾時時單次請求的逾時時間
+const defaultRequestTimeout = 10 * time.Second
+
+// ResilienceOptions 外部 API 請求的逾時、重試與斷路器設定，零值表示不重試且不使用斷路器
+type ResilienceOptions struct {
+	Timeout          time.Duration // 單次請求逾時，0 表示使用預設 10 秒
+	MaxRetries       int           // 暫時性錯誤（網路錯誤、HTTP 429 與 5xx）的重試次數
+	RetryBaseDelay   time.Duration // 重試等待時間基準，每次重試加倍並加上隨機抖動
+	BreakerThreshold int           // 連續失敗達此次數時開啟斷路器，0 表示停用
+	BreakerCooldown  time.Duration // 斷路器開啟後暫停請求的時間
+}
+
+// QuotaTracker 外部 API 請求額度追蹤，每次送出請求前呼叫，額度用盡時回傳 domain.ErrAPIQuotaExceeded
+type QuotaTracker interface {
+	Reserve(ctx context.Context, provider string) error
+}
+
+// CircuitBreaker 斷路器：連續失敗達門檻後暫停請求，暫停結束後允許一個試探請求，成功即恢復
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool // 試探請求進行中
+	now       func() time.Time
+}
+
+// NewCircuitBreaker 建立斷路器，threshold 小於等於 0 時不會開啟
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	if cooldown <= 0 {
+		cooldown = time.Minute
+	}
+	return &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow 檢查是否可送出請求，斷路器開啟時回傳 domain.ErrProviderUnavailable
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return nil
+	}
+	if b.now().Before(b.openUntil) || b.probing {
+		return domain.ErrProviderUnavailable
+	}
+	b.probing = true
+	return nil
+}
+
+// Success 記錄成功的請求並關閉斷路器
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.threshold > 0 && b.failures >= b.threshold {
+		logger.Info("外部 API 恢復正常，關閉斷路器", zap.String("provider", b.name))
+	}
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure 記錄失敗的請求，連續失敗達門檻或試探請求失敗時開啟斷路器
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+		logger.Warn("外部 API 連續失敗，開啟斷路器",
+			zap.String("provider", b.name),
+			zap.Int("failures", b.failures),
+			zap.Duration("cooldown", b.cooldown),
+		)
+	}
+}
+
+// release 試探請求未送出時釋放試探資格
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State 取得斷路器狀態
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.threshold <= 0 || b.failures < b.threshold:
+		return domain.CircuitClosed
+	case b.now().Before(b.openUntil):
+		return domain.CircuitOpen
+	default:
+		return domain.CircuitHalfOpen
+	}
+}
+
+// resilientClient 具逾時、重試、斷路器與額度控管的 HTTP 用戶端
+type resilientClient struct {
+	provider string
+	client   *http.Client
+	options  ResilienceOptions
+	breaker  *CircuitBreaker
+	quota    QuotaTracker
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+// newResilientClient 建立具逾時、重試與斷路器的 HTTP 用戶端，quota 為 nil 時不控管額度
+func newResilientClient(provider string, options ResilienceOptions, quota QuotaTracker) *resilientClient {
+	if options.Timeout <= 0 {
+		options.Timeout = defaultRequestTimeout
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+
+	return &resilientClient{
+		provider: provider,
+		client:   &http.Client{Timeout: options.Timeout},
+		options:  options,
+		breaker:  NewCircuitBreaker(provider, options.BreakerThreshold, options.BreakerCooldown),
+		quota:    quota,
+		sleep:    sleepContext,
+	}
+}
+
+// do 送出請求，網路錯誤、HTTP 429 與 5xx 依指數退避加隨機抖動重試，有 Retry-After 標頭時依標頭等待
+// newRequest 每次嘗試都會重新建立請求；斷路器開啟或額度用盡時不送出請求並直接回傳錯誤
+// 重試用盡時回傳最後一次的回應或錯誤，由呼叫端轉換為對應的錯誤
+func (c *resilientClient) do(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			return nil, err
+		}
+		if c.quota != nil {
+			if err := c.quota.Reserve(ctx, c.provider); err != nil {
+				c.breaker.release()
+				return nil, err
+			}
+		}
+
+		req, err := newRequest()
+		if err != nil {
+			c.breaker.release()
+			return nil, redactURLError(err)
+		}
+
+		resp, err := c.client.Do(req)
+		err = redactURLError(err)
+		if err != nil && ctx.Err() != nil {
+			// 呼叫端取消不視為外部服務失敗
+			c.breaker.release()
+			return nil, err
+		}
+		if err == nil && !isTransientStatus(resp.StatusCode) {
+			c.breaker.Success()
+			return resp, nil
+		}
+
+		c.breaker.Failure()
+		if attempt >= c.options.MaxRetries {
+			return resp, err
+		}
+
+		delay := c.retryDelay(attempt)
+		if resp != nil {
+			// 伺服器要求的等待時間超過單次請求逾時時不重試，直接回傳回應
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > c.options.Timeout {
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		logger.Warn("外部 API 暫時性錯誤，稍後重試",
+			zap.String("provider", c.provider),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+		if err := c.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay 第 attempt 次重試前的等待時間：基準時間乘以 2^attempt，再取 0.5 至 1.5 倍的隨機抖動
+func (c *resilientClient) retryDelay(attempt int) time.Duration {
+	if c.options.RetryBaseDelay <= 0 {
+		return 0
+	}
+	backoff := c.options.RetryBaseDelay << attempt
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff)+1))
+}
+
+// isTransientStatus 是否為暫時性錯誤的 HTTP 狀態碼（請求過多或伺服器錯誤）
+func isTransientStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter 解析 Retry-After 標頭（秒數或 HTTP 日期）
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := at.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// redactURLError 移除錯誤訊息中網址的查詢參數，避免 API 金鑰出現在日誌與錯誤訊息中
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		parsed.RawQuery = ""
+		urlErr.URL = parsed.String()
+	} else {
+		urlErr.URL = ""
+	}
+	return err
+}
+
+// CircuitState 取得斷路器狀態
+func (c *resilientClient) CircuitState() string {
+	return c.breaker.State()
+}
+
+// isFailFast 是否為未送出請求即失敗的錯誤（額度用盡或斷路器開啟），呼叫端應直接回傳
+func isFailFast(err error) bool {
+	return errors.Is(err, domain.ErrAPIQuotaExceeded) || errors.Is(err, domain.ErrProviderUnavailable)
+}
@@ -0,0 +1,189 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shaunchuang/food-roulette-backend/internal/domain"
+)
+
+// fakeQuota 測試用的請求額度
+type fakeQuota struct {
+	remaining int
+}
+
+func (q *fakeQuota) Reserve(ctx context.Context, provider string) error {
+	if q.remaining <= 0 {
+		return domain.ErrAPIQuotaExceeded
+	}
+	q.remaining--
+	return nil
+}
+
+func TestResilientClientRetry(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newResilientClient("google", ResilienceOptions{MaxRetries: 2, RetryBaseDelay: time.Millisecond}, nil)
+	resp, err := client.do(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || requests != 3 {
+		t.Errorf("status = %d, requests = %d, want 200/3", resp.StatusCode, requests)
+	}
+}
+
+func TestResilientClientRetryAfter(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newResilientClient("google", ResilienceOptions{MaxRetries: 1, RetryBaseDelay: time.Millisecond, BreakerThreshold: 5}, nil)
+	var slept []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	resp, err := client.do(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || requests != 2 {
+		t.Errorf("status = %d, requests = %d, want 200/2", resp.StatusCode, requests)
+	}
+	if len(slept) != 1 || slept[0] != 2*time.Second {
+		t.Errorf("重試等待 = %v, want [2s]", slept)
+	}
+
+	// 超過重試次數的 429 回傳給呼叫端，並計入斷路器的連續失敗
+	requests = 0
+	client.options.MaxRetries = 0
+	resp, err = client.do(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, server.URL, nil)
+	})
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || client.breaker.failures != 1 {
+		t.Errorf("status = %d, failures = %d, want 429/1", resp.StatusCode, client.breaker.failures)
+	}
+}
+
+func TestResilientClientCircuitBreaker(t *testing.T) {
+	var requests int
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := newResilientClient("google", ResilienceOptions{BreakerThreshold: 2, BreakerCooldown: time.Minute}, nil)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.breaker.now = func() time.Time { return now }
+	send := func() (*http.Response, error) {
+		resp, err := client.do(context.Background(), func() (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, server.URL, nil)
+		})
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return resp, err
+	}
+
+	for i := 0; i < 2; i++ {
+		if resp, err := send(); err != nil || resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("第 %d 次請求 resp = %v, err = %v", i+1, resp, err)
+		}
+	}
+	if _, err := send(); !errors.Is(err, domain.ErrProviderUnavailable) {
+		t.Fatalf("斷路器開啟後 error = %v, want %v", err, domain.ErrProviderUnavailable)
+	}
+	if requests != 2 || client.CircuitState() != domain.CircuitOpen {
+		t.Errorf("requests = %d, state = %s", requests, client.CircuitState())
+	}
+
+	// 暫停時間結束後允許試探請求，成功即關閉斷路器
+	now = now.Add(2 * time.Minute)
+	healthy = true
+	if client.CircuitState() != domain.CircuitHalfOpen {
+		t.Errorf("state = %s, want %s", client.CircuitState(), domain.CircuitHalfOpen)
+	}
+	if _, err := send(); err != nil {
+		t.Fatalf("試探請求 error = %v", err)
+	}
+	if client.CircuitState() != domain.CircuitClosed {
+		t.Errorf("state = %s, want %s", client.CircuitState(), domain.CircuitClosed)
+	}
+}
+
+func TestGooglePlacesQuotaExhausted(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"status":"ZERO_RESULTS"}`))
+	}))
+	defer server.Close()
+
+	service := NewGooglePlacesService("test-key", GooglePlacesOptions{Quota: &fakeQuota{remaining: 1}})
+	service.baseURL = server.URL
+
+	if _, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500); err != nil {
+		t.Fatalf("第一次搜尋 error = %v", err)
+	}
+	if _, err := service.SearchNearbyRestaurants(context.Background(), 25.03, 121.56, 500); !errors.Is(err, domain.ErrAPIQuotaExceeded) {
+		t.Errorf("額度用盡後 error = %v, want %v", err, domain.ErrAPIQuotaExceeded)
+	}
+	if requests != 1 {
+		t.Errorf("額度用盡後不應送出請求: requests = %d", requests)
+	}
+}
+
+func TestResilientClientRedactsAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	client := newResilientClient("google", ResilienceOptions{}, nil)
+	_, err := client.do(context.Background(), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, serverURL+"/photo?key=secret-key", nil)
+	})
+	if err == nil {
+		t.Fatal("連線失敗時應回傳錯誤")
+	}
+	if strings.Contains(err.Error(), "secret-key") {
+		t.Errorf("錯誤訊息不應包含 API 金鑰: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package geohash
+
+import (
+	"strings"
+)
+
+// base32 geohash 使用的字元集
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Box geohash 格網的邊界
+type Box struct {
+	MinLat float64 `json:"min_lat"`
+	MaxLat float64 `json:"max_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// Center 取得格網中心點
+func (b Box) Center() (float64, float64) {
+	return (b.MinLat + b.MaxLat) / 2, (b.MinLng + b.MaxLng) / 2
+}
+
+// Encode 將座標編碼為指定精度的 geohash
+func Encode(lat, lng float64, precision int) string {
+	if precision <= 0 {
+		return ""
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	var sb strings.Builder
+	sb.Grow(precision)
+
+	bit, ch := 0, 0
+	even := true
+	for sb.Len() < precision {
+		if even {
+			mid := (minLng + maxLng) / 2
+			if lng >= mid {
+				ch |= 1 << (4 - bit)
+				minLng = mid
+			} else {
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+		} else {
+			sb.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// Decode 取得 geohash 對應的格網邊界，遇到無效字元時回傳 false
+func Decode(hash string) (Box, bool) {
+	box := Box{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180}
+	if hash == "" {
+		return box, false
+	}
+
+	even := true
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(base32, c)
+		if idx < 0 {
+			return box, false
+		}
+		for bit := 4; bit >= 0; bit-- {
+			on := idx&(1<<bit) != 0
+			if even {
+				mid := (box.MinLng + box.MaxLng) / 2
+				if on {
+					box.MinLng = mid
+				} else {
+					box.MaxLng = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if on {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return box, true
+}
+
+// Center 取得 geohash 格網中心點座標
+func Center(hash string) (float64, float64, bool) {
+	box, ok := Decode(hash)
+	if !ok {
+		return 0, 0, false
+	}
+	lat, lng := box.Center()
+	return lat, lng, true
+}
+
+// Valid 檢查 geohash 是否只包含合法字元
+func Valid(hash string) bool {
+	_, ok := Decode(hash)
+	return ok
+}